@@ -34,6 +34,39 @@ type NodeConfigSpec struct {
 	Instance   InstanceOptions   `json:"instance,omitempty"`
 	Kubelet    KubeletOptions    `json:"kubelet,omitempty"`
 	Hybrid     *HybridOptions    `json:"hybrid,omitempty"`
+
+	// Hosts are partial overrides merged into this spec on the hosts matching their selector,
+	// in the order they are listed. This allows a fleet of nodes to share a single NodeConfig.
+	Hosts []HostOverride `json:"hosts,omitempty"`
+}
+
+// HostOverride is a partial spec for the hosts matching Selector.
+type HostOverride struct {
+	// Selector determines the hosts this override applies to.
+	Selector HostSelector `json:"selector"`
+
+	// Kubelet is merged into the kubelet options of the matching hosts.
+	// Flags are appended to the ones in the spec.
+	Kubelet KubeletOptions `json:"kubelet,omitempty"`
+
+	// Hybrid is merged into the hybrid options of the matching hosts.
+	Hybrid *HybridOptions `json:"hybrid,omitempty"`
+}
+
+// HostSelector matches hosts using facts read from the host.
+// At least one field must be set, and all the fields that are set must match.
+type HostSelector struct {
+	// Hostname is a glob pattern matched against the hostname, for example `rack1-*`.
+	Hostname string `json:"hostname,omitempty"`
+
+	// MACAddress matches hosts with a network interface with this MAC address.
+	MACAddress string `json:"macAddress,omitempty"`
+
+	// CIDR matches hosts with an IP address in this CIDR block.
+	CIDR string `json:"cidr,omitempty"`
+
+	// Serial matches hosts with this DMI system serial number.
+	Serial string `json:"serial,omitempty"`
 }
 
 // ClusterDetails contains the coordinates of your EKS cluster.
@@ -84,6 +117,10 @@ type ContainerdOptions struct {
 // InstanceOptions determines how the node's operating system and devices are configured.
 type InstanceOptions struct {
 	LocalStorage LocalStorageOptions `json:"localStorage,omitempty"`
+	// Swap determines whether swap is disabled on the host or left enabled for kubelet to use
+	// with the `LimitedSwap` behavior. Defaults to `disable`. `limited` is only supported on
+	// hybrid nodes running kubelet 1.28 or later on cgroup v2.
+	Swap SwapPolicy `json:"swap,omitempty"`
 }
 
 // LocalStorageOptions control how [EC2 instance stores](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/InstanceStorage.html)
@@ -104,6 +141,18 @@ const (
 	LocalStorageMount LocalStorageStrategy = "Mount"
 )
 
+// SwapPolicy specifies how the swap of the host is handled.
+// +kubebuilder:validation:Enum={disable, limited}
+type SwapPolicy string
+
+const (
+	// SwapPolicyDisable turns swap off and removes the swap entries from /etc/fstab.
+	SwapPolicyDisable SwapPolicy = "disable"
+
+	// SwapPolicyLimited leaves swap enabled and lets pods use it proportionally to their memory requests.
+	SwapPolicyLimited SwapPolicy = "limited"
+)
+
 // HybridOptions defines the options specific to hybrid node enrollment.
 type HybridOptions struct {
 	// EnableCredentialsFile enables a shared credentials file on the host at /eks-hybrid/.aws/credentials
@@ -112,12 +161,21 @@ type HybridOptions struct {
 	EnableCredentialsFile bool `json:"enableCredentialsFile,omitempty"`
 
 	// IAMRolesAnywhere includes IAM Roles Anywhere specific configuration and is mutually exclusive
-	// with SSM.
+	// with SSM and CredentialProcess.
 	IAMRolesAnywhere *IAMRolesAnywhere `json:"iamRolesAnywhere,omitempty"`
 
 	// SSM includes Systems Manager specific configuration and is mutually exclusive with
-	// IAMRolesAnywhere.
+	// IAMRolesAnywhere and CredentialProcess.
 	SSM *SSM `json:"ssm,omitempty"`
+
+	// CredentialProcess configures the node to get AWS credentials from an external command and is
+	// mutually exclusive with SSM and IAMRolesAnywhere.
+	CredentialProcess *CredentialProcess `json:"credentialProcess,omitempty"`
+
+	// Artifacts configures where `nodeadm install` and `nodeadm upgrade` download the release
+	// manifest and the artifacts from. The flags and their environment variables take precedence.
+	// +optional
+	Artifacts *ArtifactSource `json:"artifacts,omitempty"`
 }
 
 // IsHybridNode returns true when the nc.Hybrid configuration is non-nil.
@@ -155,6 +213,8 @@ type IAMRolesAnywhere struct {
 	CertificatePath string `json:"certificatePath,omitempty"`
 
 	// PrivateKeyPath is the location on disk for the certificate's private key.
+	// It can also be a reference to a secret: `env:VAR`, `file:/path`,
+	// `ssm-parameter:/name` or `secretsmanager:arn`.
 	// +optional
 	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
 }
@@ -164,8 +224,47 @@ type IAMRolesAnywhere struct {
 // During activation an IAM role is chosen for the SSM agent to assume. This is not overridable from the agent.
 type SSM struct {
 	// ActivationCode is the token generated when creating an SSM activation.
+	// It can also be a reference to a secret: `env:VAR`, `file:/path`,
+	// `ssm-parameter:/name` or `secretsmanager:arn`.
 	ActivationCode string `json:"activationCode,omitempty"`
 
 	// ActivationToken is the ID generated when creating an SSM activation.
+	// It can also be a reference to a secret: `env:VAR`, `file:/path`,
+	// `ssm-parameter:/name` or `secretsmanager:arn`.
 	ActivationID string `json:"activationId,omitempty"`
 }
+
+// CredentialProcess defines the configuration to get AWS credentials from an external command,
+// like a Vault or SPIFFE based credential broker already running on the host.
+// The command must print credentials in the format described in
+// https://docs.aws.amazon.com/sdkref/latest/guide/feature-process-credentials.html
+type CredentialProcess struct {
+	// NodeName is the name the node will adopt.
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Command is the command line that prints the AWS credentials. It is set as the
+	// credential_process of the AWS config used by the node.
+	Command string `json:"command,omitempty"`
+
+	// AwsConfigPath is the path where the Aws config is stored for hybrid nodes.
+	// +optional
+	AwsConfigPath string `json:"awsConfigPath,omitempty"`
+}
+
+// ArtifactSource defines where the release manifest and the artifacts are downloaded from,
+// for example a private mirror of the EKS artifacts. Credentials for the mirror are only
+// read from the environment of nodeadm.
+type ArtifactSource struct {
+	// ManifestURL is the URL of the release manifest.
+	// +optional
+	ManifestURL string `json:"manifestUrl,omitempty"`
+
+	// MirrorURL is the URL prefix of a mirror that replaces the host of every artifact
+	// in the release manifest.
+	// +optional
+	MirrorURL string `json:"mirrorUrl,omitempty"`
+
+	// MirrorCABundle is the path to a PEM CA bundle to trust when downloading from the mirror.
+	// +optional
+	MirrorCABundle string `json:"mirrorCaBundle,omitempty"`
+}
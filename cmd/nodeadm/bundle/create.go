@@ -0,0 +1,73 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+type createCmd struct {
+	cmd               *flaggy.Subcommand
+	kubernetesVersion string
+	output            string
+	arch              string
+	artifactSource    cli.ArtifactSourceFlags
+	timeout           time.Duration
+}
+
+func NewCreateCommand() cli.Command {
+	create := createCmd{
+		arch:    runtime.GOARCH,
+		timeout: 20 * time.Minute,
+	}
+	create.cmd = flaggy.NewSubcommand("create")
+	create.cmd.Description = "Download the release manifest and all artifacts for a Kubernetes version into a bundle"
+	create.cmd.AddPositionalValue(&create.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to bundle.")
+	create.cmd.String(&create.output, "o", "output", "Path of the bundle file to create. Defaults to nodeadm-bundle-<KUBERNETES_VERSION>-<ARCH>.tgz in the current directory.")
+	create.cmd.String(&create.arch, "a", "arch", "Architecture of the bundled artifacts. Allowed values: [amd64, arm64].")
+	create.cmd.Duration(&create.timeout, "t", "timeout", "Maximum bundle command duration. Input follows duration format. Example: 1h23s")
+	create.artifactSource.RegisterFlags(create.cmd)
+	return &create
+}
+
+func (c *createCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *createCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if c.output == "" {
+		c.output = fmt.Sprintf("nodeadm-bundle-%s-%s.tgz", c.kubernetesVersion, c.arch)
+	}
+
+	fh, err := os.Create(c.output)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	log.Info("Creating bundle", zap.String("kubernetes version", c.kubernetesVersion), zap.String("arch", c.arch), zap.String("output", c.output))
+	source, err := aws.CreateBundle(ctx, c.kubernetesVersion, c.arch, fh, c.artifactSource.SourceOptions(nil)...)
+	if err != nil {
+		fh.Close()
+		os.Remove(c.output)
+		return err
+	}
+
+	log.Info("Bundle created", zap.String("kubernetes version", source.Eks.Version), zap.String("output", c.output))
+	return nil
+}
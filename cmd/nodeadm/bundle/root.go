@@ -0,0 +1,19 @@
+package bundle
+
+import (
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const bundleHelpText = `Examples:
+  # Create a bundle with all the artifacts required to install Kubernetes version 1.31
+  nodeadm bundle create 1.31 --output /tmp/nodeadm-bundle-1.31.tgz
+
+  # Install from the bundle on a host without internet access
+  nodeadm install 1.31 --credential-provider iam-ra --bundle /tmp/nodeadm-bundle-1.31.tgz`
+
+func NewBundleCommand() cli.Command {
+	container := cli.NewCommandContainer("bundle", "Manage offline artifact bundles")
+	container.Flaggy().AdditionalHelpAppend = bundleHelpText
+	container.AddCommand(NewCreateCommand())
+	return container.AsCommand()
+}
@@ -1,12 +1,21 @@
 package config
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/validation"
 )
 
 type fileCmd struct {
@@ -18,7 +27,7 @@ func NewCheckCommand() cli.Command {
 	file := fileCmd{}
 	file.cmd = flaggy.NewSubcommand("check")
 	file.cmd.Description = "Verify configuration"
-	file.cmd.String(&file.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	file.cmd.String(&file.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
 	return &file
 }
 
@@ -27,17 +36,20 @@ func (c *fileCmd) Flaggy() *flaggy.Subcommand {
 }
 
 func (c *fileCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
 	log.Info("Checking configuration", zap.String("source", c.configSource))
 	provider, err := configprovider.BuildConfigProvider(c.configSource)
 	if err != nil {
 		return err
 	}
-	_, err = provider.Provide()
+	nodeConfig, err := provider.Provide()
 	if err != nil {
 		return err
 	}
 
-	nodeProvider, err := node.NewNodeProvider(c.configSource, []string{}, log)
+	nodeProvider, err := node.NewNodeProviderFromConfig(nodeConfig, []string{}, log)
 	if err != nil {
 		return err
 	}
@@ -46,6 +58,18 @@ func (c *fileCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return err
 	}
 
+	runner := validation.NewRunner[*api.NodeConfig](validation.NewPrinter())
+	runner.Register(
+		validation.New("kubelet-config", kubelet.ValidateConfig),
+		validation.New("kubelet-flags", kubelet.ValidateFlags),
+		validation.New("containerd-config", containerd.ValidateConfig),
+	)
+	if err := runner.Sequentially(ctx, nodeConfig); err != nil {
+		fmt.Println("")
+		fmt.Println("Issues found in the configuration. Please follow the remediation advice above.")
+		return errors.NewSilent(err)
+	}
+
 	log.Info("Configuration is valid")
 	return nil
 }
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/daemon"
+)
+
+const hybridNodeConfig = `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+  hybrid:
+    ssm:
+      activationCode: abcdefghijklmnopqrst
+      activationId: 01234567-89ab-cdef-0123-456789abcdef
+`
+
+func TestCheckReadsStdinOnce(t *testing.T) {
+	g := NewWithT(t)
+	manager, err := daemon.NewDaemonManager()
+	if err != nil {
+		t.Skipf("hybrid node providers need a daemon manager: %v", err)
+	}
+	manager.Close()
+
+	stdinPath := filepath.Join(t.TempDir(), "nodeconfig.yaml")
+	g.Expect(os.WriteFile(stdinPath, []byte(hybridNodeConfig), 0o644)).To(Succeed())
+	stdin, err := os.Open(stdinPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer stdin.Close()
+	originalStdin := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() { os.Stdin = originalStdin })
+
+	check := &fileCmd{configSource: configprovider.StdinSource}
+	g.Expect(check.Run(zap.NewNop(), &cli.GlobalOptions{})).To(Succeed())
+}
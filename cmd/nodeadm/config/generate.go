@@ -0,0 +1,124 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configgen"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/logger"
+)
+
+const generateHelpText = `Examples:
+  # Answer questions to generate the configuration of a node
+  nodeadm config generate --interactive --output nodeConfig.yaml
+
+  # Generate the configuration of a node using SSM hybrid activations
+  nodeadm config generate --credential-provider ssm --cluster-name my-cluster --region us-west-2 \
+    --activation-code <code> --activation-id <id> --output nodeConfig.yaml
+
+  # Generate the configuration of a node using IAM Roles Anywhere, discovering the cluster
+  # with the AWS credentials of this host
+  nodeadm config generate --credential-provider iam-ra --discover --node-name my-node \
+    --trust-anchor-arn <arn> --profile-arn <arn> --role-arn <arn>
+
+The configuration is validated before it's written. For IAM Roles Anywhere, the certificate
+and private key must exist on this host.`
+
+type generateCmd struct {
+	cmd         *flaggy.Subcommand
+	opts        configgen.Options
+	output      string
+	interactive bool
+	discover    bool
+	force       bool
+}
+
+func NewGenerateCommand() cli.Command {
+	generate := generateCmd{}
+	generate.cmd = flaggy.NewSubcommand("generate")
+	generate.cmd.Description = "Generate the configuration of a hybrid node"
+	generate.cmd.String(&generate.opts.CredentialProvider, "p", "credential-provider", fmt.Sprintf("Credential provider of the node. Allowed values: [%s, %s].", configgen.CredentialProviderSSM, configgen.CredentialProviderIAMRolesAnywhere))
+	generate.cmd.String(&generate.opts.ClusterName, "", "cluster-name", "Name of the EKS cluster.")
+	generate.cmd.String(&generate.opts.Region, "", "region", "AWS region of the EKS cluster.")
+	generate.cmd.String(&generate.opts.ActivationCode, "", "activation-code", "SSM hybrid activation code.")
+	generate.cmd.String(&generate.opts.ActivationID, "", "activation-id", "SSM hybrid activation ID.")
+	generate.cmd.String(&generate.opts.NodeName, "", "node-name", "Name of the node, used as the IAM Roles Anywhere session name.")
+	generate.cmd.String(&generate.opts.TrustAnchorARN, "", "trust-anchor-arn", "ARN of the IAM Roles Anywhere trust anchor.")
+	generate.cmd.String(&generate.opts.ProfileARN, "", "profile-arn", "ARN of the IAM Roles Anywhere profile.")
+	generate.cmd.String(&generate.opts.RoleARN, "", "role-arn", "ARN of the IAM role assumed by the node.")
+	generate.cmd.String(&generate.opts.CertificatePath, "", "certificate-path", fmt.Sprintf("Path to the IAM Roles Anywhere certificate. Defaults to %s.", iamrolesanywhere.DefaultCertificatePath))
+	generate.cmd.String(&generate.opts.PrivateKeyPath, "", "private-key-path", fmt.Sprintf("Path to the IAM Roles Anywhere private key. Defaults to %s.", iamrolesanywhere.DefaultPrivateKeyPath))
+	generate.cmd.String(&generate.output, "o", "output", "File to write the configuration to. Defaults to stdout.")
+	generate.cmd.Bool(&generate.interactive, "i", "interactive", "Ask for the values that are not set with flags.")
+	generate.cmd.Bool(&generate.discover, "", "discover", "Discover the region and cluster with the AWS credentials of this host, and check the cluster is active.")
+	generate.cmd.Bool(&generate.force, "f", "force", "Overwrite the output file if it exists.")
+	generate.cmd.AdditionalHelpAppend = generateHelpText
+	return &generate
+}
+
+func (c *generateCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *generateCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	if c.output != "" && !c.force {
+		if _, err := os.Stat(c.output); err == nil {
+			return fmt.Errorf("output file %s already exists, use --force to overwrite it", c.output)
+		}
+	}
+
+	var awsConfig aws.Config
+	if c.discover {
+		var err error
+		if awsConfig, err = config.LoadDefaultConfig(ctx); err != nil {
+			return err
+		}
+		if err := configgen.DiscoverCluster(ctx, awsConfig, &c.opts); err != nil {
+			return err
+		}
+	}
+
+	if c.interactive {
+		if err := configgen.NewPrompter(os.Stdin, os.Stderr).Complete(&c.opts); err != nil {
+			return err
+		}
+	}
+
+	if c.discover {
+		log.Info("Checking EKS cluster", zap.String("cluster", c.opts.ClusterName), zap.String("region", c.opts.Region))
+		if err := configgen.VerifyCluster(ctx, awsConfig, c.opts); err != nil {
+			return err
+		}
+	}
+
+	nodeConfig, err := configgen.Generate(c.opts)
+	if err != nil {
+		return err
+	}
+	data, err := configgen.Marshal(nodeConfig)
+	if err != nil {
+		return err
+	}
+
+	if c.output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	// The configuration can hold the SSM activation code.
+	if err := os.WriteFile(c.output, data, 0o600); err != nil {
+		return err
+	}
+	log.Info("Configuration written", zap.String("path", c.output))
+	return nil
+}
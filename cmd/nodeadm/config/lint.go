@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api/schema"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/node/ec2"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
+)
+
+const lintHelpText = `Examples:
+  # Lint a configuration file
+  nodeadm config lint --file nodeConfig.yaml
+
+  # Lint the configuration of several sites, each merged from a directory of files
+  nodeadm config lint --file sites/seattle/ --file sites/dublin/
+
+Every file is validated against the NodeConfig schema. Then the configuration
+read from each --file, merged like the file config source does for directories,
+is validated with the same rules as init. Linting doesn't access the network, but
+the IAM Roles Anywhere certificate and private key need to exist on this host.`
+
+type lintCmd struct {
+	cmd   *flaggy.Subcommand
+	paths []string
+}
+
+func NewLintCommand() cli.Command {
+	lint := lintCmd{}
+	lint.cmd = flaggy.NewSubcommand("lint")
+	lint.cmd.Description = "Validate configuration files offline"
+	lint.cmd.StringSlice(&lint.paths, "f", "file", "Configuration file, or directory of *.yaml files, to lint. Can be repeated.")
+	lint.cmd.AdditionalHelpAppend = lintHelpText
+	return &lint
+}
+
+func (c *lintCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *lintCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	if len(c.paths) == 0 {
+		flaggy.ShowHelpAndExit("--file is a required flag.")
+	}
+
+	validator, err := schema.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	var issues int
+	for _, path := range c.paths {
+		errs := lint(validator, path)
+		for _, err := range errs {
+			fmt.Printf("%s: %s\n", path, err)
+		}
+		issues += len(errs)
+	}
+
+	if issues > 0 {
+		return errors.NewSilent(fmt.Errorf("found %d issues in the configuration", issues))
+	}
+	log.Info("Configuration is valid", zap.Strings("paths", c.paths))
+	return nil
+}
+
+// lint validates every file read for path against the schema and, if they are valid,
+// the merged configuration against the node rules.
+func lint(validator *schema.Validator, path string) []error {
+	files, err := configprovider.ConfigFiles(path)
+	if err != nil {
+		return []error{err}
+	}
+	if len(files) == 0 {
+		return []error{fmt.Errorf("no configuration files found")}
+	}
+
+	var errs []error
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, err := range validator.Validate(data) {
+			if file != path {
+				err = fmt.Errorf("%s: %w", file, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	nodeConfig, err := configprovider.NewFileConfigProvider(path).Provide()
+	if err != nil {
+		return []error{err}
+	}
+	if nodeConfig.IsHybridNode() {
+		hybrid.PopulateNodeConfigDefaults(nodeConfig)
+		err = hybrid.ValidateNodeConfig(nodeConfig)
+	} else {
+		err = ec2.ValidateNodeConfig(nodeConfig)
+	}
+	if err != nil {
+		return []error{err}
+	}
+	return nil
+}
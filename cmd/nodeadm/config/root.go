@@ -7,6 +7,15 @@ import (
 const configHelpText = `Examples:
   # Check configuration file
   nodeadm config check --config-source file:///root/nodeConfig.yaml
+
+  # Show the effective configuration, with the kubelet flags and configuration
+  nodeadm config view --config-source file:///root/nodeConfig.yaml --enrich
+
+  # Generate the configuration of a hybrid node
+  nodeadm config generate --interactive --output nodeConfig.yaml
+
+  # Lint configuration files offline
+  nodeadm config lint --file nodeConfig.yaml
   
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_config_check`
@@ -15,5 +24,9 @@ func NewConfigCommand() cli.Command {
 	container := cli.NewCommandContainer("config", "Manage configuration")
 	container.Flaggy().AdditionalHelpAppend = configHelpText
 	container.AddCommand(NewCheckCommand())
+	container.AddCommand(NewViewCommand())
+	container.AddCommand(NewSchemaCommand())
+	container.AddCommand(NewLintCommand())
+	container.AddCommand(NewGenerateCommand())
 	return container.AsCommand()
 }
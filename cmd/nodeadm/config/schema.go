@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api/schema"
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const schemaHelpText = `Examples:
+  # Write the JSON Schema of NodeConfig to use it in an editor
+  nodeadm config schema > nodeconfig.schema.json
+
+  # Print the OpenAPI v3 schema of NodeConfig
+  nodeadm config schema --format openapi
+
+Editors using the YAML language server can validate configuration files by adding
+the following comment at the top of the file:
+  # yaml-language-server: $schema=./nodeconfig.schema.json`
+
+type schemaCmd struct {
+	cmd    *flaggy.Subcommand
+	format string
+}
+
+func NewSchemaCommand() cli.Command {
+	cmd := schemaCmd{
+		format: schema.FormatJSONSchema,
+	}
+	cmd.cmd = flaggy.NewSubcommand("schema")
+	cmd.cmd.Description = "Print the schema of the configuration"
+	cmd.cmd.String(&cmd.format, "f", "format", fmt.Sprintf("Format of the schema. Allowed values: [%s, %s].", schema.FormatJSONSchema, schema.FormatOpenAPI))
+	cmd.cmd.AdditionalHelpAppend = schemaHelpText
+	return &cmd
+}
+
+func (c *schemaCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *schemaCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	var document map[string]any
+	var err error
+	switch c.format {
+	case schema.FormatJSONSchema:
+		document, err = schema.JSONSchema()
+	case schema.FormatOpenAPI:
+		document, err = schema.OpenAPI()
+	default:
+		return fmt.Errorf("invalid schema format %s, supported formats: [%s, %s]", c.format, schema.FormatJSONSchema, schema.FormatOpenAPI)
+	}
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/logging"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/node/ec2"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
+	"github.com/aws/eks-hybrid/internal/nodeprovider"
+	"github.com/aws/eks-hybrid/internal/secrets"
+)
+
+const viewHelpText = `Examples:
+  # Show the configuration with the defaults applied
+  nodeadm config view --config-source file:///root/nodeConfig.yaml
+
+  # Show the configuration enriched with the cluster details and the kubelet configuration
+  nodeadm config view --config-source file:///root/nodeConfig.yaml --enrich
+
+Enriching the configuration reads the cluster details from the EKS API with the node
+credentials, and the kubelet version from the installed kubelet.`
+
+type viewCmd struct {
+	cmd          *flaggy.Subcommand
+	configSource string
+	enrich       bool
+}
+
+// effectiveConfig is the configuration printed by view.
+type effectiveConfig struct {
+	NodeConfig *api.NodeConfig          `json:"nodeConfig"`
+	Kubelet    *kubelet.EffectiveConfig `json:"kubelet,omitempty"`
+}
+
+func NewViewCommand() cli.Command {
+	view := viewCmd{}
+	view.cmd = flaggy.NewSubcommand("view")
+	view.cmd.Description = "Show the effective configuration"
+	view.cmd.String(&view.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
+	view.cmd.Bool(&view.enrich, "", "enrich", "Enrich the configuration with the cluster details and show the kubelet flags and configuration.")
+	view.cmd.AdditionalHelpAppend = viewHelpText
+	return &view
+}
+
+func (c *viewCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *viewCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
+	}
+
+	provider, err := configprovider.BuildConfigProvider(c.configSource)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+
+	view := effectiveConfig{NodeConfig: nodeConfig}
+	if !c.enrich {
+		if nodeConfig.IsHybridNode() {
+			hybrid.PopulateNodeConfigDefaults(nodeConfig)
+		}
+		return printEffectiveConfig(os.Stdout, view)
+	}
+
+	nodeProvider, err := newNodeProvider(ctx, nodeConfig, log)
+	if err != nil {
+		return err
+	}
+	defer nodeProvider.Cleanup()
+
+	nodeProvider.PopulateNodeConfigDefaults()
+	if err := nodeProvider.ValidateConfig(); err != nil {
+		return err
+	}
+	if err := nodeProvider.Enrich(ctx); err != nil {
+		return err
+	}
+	if view.Kubelet, err = kubelet.GetEffectiveConfig(nodeConfig); err != nil {
+		return fmt.Errorf("rendering kubelet configuration: %w", err)
+	}
+
+	return printEffectiveConfig(os.Stdout, view)
+}
+
+// newNodeProvider builds the provider used to enrich nodeConfig. Hybrid nodes read the
+// AWS config the node is already using instead of configuring it, view must not make
+// any change to the node.
+func newNodeProvider(ctx context.Context, nodeConfig *api.NodeConfig, log *zap.Logger) (nodeprovider.NodeProvider, error) {
+	if !nodeConfig.IsHybridNode() {
+		return ec2.NewEc2NodeProvider(nodeConfig, log)
+	}
+	awsConfig, err := creds.ReadConfig(ctx, nodeConfig, config.WithLogger(logging.Nop{}))
+	if err != nil {
+		return nil, err
+	}
+	return hybrid.NewHybridNodeProvider(nodeConfig, nil, log, hybrid.WithAWSConfig(&awsConfig))
+}
+
+// printEffectiveConfig writes view as YAML with the secrets it contains redacted.
+func printEffectiveConfig(w io.Writer, view effectiveConfig) error {
+	view.NodeConfig = redactNodeConfig(view.NodeConfig)
+	data, err := yaml.Marshal(view)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, secrets.Redact(string(data)))
+	return err
+}
+
+// redactNodeConfig returns a copy of node without the secrets set inline, the
+// resolved secret references are redacted when printing.
+func redactNodeConfig(node *api.NodeConfig) *api.NodeConfig {
+	node = node.DeepCopy()
+	if node.Spec.Hybrid != nil && node.Spec.Hybrid.SSM != nil && node.Spec.Hybrid.SSM.ActivationCode != "" {
+		node.Spec.Hybrid.SSM.ActivationCode = secrets.Redacted
+	}
+	return node
+}
@@ -0,0 +1,16 @@
+package credentials
+
+import (
+	"github.com/aws/eks-hybrid/internal/cli"
+)
+
+const credentialsHelpText = `Examples:
+  # Rotate the IAM Roles Anywhere certificate of the node
+  nodeadm credentials rotate --certificate /tmp/server.pem --private-key /tmp/server.key --config-source file:///root/nodeConfig.yaml`
+
+func NewCredentialsCommand() cli.Command {
+	container := cli.NewCommandContainer("credentials", "Manage the credentials used by the node")
+	container.Flaggy().AdditionalHelpAppend = credentialsHelpText
+	container.AddCommand(NewRotateCommand())
+	return container.AsCommand()
+}
@@ -0,0 +1,188 @@
+package credentials
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/rolesanywhere"
+	"github.com/integrii/flaggy"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/aws/sts"
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
+)
+
+const rotateHelpText = `Examples:
+  # Rotate the certificate, validating it against the CA certificates of the trust anchor
+  nodeadm credentials rotate --certificate /tmp/server.pem --private-key /tmp/server.key --config-source file:///root/nodeConfig.yaml
+
+  # Rotate the certificate of a trust anchor backed by ACM Private CA
+  nodeadm credentials rotate --certificate /tmp/server.pem --private-key /tmp/server.key --ca-bundle /tmp/ca.pem --config-source file:///root/nodeConfig.yaml`
+
+func NewRotateCommand() cli.Command {
+	cmd := rotate{
+		timeout: 2 * time.Minute,
+	}
+	cmd.flaggy = flaggy.NewSubcommand("rotate")
+	cmd.flaggy.Description = "Replace the IAM Roles Anywhere certificate and private key of the node"
+	cmd.flaggy.AdditionalHelpAppend = rotateHelpText
+	cmd.flaggy.String(&cmd.certificate, "", "certificate", "Path to the new PEM encoded certificate. It can include intermediate certificates after the node certificate.")
+	cmd.flaggy.String(&cmd.privateKey, "", "private-key", "Path to the new PEM encoded private key.")
+	cmd.flaggy.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
+	cmd.flaggy.String(&cmd.caBundle, "", "ca-bundle", "Path to the PEM encoded CA certificates of the trust anchor. Required when the trust anchor is backed by ACM Private CA. Defaults to the certificates configured in the trust anchor.")
+	cmd.flaggy.Duration(&cmd.timeout, "t", "timeout", "Maximum time to wait for AWS to accept the new certificate. Input follows duration format. Example: 1m")
+	return &cmd
+}
+
+type rotate struct {
+	flaggy       *flaggy.Subcommand
+	certificate  string
+	privateKey   string
+	configSource string
+	caBundle     string
+	timeout      time.Duration
+}
+
+func (c *rotate) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *rotate) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	}
+	if !root {
+		return cli.ErrMustRunAsRoot
+	}
+
+	if c.certificate == "" || c.privateKey == "" {
+		flaggy.ShowHelpAndExit("--certificate and --private-key are required flags")
+	}
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin." +
+			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
+	}
+
+	provider, err := configprovider.BuildConfigProvider(c.configSource)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+	if !nodeConfig.IsIAMRolesAnywhere() {
+		return fmt.Errorf("certificate rotation is only supported for nodes using IAM Roles Anywhere")
+	}
+	hybrid.PopulateNodeConfigDefaults(nodeConfig)
+	rolesAnywhere := nodeConfig.Spec.Hybrid.IAMRolesAnywhere
+
+	certificate, err := os.ReadFile(c.certificate)
+	if err != nil {
+		return errors.Wrap(err, "reading certificate")
+	}
+	privateKey, err := os.ReadFile(c.privateKey)
+	if err != nil {
+		return errors.Wrap(err, "reading private key")
+	}
+
+	roots, err := c.trustAnchorCertPool(ctx, nodeConfig)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Validating new certificate...")
+	leaf, err := iamrolesanywhere.ValidateCertificate(certificate, privateKey, roots)
+	if err != nil {
+		return err
+	}
+	log.Info("New certificate is valid", zap.String("subject", leaf.Subject.String()), zap.Time("notAfter", leaf.NotAfter))
+
+	oldCertificate, err := os.ReadFile(rolesAnywhere.CertificatePath)
+	if err != nil {
+		return errors.Wrap(err, "reading current certificate")
+	}
+	oldPrivateKey, err := os.ReadFile(iamrolesanywhere.PrivateKeyFile(rolesAnywhere.PrivateKeyPath))
+	if err != nil {
+		return errors.Wrap(err, "reading current private key")
+	}
+
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+
+	log.Info("Replacing certificate...", zap.String("certificate", rolesAnywhere.CertificatePath), zap.String("privateKey", iamrolesanywhere.PrivateKeyFile(rolesAnywhere.PrivateKeyPath)))
+	if err := c.install(ctx, log, daemonManager, nodeConfig, certificate, privateKey); err != nil {
+		log.Error("New certificate was not accepted, restoring the previous one", zap.Error(err))
+		if restoreErr := c.install(ctx, log, daemonManager, nodeConfig, oldCertificate, oldPrivateKey); restoreErr != nil {
+			return fmt.Errorf("%w; restoring previous certificate: %v", err, restoreErr)
+		}
+		return err
+	}
+
+	log.Info("Certificate rotated")
+	return nil
+}
+
+// trustAnchorCertPool returns the CA certificates in --ca-bundle or, if not set, the ones in the trust anchor.
+// Getting the trust anchor uses the current certificate, so it needs to still be valid.
+func (c *rotate) trustAnchorCertPool(ctx context.Context, nodeConfig *api.NodeConfig) (*x509.CertPool, error) {
+	if c.caBundle != "" {
+		return iamrolesanywhere.ReadCertPool(c.caBundle)
+	}
+
+	awsConfig, err := creds.ReadConfig(ctx, nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := iamrolesanywhere.TrustAnchorCertPool(ctx, rolesanywhere.NewFromConfig(awsConfig), nodeConfig.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading CA certificates from trust anchor, use --ca-bundle to provide them")
+	}
+	return pool, nil
+}
+
+// install swaps the certificate files, refreshes the credentials file and verifies
+// AWS accepts the certificate.
+func (c *rotate) install(ctx context.Context, log *zap.Logger, daemonManager daemon.DaemonManager, nodeConfig *api.NodeConfig, certificate, privateKey []byte) error {
+	rolesAnywhere := nodeConfig.Spec.Hybrid.IAMRolesAnywhere
+	if err := iamrolesanywhere.ReplaceCertificate(rolesAnywhere.CertificatePath, iamrolesanywhere.PrivateKeyFile(rolesAnywhere.PrivateKeyPath), certificate, privateKey); err != nil {
+		return err
+	}
+
+	if nodeConfig.Spec.Hybrid.EnableCredentialsFile {
+		log.Info("Restarting aws_signing_helper_update daemon...")
+		if err := daemonManager.RestartDaemon(ctx, iamrolesanywhere.DaemonName); err != nil {
+			return errors.Wrap(err, "restarting aws_signing_helper_update daemon")
+		}
+	}
+
+	log.Info("Verifying access to AWS STS...")
+	verifyCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	awsConfig, err := creds.ReadConfig(verifyCtx, nodeConfig)
+	if err != nil {
+		return err
+	}
+	if err := sts.CheckAuthentication(verifyCtx, awsConfig); err != nil {
+		return errors.Wrap(err, "authenticating with AWS STS using the new certificate")
+	}
+	return nil
+}
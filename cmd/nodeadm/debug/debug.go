@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/smithy-go/logging"
@@ -16,6 +17,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/kubernetes"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
@@ -26,23 +28,39 @@ const debugHelpText = `Examples:
   # Debug using a local config file
   nodeadm debug --config-source file://nodeConfig.yaml
 
+  # Warn if the IAM Roles Anywhere certificate expires in the next 60 days
+  nodeadm debug --config-source file://nodeConfig.yaml --certificate-expiry-warning-days 60
+
+  # Write the validation results as a JUnit report
+  nodeadm debug --config-source file://nodeConfig.yaml --output junit > nodeadm-debug.xml
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_debug`
 
 func NewCommand() cli.Command {
-	debug := debug{}
+	debug := debug{
+		output:                       validation.OutputText,
+		certificateExpiryWarningDays: iamrolesanywhere.DefaultCertificateExpiryWarningDays,
+		validationTimeout:            30 * time.Second,
+	}
 	debug.cmd = flaggy.NewSubcommand("debug")
-	debug.cmd.String(&debug.nodeConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	debug.cmd.String(&debug.nodeConfigSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
 	debug.cmd.Bool(&debug.noColor, "", "no-color", "If set, suppresses color output.")
+	debug.cmd.String(&debug.output, "o", "output", "Output format. Allowed values: [text, json, junit]. The json and junit formats are written to stdout once all validations finish.")
+	debug.cmd.Int(&debug.certificateExpiryWarningDays, "", "certificate-expiry-warning-days", "Warn when the IAM Roles Anywhere certificate expires in less than this number of days.")
+	debug.cmd.Duration(&debug.validationTimeout, "", "validation-timeout", "Maximum time each validation can take. Input follows duration format. Example: 1m")
 	debug.cmd.Description = "Debug the node registration process"
 	debug.cmd.AdditionalHelpPrepend = debugHelpText
 	return &debug
 }
 
 type debug struct {
-	cmd              *flaggy.Subcommand
-	nodeConfigSource string
-	noColor          bool
+	cmd                          *flaggy.Subcommand
+	nodeConfigSource             string
+	noColor                      bool
+	output                       string
+	certificateExpiryWarningDays int
+	validationTimeout            time.Duration
 }
 
 func (c *debug) Flaggy() *flaggy.Subcommand {
@@ -54,10 +72,16 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx = logger.NewContext(ctx, log)
 
 	if c.nodeConfigSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
+	switch c.output {
+	case validation.OutputText, validation.OutputJSON, validation.OutputJUnit:
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats: [%s, %s, %s]", c.output, validation.OutputText, validation.OutputJSON, validation.OutputJUnit)
+	}
+
 	provider, err := configprovider.BuildConfigProvider(c.nodeConfigSource)
 	if err != nil {
 		return err
@@ -72,37 +96,58 @@ func (c *debug) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return err
 	}
 
-	printer := validation.NewPrinterWithStdCapture("stderr", c.noColor)
-	if err := printer.Init(); err != nil {
-		return err
+	var informer validation.Informer
+	var recorder *validation.Recorder
+	if c.output == validation.OutputText {
+		printer := validation.NewPrinterWithStdCapture("stderr", c.noColor)
+		if err := printer.Init(); err != nil {
+			return err
+		}
+		defer printer.Close()
+
+		// We want to capture stderr and let the printer control it.
+		// When the AWS SDK calls the credentials_process for IAM Roles Anywhere
+		// or when the k8s client-go calls the aws-iam-authenticator binary, those processes
+		// output to stderr and those logs are not returned to the caller in the go error.
+		// In order to not have interfere with the printer logs or get lost,
+		// we just override the global stderr and restore after we are done running validations.
+		originalStderr := os.Stderr
+		defer func() { os.Stderr = originalStderr }()
+		os.Stderr = printer.File
+		informer = printer
+	} else {
+		// Machine-readable reports go to stdout, so external processes can keep writing to stderr.
+		recorder = validation.NewRecorder()
+		informer = recorder
 	}
-	defer printer.Close()
-
-	// We want to capture stderr and let the printer control it.
-	// When the AWS SDK calls the credentials_process for IAM Roles Anywhere
-	// or when the k8s client-go calls the aws-iam-authenticator binary, those processes
-	// output to stderr and those logs are not returned to the caller in the go error.
-	// In order to not have interfere with the printer logs or get lost,
-	// we just override the global stderr and restore after we are done running validations.
-	originalStderr := os.Stderr
-	defer func() { os.Stderr = originalStderr }()
-	os.Stderr = printer.File
-
-	runner := validation.NewRunner[*api.NodeConfig](printer)
+
+	runner := validation.NewRunner[*api.NodeConfig](informer, validation.WithValidationTimeout(c.validationTimeout))
 	apiServerValidator := node.NewAPIServerValidator()
 
 	runner.Register(creds.Validations(awsConfig, nodeConfig)...)
+	if nodeConfig.IsIAMRolesAnywhere() {
+		runner.Register(validation.New("iam-ra-certificate-expiry", iamrolesanywhere.NewCertificateExpiryValidator(c.certificateExpiryWarningDays).Run))
+	}
 	runner.Register(
 		validation.New("aws-auth", sts.NewAuthenticationValidator(awsConfig).Run),
-		runner.UntilError(
-			validation.New("k8s-endpoint-network", kubernetes.NewAccessValidator(awsConfig).Run),
-			validation.New("k8s-authentication", apiServerValidator.MakeAuthenticatedRequest),
-			validation.New("k8s-identity", apiServerValidator.CheckIdentity),
-			validation.New("k8s-vpc-network", apiServerValidator.CheckVPCEndpointAccess),
-		),
+		validation.New("k8s-endpoint-network", kubernetes.NewAccessValidator(awsConfig).Run),
+		validation.New("k8s-authentication", apiServerValidator.MakeAuthenticatedRequest).DependingOn("k8s-endpoint-network"),
+		validation.New("k8s-identity", apiServerValidator.CheckIdentity).DependingOn("k8s-authentication"),
+		validation.New("k8s-vpc-network", apiServerValidator.CheckVPCEndpointAccess).DependingOn("k8s-identity"),
 	)
 
-	if err := runner.Sequentially(ctx, nodeConfig); err != nil {
+	err = runner.Concurrently(ctx, nodeConfig)
+	if recorder != nil {
+		if writeErr := recorder.Write(os.Stdout, c.output); writeErr != nil {
+			return writeErr
+		}
+		if err != nil {
+			return errors.NewSilent(err)
+		}
+		return nil
+	}
+
+	if err != nil {
 		fmt.Println("")
 		fmt.Println("Issues found during validation. Please follow the remediation advice above.")
 		// Errors are already presented by the printer
@@ -0,0 +1,113 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/logging"
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/drift"
+	"github.com/aws/eks-hybrid/internal/errors"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
+)
+
+const diffHelpText = `Examples:
+  # Show the configuration changes init would make to this node
+  nodeadm diff --config-source file://nodeConfig.yaml
+
+The command exits with a non-zero status code when the configuration on disk has drifted.
+
+Documentation:
+  https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html`
+
+func NewCommand() cli.Command {
+	diff := diff{}
+	diff.cmd = flaggy.NewSubcommand("diff")
+	diff.cmd.String(&diff.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
+	diff.cmd.Description = "Compare the configuration init would write with the files on disk"
+	diff.cmd.AdditionalHelpAppend = diffHelpText
+	return &diff
+}
+
+type diff struct {
+	cmd          *flaggy.Subcommand
+	configSource string
+}
+
+func (c *diff) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *diff) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	if c.configSource == "" {
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin." +
+			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
+	}
+
+	provider, err := configprovider.BuildConfigProvider(c.configSource)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+	if !nodeConfig.IsHybridNode() {
+		return fmt.Errorf("diff is only supported for hybrid nodes")
+	}
+
+	// Read the AWS config the node is already using instead of configuring it,
+	// diff must not make any change to the node.
+	awsConfig, err := creds.ReadConfig(ctx, nodeConfig, config.WithLogger(logging.Nop{}))
+	if err != nil {
+		return err
+	}
+
+	nodeProvider, err := hybrid.NewHybridNodeProvider(nodeConfig, nil, log, hybrid.WithAWSConfig(&awsConfig))
+	if err != nil {
+		return err
+	}
+	defer nodeProvider.Cleanup()
+
+	nodeProvider.PopulateNodeConfigDefaults()
+	if err := nodeProvider.ValidateConfig(); err != nil {
+		return err
+	}
+	if err := nodeProvider.Enrich(ctx); err != nil {
+		return err
+	}
+
+	renderer, ok := nodeProvider.(drift.Renderer)
+	if !ok {
+		return fmt.Errorf("node provider doesn't support rendering its configuration")
+	}
+	files, err := renderer.RenderConfig()
+	if err != nil {
+		return fmt.Errorf("rendering node configuration: %w", err)
+	}
+
+	drifts, err := drift.Compare(files)
+	if err != nil {
+		return err
+	}
+	if len(drifts) == 0 {
+		log.Info("Node configuration is up to date", zap.Int("files", len(files)))
+		return nil
+	}
+
+	if err := drift.Print(os.Stdout, drifts); err != nil {
+		return err
+	}
+	return errors.NewSilent(fmt.Errorf("%d of %d configuration files drifted", len(drifts), len(files)))
+}
@@ -9,11 +9,16 @@ import (
 	"go.uber.org/zap"
 	"k8s.io/utils/strings/slices"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/cli"
 	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/firewall"
 	"github.com/aws/eks-hybrid/internal/flows"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
 	"github.com/aws/eks-hybrid/internal/system"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
@@ -23,22 +28,26 @@ const (
 	cniPortCheckValidation = "cni-validation"
 	calicoVxLanPort        = "4789"
 	ciliumVxLanPort        = "8472"
-	vxLanProtocol          = "udp"
+	vxLanProtocol          = firewall.ProtocolUDP
 )
 
 const initHelpText = `Examples:
   # Initialize using configuration file
   nodeadm init --config-source file://nodeConfig.yaml
 
+  # Print the changes init would make to the host without applying them
+  nodeadm init --config-source file://nodeConfig.yaml --dry-run
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_init`
 
 func NewInitCommand() cli.Command {
 	init := initCmd{}
 	init.cmd = flaggy.NewSubcommand("init")
-	init.cmd.String(&init.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
+	init.cmd.String(&init.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
 	init.cmd.StringSlice(&init.daemons, "d", "daemon", "Specify one or more of `containerd` and `kubelet`. This is intended for testing and should not be used in a production environment.")
 	init.cmd.StringSlice(&init.skipPhases, "s", "skip", "Phases of the bootstrap to skip. Allowed values: [install-validation, cni-validation, node-ip-validation, kubelet-cert-validation, preprocess, config, run].")
+	init.dryRun.RegisterFlags(init.cmd)
 	init.cmd.Description = "Initialize this instance as a node in an EKS cluster"
 	init.cmd.AdditionalHelpAppend = initHelpText
 	return &init
@@ -49,6 +58,7 @@ type initCmd struct {
 	configSource string
 	skipPhases   []string
 	daemons      []string
+	dryRun       cli.DryRunFlags
 }
 
 func (c *initCmd) Flaggy() *flaggy.Subcommand {
@@ -58,6 +68,7 @@ func (c *initCmd) Flaggy() *flaggy.Subcommand {
 func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx := context.Background()
 	ctx = logger.NewContext(ctx, log)
+	ctx = c.dryRun.Context(ctx)
 
 	log.Info("Checking user is root..")
 	root, err := cli.IsRunningAsRoot()
@@ -68,7 +79,7 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	if c.configSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
@@ -90,15 +101,30 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	// Check if either of cilium or calico vxlan port are open
 	if !slices.Contains(c.skipPhases, cniPortCheckValidation) {
 		log.Info("Validating firewall ports for cilium and calico")
-		if err := validateFirewallOpenPorts(); err != nil {
+		if err := validateFirewallOpenPorts(ctx); err != nil {
 			return fmt.Errorf("Cilium (%s/%s) or Calico (%s/%s) VxLan ports are not open on the host. If you are not using VxLan, this validation can by bypassed with --skip %s",
 				ciliumVxLanPort, vxLanProtocol, calicoVxLanPort, vxLanProtocol, cniPortCheckValidation)
 		}
 	}
-	nodeProvider, err := node.NewNodeProvider(c.configSource, c.skipPhases, log)
+	var providerOpts []hybrid.NodeProviderOpt
+	if c.dryRun.Enabled {
+		daemonManager, err := daemon.NewDaemonManager()
+		if err != nil {
+			return err
+		}
+		providerOpts = append(providerOpts, hybrid.WithDaemonManager(c.dryRun.DaemonManager(daemonManager)))
+	}
+	nodeProvider, err := node.NewNodeProvider(c.configSource, c.skipPhases, log, providerOpts...)
 	if err != nil {
 		return err
 	}
+	if c.dryRun.Enabled && !nodeProvider.GetNodeConfig().IsHybridNode() {
+		return fmt.Errorf("--dry-run is only supported for hybrid nodes")
+	}
+
+	if err := recordAWSConfigPath(ctx, nodeProvider.GetNodeConfig()); err != nil {
+		return err
+	}
 
 	initer := &flows.Initer{
 		NodeProvider: nodeProvider,
@@ -106,10 +132,34 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		Logger:       log,
 	}
 
-	return initer.Run(ctx)
+	if err := initer.Run(ctx); err != nil {
+		return err
+	}
+	return c.dryRun.Print(os.Stdout)
+}
+
+// recordAWSConfigPath stores in the tracker the AWS config file init writes for
+// the node credentials, so uninstall removes it from a custom path too.
+func recordAWSConfigPath(ctx context.Context, nodeConfig *api.NodeConfig) error {
+	if !nodeConfig.IsHybridNode() {
+		return nil
+	}
+	installed, err := tracker.GetInstalledArtifacts()
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	provider, err := creds.ForNodeConfig(nodeConfig)
+	if err != nil {
+		// The config validation reports the missing credential provider.
+		return nil
+	}
+	installed.RecordAWSConfigPath(provider.AWSConfigPath(nodeConfig))
+	return installed.Save(ctx)
 }
 
-func validateFirewallOpenPorts() error {
+func validateFirewallOpenPorts(ctx context.Context) error {
 	firewallManager := system.NewFirewallManager()
 	enabled, err := firewallManager.IsEnabled()
 	if err != nil {
@@ -118,14 +168,14 @@ func validateFirewallOpenPorts() error {
 	if !enabled {
 		return nil
 	}
-	if err := firewallManager.FlushRules(); err != nil {
+	if err := firewallManager.FlushRules(ctx); err != nil {
 		return err
 	}
-	ciliumVxlanPortOpen, err := firewallManager.IsPortOpen(ciliumVxLanPort, vxLanProtocol)
+	ciliumVxlanPortOpen, err := firewallManager.IsAllowed(firewall.UDPPort(ciliumVxLanPort))
 	if err != nil {
 		return err
 	}
-	calicoVxlanPortOpen, err := firewallManager.IsPortOpen(calicoVxLanPort, vxLanProtocol)
+	calicoVxlanPortOpen, err := firewallManager.IsAllowed(firewall.UDPPort(calicoVxLanPort))
 	if err != nil {
 		return err
 	}
@@ -2,13 +2,17 @@ package install
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/containerd"
 	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/flows"
@@ -25,6 +29,30 @@ const installHelpText = `Examples:
   # Install Kubernetes version 1.31 with AWS IAM Roles Anywhere as the credential provider and Docker as the containerd source
   nodeadm install 1.31 --credential-provider iam-ra --containerd-source docker
 
+  # Install Kubernetes version 1.31 with containerd 1.7 from the distro repositories
+  nodeadm install 1.31 --credential-provider ssm --containerd-version 1.7
+
+  # Install Kubernetes version 1.31 with the containerd, runc and shim binaries from the release manifest
+  nodeadm install 1.31 --credential-provider ssm --containerd-source eks
+
+  # Install Kubernetes version 1.31 getting credentials from an external credential_process command
+  nodeadm install 1.31 --credential-provider credential-process
+
+  # Install Kubernetes version 1.31 downloading the artifacts from a private mirror
+  nodeadm install 1.31 --credential-provider ssm --manifest-url https://mirror.example.com/manifest.yaml --mirror-url https://mirror.example.com
+
+  # Install Kubernetes version 1.31 downloading the artifacts from the mirror set in spec.hybrid.artifacts of the node config
+  nodeadm install 1.31 --credential-provider ssm --config-source file:///root/nodeConfig.yaml
+
+  # Install Kubernetes version 1.31 from a bundle created with nodeadm bundle create
+  nodeadm install 1.31 --credential-provider iam-ra --bundle /tmp/nodeadm-bundle-1.31-amd64.tgz
+
+  # Install Kubernetes version 1.31 on an operating system version that is not in the support matrix
+  nodeadm install 1.31 --credential-provider ssm --allow-unsupported-os
+
+  # Print the changes install would make to the host without applying them
+  nodeadm install 1.31 --credential-provider ssm --dry-run
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_install`
 
@@ -38,10 +66,16 @@ func NewCommand() cli.Command {
 	fc.Description = "Install components required to join an EKS cluster"
 	fc.AdditionalHelpAppend = installHelpText
 	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to install.")
-	fc.String(&cmd.credentialProvider, "p", "credential-provider", "Credential process to install. Allowed values: [ssm, iam-ra].")
-	fc.String(&cmd.containerdSource, "s", "containerd-source", "Source for containerd artifact. Allowed values: [none, distro, docker].")
+	fc.String(&cmd.credentialProvider, "p", "credential-provider", "Credential process to install. Allowed values: [ssm, iam-ra, credential-process].")
+	fc.String(&cmd.containerdSource, "s", "containerd-source", "Source for containerd artifact. Allowed values: [none, distro, docker, eks].")
+	fc.String(&cmd.containerdVersion, "", "containerd-version", "Version of containerd to install, as major.minor[.patch]. Defaults to the latest version supported for the containerd source.")
 	fc.String(&cmd.region, "r", "region", "AWS region for downloading regional artifacts.")
+	fc.String(&cmd.bundle, "b", "bundle", "Path to an artifact bundle created with nodeadm bundle create. When set, EKS artifacts are installed from the bundle instead of being downloaded, so it can't be combined with the manifest and mirror flags.")
+	fc.Bool(&cmd.allowUnsupportedOs, "", "allow-unsupported-os", "Install on operating system versions that nodeadm is not validated on.")
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum install command duration. Input follows duration format. Example: 1h23s")
+	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration to read spec.hybrid.artifacts from when the manifest and mirror flags are not set. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
+	cmd.artifactSource.RegisterFlags(fc)
+	cmd.dryRun.RegisterFlags(fc)
 	cmd.flaggy = fc
 
 	return &cmd
@@ -52,8 +86,14 @@ type command struct {
 	kubernetesVersion  string
 	credentialProvider string
 	containerdSource   string
+	containerdVersion  string
 	region             string
+	bundle             string
+	allowUnsupportedOs bool
+	configSource       string
+	artifactSource     cli.ArtifactSourceFlags
 	timeout            time.Duration
+	dryRun             cli.DryRunFlags
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -63,6 +103,7 @@ func (c *command) Flaggy() *flaggy.Subcommand {
 func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx := context.Background()
 	ctx = logger.NewContext(ctx, log)
+	ctx = c.dryRun.Context(ctx)
 
 	root, err := cli.IsRunningAsRoot()
 	if err != nil {
@@ -73,14 +114,23 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	}
 
 	if c.credentialProvider == "" {
-		flaggy.ShowHelpAndExit("--credential-provider is a required flag. Allowed values are ssm & iam-ra")
+		flaggy.ShowHelpAndExit("--credential-provider is a required flag. Allowed values are ssm, iam-ra & credential-process")
+	}
+	if c.bundle != "" && c.artifactSource.IsSet() {
+		return fmt.Errorf("--bundle can't be used with --manifest-url, --mirror-url or --mirror-ca-bundle, the artifacts are installed from the bundle")
 	}
 	credentialProvider, err := creds.GetCredentialProvider(c.credentialProvider)
 	if err != nil {
 		return err
 	}
-	osName, osVersion := system.GetOsNameWithVersion()
-	if err = creds.ValidateCredentialProvider(credentialProvider, osName, osVersion); err != nil {
+	osRelease := system.GetOsRelease()
+	if err := system.ValidateSupportedOs(osRelease); err != nil {
+		if !c.allowUnsupportedOs {
+			return fmt.Errorf("%w. Use --allow-unsupported-os to install anyway", err)
+		}
+		log.Warn("Installing on an unsupported operating system", zap.Error(err))
+	}
+	if err = creds.ValidateCredentialProvider(credentialProvider, osRelease.Family(), osRelease.VersionID); err != nil {
 		return err
 	}
 
@@ -92,6 +142,9 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	if err := containerd.ValidateContainerdSource(containerdSource); err != nil {
 		return err
 	}
+	if err := containerd.ValidateVersion(containerdSource, c.containerdVersion); err != nil {
+		return err
+	}
 
 	log.Info("Creating package manager...")
 	packageManager, err := packagemanager.New(containerdSource, log)
@@ -104,9 +157,48 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 
 	log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
 	// Create a Source for all AWS managed artifacts.
-	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion)
-	if err != nil {
-		return err
+	var awsSource aws.Source
+	if c.bundle != "" {
+		log.Info("Loading artifact bundle", zap.String("bundle", c.bundle))
+		bundle, err := aws.OpenBundle(c.bundle)
+		if err != nil {
+			return err
+		}
+		defer bundle.Close()
+
+		// --containerd-version only selects the containerd release for the eks source,
+		// the other sources install it with the package manager.
+		containerdVersion := ""
+		if containerdSource == containerd.ContainerdSourceEks {
+			containerdVersion = c.containerdVersion
+		}
+		awsSource, err = bundle.GetSource(c.kubernetesVersion, containerdVersion)
+		if err != nil {
+			return err
+		}
+	} else {
+		var nodeConfig *api.NodeConfig
+		if c.configSource != "" {
+			log.Info("Loading configuration..", zap.String("configSource", c.configSource))
+			provider, err := configprovider.BuildConfigProviderWithoutSecrets(c.configSource)
+			if err != nil {
+				return err
+			}
+			if nodeConfig, err = provider.Provide(); err != nil {
+				return err
+			}
+		}
+		sourceOpts := c.artifactSource.SourceOptions(nodeConfig)
+		if containerdSource == containerd.ContainerdSourceEks {
+			sourceOpts = append(sourceOpts, aws.WithContainerdVersion(c.containerdVersion))
+		}
+		awsSource, err = aws.GetLatestSource(ctx, c.kubernetesVersion, sourceOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	if containerdSource == containerd.ContainerdSourceEks && awsSource.Containerd.Version == "" {
+		return fmt.Errorf("no supported containerd releases found in the release manifest. Please provide `distro`, `docker` or `none` to the --containerd-source flag")
 	}
 	log.Info("Using Kubernetes version", zap.Reflect("kubernetes version", awsSource.Eks.Version))
 
@@ -114,10 +206,14 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		AwsSource:          awsSource,
 		PackageManager:     packageManager,
 		ContainerdSource:   containerdSource,
+		ContainerdVersion:  c.containerdVersion,
 		SsmRegion:          c.region,
 		CredentialProvider: credentialProvider,
 		Logger:             log,
 	}
 
-	return installer.Run(ctx)
+	if err := installer.Run(ctx); err != nil {
+		return err
+	}
+	return c.dryRun.Print(os.Stdout)
 }
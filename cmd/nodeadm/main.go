@@ -6,10 +6,14 @@ import (
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/cmd/nodeadm/bundle"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/config"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/credentials"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/debug"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/diff"
 	initcmd "github.com/aws/eks-hybrid/cmd/nodeadm/init"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/install"
+	"github.com/aws/eks-hybrid/cmd/nodeadm/status"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/uninstall"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/upgrade"
 	"github.com/aws/eks-hybrid/cmd/nodeadm/version"
@@ -37,6 +41,10 @@ func main() {
 		uninstall.NewCommand(),
 		upgrade.NewUpgradeCommand(),
 		debug.NewCommand(),
+		bundle.NewBundleCommand(),
+		status.NewCommand(),
+		diff.NewCommand(),
+		credentials.NewCredentialsCommand(),
 	}
 
 	for _, cmd := range cmds {
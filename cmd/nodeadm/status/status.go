@@ -0,0 +1,72 @@
+package status
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/cli"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/logger"
+	"github.com/aws/eks-hybrid/internal/status"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+const statusHelpText = `Examples:
+  # Show a summary of the node
+  nodeadm status
+
+  # Show the node status as JSON
+  nodeadm status -o json`
+
+func NewCommand() cli.Command {
+	cmd := command{
+		output:  status.OutputTable,
+		timeout: 30 * time.Second,
+	}
+	cmd.flaggy = flaggy.NewSubcommand("status")
+	cmd.flaggy.Description = "Show the health of the node and the components installed by nodeadm"
+	cmd.flaggy.AdditionalHelpAppend = statusHelpText
+	cmd.flaggy.String(&cmd.output, "o", "output", "Output format. Allowed values: [table, json, yaml].")
+	cmd.flaggy.Duration(&cmd.timeout, "t", "timeout", "Maximum time to wait for the Kubernetes API server. Input follows duration format. Example: 1m")
+	return &cmd
+}
+
+type command struct {
+	flaggy  *flaggy.Subcommand
+	output  string
+	timeout time.Duration
+}
+
+func (c *command) Flaggy() *flaggy.Subcommand {
+	return c.flaggy
+}
+
+func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	ctx := context.Background()
+	ctx = logger.NewContext(ctx, log)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	installed, err := tracker.GetCurrentState()
+	if err != nil {
+		return err
+	}
+
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+
+	var collectorOpts []status.CollectorOption
+	if path := installed.CurrentAWSConfigPath(); path != "" {
+		collectorOpts = append(collectorOpts, status.WithAWSConfigPath(path))
+	}
+	nodeStatus := status.NewCollector(daemonManager, collectorOpts...).Collect(ctx, installed)
+	return status.Print(os.Stdout, nodeStatus, c.output)
+}
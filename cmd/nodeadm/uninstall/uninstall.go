@@ -18,6 +18,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
@@ -34,6 +35,9 @@ const uninstallHelpText = `Examples:
   # Uninstall all components and skip pod-validation and node-validation pre-flight validation
   nodeadm uninstall --skip node-validation,pod-validation
 
+  # Print the changes uninstall would make to the host without applying them
+  nodeadm uninstall --dry-run
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_uninstall`
 
@@ -45,6 +49,7 @@ func NewCommand() cli.Command {
 	fc.AdditionalHelpAppend = uninstallHelpText
 	fc.StringSlice(&cmd.skipPhases, "s", "skip", "Phases of uninstall to skip. Allowed values: [pod-validation, node-validation].")
 	fc.Bool(&cmd.force, "f", "force", "Force delete additional directories that might contain leftovers from the node process. WARNING: This will delete all contents in default Kubernetes and CNI directories (/var/lib/kubelet, /var/lib/cni, etc). Do not use this flag if you store your own data in these locations.")
+	cmd.dryRun.RegisterFlags(fc)
 	cmd.flaggy = fc
 
 	return &cmd
@@ -54,6 +59,7 @@ type command struct {
 	flaggy     *flaggy.Subcommand
 	skipPhases []string
 	force      bool
+	dryRun     cli.DryRunFlags
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -63,6 +69,7 @@ func (c *command) Flaggy() *flaggy.Subcommand {
 func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx := context.Background()
 	ctx = logger.NewContext(ctx, log)
+	ctx = c.dryRun.Context(ctx)
 
 	root, err := cli.IsRunningAsRoot()
 	if err != nil {
@@ -87,6 +94,7 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return err
 	}
 	defer daemonManager.Close()
+	daemonManager = c.dryRun.DaemonManager(daemonManager)
 
 	if installed.Artifacts.Kubelet {
 		kubeletStatus, err := daemonManager.GetDaemonStatus(kubelet.KubeletDaemonName)
@@ -122,6 +130,10 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 
 	uninstaller := &flows.Uninstaller{
 		Artifacts:      installed.Artifacts,
+		HeldPackages:   installed.HeldPackages,
+		HoldPlugins:    installed.HoldPlugins,
+		AWSConfigPaths: installed.AWSConfigPaths,
+		Aspects:        hybrid.TeardownAspects(log),
 		DaemonManager:  daemonManager,
 		PackageManager: packageManager,
 		Logger:         log,
@@ -135,10 +147,10 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	if c.force {
 		log.Info("Force mode enabled, cleaning up additional directories...")
 		cleanupManager := cleanup.New(log)
-		if err := cleanupManager.Cleanup(); err != nil {
+		if err := cleanupManager.Cleanup(ctx); err != nil {
 			return fmt.Errorf("cleaning up additional directories: %w", err)
 		}
 	}
 
-	return nil
+	return c.dryRun.Print(os.Stdout)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/logger"
 	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
@@ -36,6 +37,15 @@ const upgradeHelpText = `Examples:
   # Upgrade all components with a custom timeout
   nodeadm upgrade 1.31 --config-source file:///root/nodeConfig.yaml --timeout 1h23s
 
+  # Upgrade all components and move containerd to version 1.7.24
+  nodeadm upgrade 1.31 --config-source file:///root/nodeConfig.yaml --containerd-version 1.7.24
+
+  # Print the changes the upgrade would make to the host without applying them
+  nodeadm upgrade 1.31 --config-source file:///root/nodeConfig.yaml --dry-run
+
+  # Restore the binaries and configuration replaced by the last upgrade
+  nodeadm upgrade --rollback
+
 Documentation:
   https://docs.aws.amazon.com/eks/latest/userguide/hybrid-nodes-nodeadm.html#_upgrade`
 
@@ -47,10 +57,14 @@ func NewUpgradeCommand() cli.Command {
 	fc := flaggy.NewSubcommand("upgrade")
 	fc.Description = "Upgrade components installed using the install sub-command"
 	fc.AdditionalHelpAppend = upgradeHelpText
-	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, true, "The major[.minor[.patch]] version of Kubernetes to install.")
-	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds].")
-	fc.StringSlice(&cmd.skipPhases, "s", "skip", "Phases of the upgrade to skip. Allowed values: [init-validation, pod-validation, node-validation, node-ip-validation].")
+	fc.AddPositionalValue(&cmd.kubernetesVersion, "KUBERNETES_VERSION", 1, false, "The major[.minor[.patch]] version of Kubernetes to upgrade to. Required unless --rollback is set.")
+	fc.Bool(&cmd.rollback, "", "rollback", "Restore the binaries and configuration replaced by the last upgrade instead of upgrading. Cannot be combined with KUBERNETES_VERSION.")
+	fc.String(&cmd.configSource, "c", "config-source", "Source of node configuration. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin.")
+	fc.String(&cmd.containerdVersion, "", "containerd-version", "Version of containerd to upgrade to, as major.minor[.patch]. Defaults to the latest version supported for the installed containerd source.")
+	fc.StringSlice(&cmd.skipPhases, "s", "skip", "Phases of the upgrade to skip. Allowed values: [init-validation, pod-validation, node-validation, node-ip-validation, health-check].")
 	fc.Duration(&cmd.timeout, "t", "timeout", "Maximum upgrade command duration. Input follows duration format. Example: 1h23s")
+	cmd.artifactSource.RegisterFlags(fc)
+	cmd.dryRun.RegisterFlags(fc)
 	cmd.flaggy = fc
 	return &cmd
 }
@@ -58,9 +72,13 @@ func NewUpgradeCommand() cli.Command {
 type command struct {
 	flaggy            *flaggy.Subcommand
 	configSource      string
+	containerdVersion string
 	skipPhases        []string
 	kubernetesVersion string
+	rollback          bool
+	artifactSource    cli.ArtifactSourceFlags
 	timeout           time.Duration
+	dryRun            cli.DryRunFlags
 }
 
 func (c *command) Flaggy() *flaggy.Subcommand {
@@ -70,6 +88,7 @@ func (c *command) Flaggy() *flaggy.Subcommand {
 func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	ctx := context.Background()
 	ctx = logger.NewContext(ctx, log)
+	ctx = c.dryRun.Context(ctx)
 
 	root, err := cli.IsRunningAsRoot()
 	if err != nil {
@@ -79,8 +98,22 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return cli.ErrMustRunAsRoot
 	}
 
+	if c.rollback {
+		if c.kubernetesVersion != "" {
+			flaggy.ShowHelpAndExit("KUBERNETES_VERSION cannot be used with --rollback")
+		}
+		if err := c.rollbackUpgrade(ctx, log); err != nil {
+			return err
+		}
+		return c.dryRun.Print(os.Stdout)
+	}
+
+	if c.kubernetesVersion == "" {
+		flaggy.ShowHelpAndExit("KUBERNETES_VERSION is required unless --rollback is set")
+	}
+
 	if c.configSource == "" {
-		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds]." +
+		flaggy.ShowHelpAndExit("--config-source is a required flag. The format is a URI with supported schemes: [file, imds, https, s3], or - to read from stdin." +
 			" For example on hybrid nodes --config-source file://nodeConfig.yaml")
 	}
 
@@ -93,6 +126,10 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		return err
 	}
 
+	if err := containerd.ValidateVersion(containerd.GetContainerdSource(installed.Artifacts.Containerd), c.containerdVersion); err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
@@ -103,11 +140,30 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		}
 	}
 
+	log.Info("Creating daemon manager..")
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+	daemonManager = c.dryRun.DaemonManager(daemonManager)
+
+	skipPhases := c.skipPhases
+	var providerOpts []hybrid.NodeProviderOpt
+	if c.dryRun.Enabled {
+		// The daemons are not restarted, so the node can't become healthy.
+		skipPhases = append(append([]string{}, skipPhases...), flows.HealthCheckPhase)
+		providerOpts = append(providerOpts, hybrid.WithDaemonManager(daemonManager))
+	}
+
 	log.Info("Loading configuration..", zap.String("configSource", c.configSource))
-	nodeProvider, err := node.NewNodeProvider(c.configSource, c.skipPhases, log)
+	nodeProvider, err := node.NewNodeProvider(c.configSource, skipPhases, log, providerOpts...)
 	if err != nil {
 		return err
 	}
+	if c.dryRun.Enabled && !nodeProvider.GetNodeConfig().IsHybridNode() {
+		return fmt.Errorf("--dry-run is only supported for hybrid nodes")
+	}
 
 	nodeProvider.PopulateNodeConfigDefaults()
 
@@ -131,18 +187,15 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 
 	log.Info("Validating Kubernetes version", zap.Reflect("kubernetes version", c.kubernetesVersion))
 	// Create a Source for all AWS managed artifacts.
-	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion)
-	if err != nil {
-		return err
+	sourceOpts := c.artifactSource.SourceOptions(nodeProvider.GetNodeConfig())
+	if installed.Artifacts.Containerd == string(containerd.ContainerdSourceEks) {
+		sourceOpts = append(sourceOpts, aws.WithContainerdVersion(c.containerdVersion))
 	}
-	log.Info("Using Kubernetes version", zap.Reflect("kubernetes version", awsSource.Eks.Version))
-
-	log.Info("Creating daemon manager..")
-	daemonManager, err := daemon.NewDaemonManager()
+	awsSource, err := aws.GetLatestSource(ctx, c.kubernetesVersion, sourceOpts...)
 	if err != nil {
 		return err
 	}
-	defer daemonManager.Close()
+	log.Info("Using Kubernetes version", zap.Reflect("kubernetes version", awsSource.Eks.Version))
 
 	if installed.Artifacts.Kubelet {
 		kubeletStatus, err := daemonManager.GetDaemonStatus(kubelet.KubeletDaemonName)
@@ -181,11 +234,57 @@ func (c *command) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 		AwsSource:          awsSource,
 		PackageManager:     packageManager,
 		CredentialProvider: credsProvider,
-		Artifacts:          installed.Artifacts,
+		ContainerdVersion:  c.containerdVersion,
+		Tracker:            installed,
 		DaemonManager:      daemonManager,
-		SkipPhases:         c.skipPhases,
+		SkipPhases:         skipPhases,
 		Logger:             log,
 	}
 
-	return upgrader.Run(ctx)
+	if err := upgrader.Run(ctx); err != nil {
+		return err
+	}
+	return c.dryRun.Print(os.Stdout)
+}
+
+func (c *command) rollbackUpgrade(ctx context.Context, log *zap.Logger) error {
+	installed, err := tracker.GetInstalledArtifacts()
+	if err != nil {
+		return err
+	}
+	if installed.Backup == nil {
+		return fmt.Errorf("no upgrade backup found, nothing to roll back")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	log.Info("Creating daemon manager..")
+	daemonManager, err := daemon.NewDaemonManager()
+	if err != nil {
+		return err
+	}
+	defer daemonManager.Close()
+
+	if err := flows.Rollback(ctx, installed.Backup, c.dryRun.DaemonManager(daemonManager), log); err != nil {
+		return err
+	}
+
+	log.Info("Creating package manager...")
+	packageManager, err := packagemanager.New(containerd.GetContainerdSource(installed.Artifacts.Containerd), log)
+	if err != nil {
+		return err
+	}
+	if err := flows.HoldBackupPackages(ctx, installed, packageManager); err != nil {
+		return err
+	}
+	// The restored binaries are the ones in the records saved with the backup, so the
+	// next upgrade doesn't skip them as current.
+	installed.RestoreBackupRecords()
+
+	if err := installed.Backup.Remove(ctx); err != nil {
+		return err
+	}
+	installed.ClearBackup()
+	return installed.Save(ctx)
 }
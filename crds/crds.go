@@ -0,0 +1,9 @@
+// Package crds embeds the CustomResourceDefinitions generated from the API types.
+package crds
+
+import _ "embed"
+
+// NodeConfig is the CustomResourceDefinition of the NodeConfig API.
+//
+//go:embed node.eks.aws_nodeconfigs.yaml
+var NodeConfig []byte
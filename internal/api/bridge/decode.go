@@ -1,10 +1,15 @@
 package bridge
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-hybrid/api"
@@ -46,3 +51,36 @@ func DecodeStrictNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
 
 	return &obj, nil
 }
+
+// DecodeStrictNodeConfigs unmarshals each document in the given multi-document YAML
+// data into an internal NodeConfig object, in the order they appear. Empty documents
+// are skipped. Will throw an error if unknown fields are present.
+func DecodeStrictNodeConfigs(data []byte) ([]*internalapi.NodeConfig, error) {
+	var configs []*internalapi.NodeConfig
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading yaml document %d: %w", i, err)
+		}
+
+		// Documents with only comments or separators are converted to null.
+		jsonDoc, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("decoding yaml document %d: %w", i, err)
+		}
+		if bytes.Equal(bytes.TrimSpace(jsonDoc), []byte("null")) {
+			continue
+		}
+
+		config, err := DecodeStrictNodeConfig(doc)
+		if err != nil {
+			return nil, fmt.Errorf("decoding yaml document %d: %w", i, err)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
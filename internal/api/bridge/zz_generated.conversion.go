@@ -21,6 +21,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ArtifactSource)(nil), (*api.ArtifactSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ArtifactSource_To_api_ArtifactSource(a.(*v1alpha1.ArtifactSource), b.(*api.ArtifactSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ArtifactSource)(nil), (*v1alpha1.ArtifactSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ArtifactSource_To_v1alpha1_ArtifactSource(a.(*api.ArtifactSource), b.(*v1alpha1.ArtifactSource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterDetails)(nil), (*api.ClusterDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(a.(*v1alpha1.ClusterDetails), b.(*api.ClusterDetails), scope)
 	}); err != nil {
@@ -41,6 +51,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.CredentialProcess)(nil), (*api.CredentialProcess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CredentialProcess_To_api_CredentialProcess(a.(*v1alpha1.CredentialProcess), b.(*api.CredentialProcess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CredentialProcess)(nil), (*v1alpha1.CredentialProcess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CredentialProcess_To_v1alpha1_CredentialProcess(a.(*api.CredentialProcess), b.(*v1alpha1.CredentialProcess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.HostOverride)(nil), (*api.HostOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HostOverride_To_api_HostOverride(a.(*v1alpha1.HostOverride), b.(*api.HostOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.HostOverride)(nil), (*v1alpha1.HostOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_HostOverride_To_v1alpha1_HostOverride(a.(*api.HostOverride), b.(*v1alpha1.HostOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.HostSelector)(nil), (*api.HostSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HostSelector_To_api_HostSelector(a.(*v1alpha1.HostSelector), b.(*api.HostSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.HostSelector)(nil), (*v1alpha1.HostSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_HostSelector_To_v1alpha1_HostSelector(a.(*api.HostSelector), b.(*v1alpha1.HostSelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.HybridOptions)(nil), (*api.HybridOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_HybridOptions_To_api_HybridOptions(a.(*v1alpha1.HybridOptions), b.(*api.HybridOptions), scope)
 	}); err != nil {
@@ -134,6 +174,30 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_ArtifactSource_To_api_ArtifactSource(in *v1alpha1.ArtifactSource, out *api.ArtifactSource, s conversion.Scope) error {
+	out.ManifestURL = in.ManifestURL
+	out.MirrorURL = in.MirrorURL
+	out.MirrorCABundle = in.MirrorCABundle
+	return nil
+}
+
+// Convert_v1alpha1_ArtifactSource_To_api_ArtifactSource is an autogenerated conversion function.
+func Convert_v1alpha1_ArtifactSource_To_api_ArtifactSource(in *v1alpha1.ArtifactSource, out *api.ArtifactSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ArtifactSource_To_api_ArtifactSource(in, out, s)
+}
+
+func autoConvert_api_ArtifactSource_To_v1alpha1_ArtifactSource(in *api.ArtifactSource, out *v1alpha1.ArtifactSource, s conversion.Scope) error {
+	out.ManifestURL = in.ManifestURL
+	out.MirrorURL = in.MirrorURL
+	out.MirrorCABundle = in.MirrorCABundle
+	return nil
+}
+
+// Convert_api_ArtifactSource_To_v1alpha1_ArtifactSource is an autogenerated conversion function.
+func Convert_api_ArtifactSource_To_v1alpha1_ArtifactSource(in *api.ArtifactSource, out *v1alpha1.ArtifactSource, s conversion.Scope) error {
+	return autoConvert_api_ArtifactSource_To_v1alpha1_ArtifactSource(in, out, s)
+}
+
 func autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Region = in.Region
@@ -186,10 +250,94 @@ func Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.Contain
 	return autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_CredentialProcess_To_api_CredentialProcess(in *v1alpha1.CredentialProcess, out *api.CredentialProcess, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.Command = in.Command
+	out.AwsConfigPath = in.AwsConfigPath
+	return nil
+}
+
+// Convert_v1alpha1_CredentialProcess_To_api_CredentialProcess is an autogenerated conversion function.
+func Convert_v1alpha1_CredentialProcess_To_api_CredentialProcess(in *v1alpha1.CredentialProcess, out *api.CredentialProcess, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CredentialProcess_To_api_CredentialProcess(in, out, s)
+}
+
+func autoConvert_api_CredentialProcess_To_v1alpha1_CredentialProcess(in *api.CredentialProcess, out *v1alpha1.CredentialProcess, s conversion.Scope) error {
+	out.NodeName = in.NodeName
+	out.Command = in.Command
+	out.AwsConfigPath = in.AwsConfigPath
+	return nil
+}
+
+// Convert_api_CredentialProcess_To_v1alpha1_CredentialProcess is an autogenerated conversion function.
+func Convert_api_CredentialProcess_To_v1alpha1_CredentialProcess(in *api.CredentialProcess, out *v1alpha1.CredentialProcess, s conversion.Scope) error {
+	return autoConvert_api_CredentialProcess_To_v1alpha1_CredentialProcess(in, out, s)
+}
+
+func autoConvert_v1alpha1_HostOverride_To_api_HostOverride(in *v1alpha1.HostOverride, out *api.HostOverride, s conversion.Scope) error {
+	if err := Convert_v1alpha1_HostSelector_To_api_HostSelector(&in.Selector, &out.Selector, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(&in.Kubelet, &out.Kubelet, s); err != nil {
+		return err
+	}
+	out.Hybrid = (*api.HybridOptions)(unsafe.Pointer(in.Hybrid))
+	return nil
+}
+
+// Convert_v1alpha1_HostOverride_To_api_HostOverride is an autogenerated conversion function.
+func Convert_v1alpha1_HostOverride_To_api_HostOverride(in *v1alpha1.HostOverride, out *api.HostOverride, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HostOverride_To_api_HostOverride(in, out, s)
+}
+
+func autoConvert_api_HostOverride_To_v1alpha1_HostOverride(in *api.HostOverride, out *v1alpha1.HostOverride, s conversion.Scope) error {
+	if err := Convert_api_HostSelector_To_v1alpha1_HostSelector(&in.Selector, &out.Selector, s); err != nil {
+		return err
+	}
+	if err := Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions(&in.Kubelet, &out.Kubelet, s); err != nil {
+		return err
+	}
+	out.Hybrid = (*v1alpha1.HybridOptions)(unsafe.Pointer(in.Hybrid))
+	return nil
+}
+
+// Convert_api_HostOverride_To_v1alpha1_HostOverride is an autogenerated conversion function.
+func Convert_api_HostOverride_To_v1alpha1_HostOverride(in *api.HostOverride, out *v1alpha1.HostOverride, s conversion.Scope) error {
+	return autoConvert_api_HostOverride_To_v1alpha1_HostOverride(in, out, s)
+}
+
+func autoConvert_v1alpha1_HostSelector_To_api_HostSelector(in *v1alpha1.HostSelector, out *api.HostSelector, s conversion.Scope) error {
+	out.Hostname = in.Hostname
+	out.MACAddress = in.MACAddress
+	out.CIDR = in.CIDR
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1alpha1_HostSelector_To_api_HostSelector is an autogenerated conversion function.
+func Convert_v1alpha1_HostSelector_To_api_HostSelector(in *v1alpha1.HostSelector, out *api.HostSelector, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HostSelector_To_api_HostSelector(in, out, s)
+}
+
+func autoConvert_api_HostSelector_To_v1alpha1_HostSelector(in *api.HostSelector, out *v1alpha1.HostSelector, s conversion.Scope) error {
+	out.Hostname = in.Hostname
+	out.MACAddress = in.MACAddress
+	out.CIDR = in.CIDR
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_api_HostSelector_To_v1alpha1_HostSelector is an autogenerated conversion function.
+func Convert_api_HostSelector_To_v1alpha1_HostSelector(in *api.HostSelector, out *v1alpha1.HostSelector, s conversion.Scope) error {
+	return autoConvert_api_HostSelector_To_v1alpha1_HostSelector(in, out, s)
+}
+
 func autoConvert_v1alpha1_HybridOptions_To_api_HybridOptions(in *v1alpha1.HybridOptions, out *api.HybridOptions, s conversion.Scope) error {
 	out.EnableCredentialsFile = in.EnableCredentialsFile
 	out.IAMRolesAnywhere = (*api.IAMRolesAnywhere)(unsafe.Pointer(in.IAMRolesAnywhere))
 	out.SSM = (*api.SSM)(unsafe.Pointer(in.SSM))
+	out.CredentialProcess = (*api.CredentialProcess)(unsafe.Pointer(in.CredentialProcess))
+	out.Artifacts = (*api.ArtifactSource)(unsafe.Pointer(in.Artifacts))
 	return nil
 }
 
@@ -202,6 +350,8 @@ func autoConvert_api_HybridOptions_To_v1alpha1_HybridOptions(in *api.HybridOptio
 	out.EnableCredentialsFile = in.EnableCredentialsFile
 	out.IAMRolesAnywhere = (*v1alpha1.IAMRolesAnywhere)(unsafe.Pointer(in.IAMRolesAnywhere))
 	out.SSM = (*v1alpha1.SSM)(unsafe.Pointer(in.SSM))
+	out.CredentialProcess = (*v1alpha1.CredentialProcess)(unsafe.Pointer(in.CredentialProcess))
+	out.Artifacts = (*v1alpha1.ArtifactSource)(unsafe.Pointer(in.Artifacts))
 	return nil
 }
 
@@ -246,6 +396,7 @@ func autoConvert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in *v1alpha1.In
 	if err := Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
 		return err
 	}
+	out.Swap = api.SwapPolicy(in.Swap)
 	return nil
 }
 
@@ -258,6 +409,7 @@ func autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.Instanc
 	if err := Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
 		return err
 	}
+	out.Swap = v1alpha1.SwapPolicy(in.Swap)
 	return nil
 }
 
@@ -391,6 +543,17 @@ func autoConvert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(in *v1alpha1.Node
 		return err
 	}
 	out.Hybrid = (*api.HybridOptions)(unsafe.Pointer(in.Hybrid))
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]api.HostOverride, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_HostOverride_To_api_HostOverride(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Hosts = nil
+	}
 	return nil
 }
 
@@ -413,6 +576,17 @@ func autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfi
 		return err
 	}
 	out.Hybrid = (*v1alpha1.HybridOptions)(unsafe.Pointer(in.Hybrid))
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]v1alpha1.HostOverride, len(*in))
+		for i := range *in {
+			if err := Convert_api_HostOverride_To_v1alpha1_HostOverride(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Hosts = nil
+	}
 	return nil
 }
 
@@ -0,0 +1,175 @@
+// Package schema exposes the schema of the NodeConfig API, generated from the
+// api/v1alpha1 types, for tools and editors to validate configuration files.
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/api"
+	"github.com/aws/eks-hybrid/api/v1alpha1"
+	"github.com/aws/eks-hybrid/crds"
+)
+
+const (
+	// FormatJSONSchema is a JSON Schema document, as used by editors and linters.
+	FormatJSONSchema = "jsonschema"
+	// FormatOpenAPI is the OpenAPI v3 schema of the CustomResourceDefinition.
+	FormatOpenAPI = "openapi"
+
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+)
+
+type customResourceDefinition struct {
+	Spec struct {
+		Versions []struct {
+			Name   string `json:"name"`
+			Schema struct {
+				OpenAPIV3Schema map[string]any `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// OpenAPI returns the OpenAPI v3 schema of the NodeConfig version served by nodeadm.
+func OpenAPI() (map[string]any, error) {
+	var crd customResourceDefinition
+	if err := yaml.Unmarshal(crds.NodeConfig, &crd); err != nil {
+		return nil, fmt.Errorf("reading NodeConfig CustomResourceDefinition: %w", err)
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Name == v1alpha1.GroupVersion.Version {
+			return version.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return nil, fmt.Errorf("NodeConfig CustomResourceDefinition has no %s schema", v1alpha1.GroupVersion.Version)
+}
+
+// JSONSchema returns the NodeConfig schema as a JSON Schema document. Unlike the
+// OpenAPI schema, which relies on the API server pruning unknown fields, it rejects
+// the fields not defined in the API and requires apiVersion and kind.
+func JSONSchema() (map[string]any, error) {
+	openAPI, err := OpenAPI()
+	if err != nil {
+		return nil, err
+	}
+	schema := toJSONSchema(openAPI)
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = api.KindNodeConfig
+	properties := schema["properties"].(map[string]any)
+	properties["apiVersion"] = map[string]any{"type": "string", "enum": []any{v1alpha1.GroupVersion.String()}}
+	properties["kind"] = map[string]any{"type": "string", "enum": []any{api.KindNodeConfig}}
+	schema["required"] = []any{"apiVersion", "kind"}
+	return schema, nil
+}
+
+// toJSONSchema converts an OpenAPI v3 schema to JSON Schema, dropping the Kubernetes
+// extensions, closing the objects that don't preserve unknown fields and opening the
+// raw values that do.
+func toJSONSchema(openAPI map[string]any) map[string]any {
+	schema := map[string]any{}
+	for key, value := range openAPI {
+		if strings.HasPrefix(key, "x-kubernetes-") || key == "nullable" {
+			continue
+		}
+		switch key {
+		case "properties":
+			properties := map[string]any{}
+			for name, property := range value.(map[string]any) {
+				properties[name] = toJSONSchema(property.(map[string]any))
+			}
+			schema[key] = properties
+		case "items", "additionalProperties":
+			if nested, ok := value.(map[string]any); ok {
+				schema[key] = toJSONSchema(nested)
+			} else {
+				schema[key] = value
+			}
+		default:
+			schema[key] = value
+		}
+	}
+
+	if nullable, _ := openAPI["nullable"].(bool); nullable {
+		if t, ok := schema["type"].(string); ok {
+			schema["type"] = []any{t, "null"}
+		}
+	}
+	preserveUnknown, _ := openAPI["x-kubernetes-preserve-unknown-fields"].(bool)
+	_, hasProperties := openAPI["properties"]
+	_, hasAdditional := openAPI["additionalProperties"]
+	if preserveUnknown && !hasProperties {
+		// Raw values, like the values of inline documents, can be of any type.
+		delete(schema, "type")
+	}
+	if hasProperties && !preserveUnknown && !hasAdditional {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// Validator validates NodeConfig documents against the JSON Schema.
+type Validator struct {
+	validator *validate.SchemaValidator
+}
+
+// NewValidator builds a Validator for the NodeConfig JSON Schema.
+func NewValidator() (*Validator, error) {
+	jsonSchema, err := JSONSchema()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(jsonSchema)
+	if err != nil {
+		return nil, err
+	}
+	var schema spec.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("reading NodeConfig JSON Schema: %w", err)
+	}
+	return &Validator{
+		validator: validate.NewSchemaValidator(&schema, nil, "", strfmt.Default),
+	}, nil
+}
+
+// Validate validates every YAML document in data, skipping the empty ones, and returns
+// all the violations found.
+func (v *Validator) Validate(data []byte) []error {
+	var errs []error
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return append(errs, fmt.Errorf("reading yaml document %d: %w", i, err))
+		}
+
+		var obj any
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			errs = append(errs, fmt.Errorf("decoding yaml document %d: %w", i, err))
+			continue
+		}
+		// Documents with only comments or separators are decoded to nil.
+		if obj == nil {
+			continue
+		}
+		for _, err := range v.validator.Validate(obj).Errors {
+			// Errors on top level fields have an empty path prefix.
+			errs = append(errs, fmt.Errorf("yaml document %d: %s", i, strings.TrimPrefix(err.Error(), ".")))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,117 @@
+package schema_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api/schema"
+)
+
+func TestOpenAPI(t *testing.T) {
+	g := NewWithT(t)
+	openAPI, err := schema.OpenAPI()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(openAPI).To(HaveKeyWithValue("type", "object"))
+	g.Expect(openAPI["properties"]).To(HaveKey("spec"))
+	g.Expect(openAPI["properties"]).To(HaveKeyWithValue("apiVersion", HaveKeyWithValue("type", "string")))
+}
+
+func TestJSONSchema(t *testing.T) {
+	g := NewWithT(t)
+	jsonSchema, err := schema.JSONSchema()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(jsonSchema).To(HaveKeyWithValue("$schema", "http://json-schema.org/draft-07/schema#"))
+	g.Expect(jsonSchema).To(HaveKeyWithValue("required", ConsistOf("apiVersion", "kind")))
+	g.Expect(jsonSchema).To(HaveKeyWithValue("additionalProperties", false))
+
+	spec := jsonSchema["properties"].(map[string]any)["spec"].(map[string]any)
+	g.Expect(spec).To(HaveKeyWithValue("additionalProperties", false))
+	kubelet := spec["properties"].(map[string]any)["kubelet"].(map[string]any)
+	kubeletConfig := kubelet["properties"].(map[string]any)["config"].(map[string]any)
+	g.Expect(kubeletConfig).To(HaveKeyWithValue("additionalProperties", BeEmpty()))
+	g.Expect(kubeletConfig).To(HaveKeyWithValue("type", "object"))
+}
+
+func TestValidatorValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		wantErrs []string
+	}{
+		{
+			name: "valid",
+			data: `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+  kubelet:
+    config:
+      maxPods: 110
+      anything:
+        goes: here
+  hybrid:
+    ssm:
+      activationCode: code
+      activationId: id
+`,
+		},
+		{
+			name: "multiple documents with empty ones",
+			data: `---
+# base
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    nme: my-cluster
+`,
+			wantErrs: []string{"yaml document 3: spec.cluster.nme in body is a forbidden property"},
+		},
+		{
+			name: "wrong types and kind",
+			data: `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConf
+spec:
+  cluster:
+    name: 3
+  kubelet:
+    flags: --node-labels=a=b
+`,
+			wantErrs: []string{
+				`yaml document 1: kind in body should be one of [NodeConfig]`,
+				`yaml document 1: spec.cluster.name in body must be of type string: "number"`,
+				`yaml document 1: spec.kubelet.flags in body must be of type array: "string"`,
+			},
+		},
+		{
+			name:     "missing apiVersion",
+			data:     `kind: NodeConfig`,
+			wantErrs: []string{"yaml document 1: apiVersion in body is required"},
+		},
+	}
+
+	validator, err := schema.NewValidator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var messages []string
+			for _, err := range validator.Validate([]byte(tc.data)) {
+				messages = append(messages, err.Error())
+			}
+			g.Expect(messages).To(ConsistOf(tc.wantErrs))
+		})
+	}
+}
@@ -33,6 +33,20 @@ type NodeConfigSpec struct {
 	Instance   InstanceOptions   `json:"instance,omitempty"`
 	Kubelet    KubeletOptions    `json:"kubelet,omitempty"`
 	Hybrid     *HybridOptions    `json:"hybrid,omitempty"`
+	Hosts      []HostOverride    `json:"hosts,omitempty"`
+}
+
+type HostOverride struct {
+	Selector HostSelector   `json:"selector"`
+	Kubelet  KubeletOptions `json:"kubelet,omitempty"`
+	Hybrid   *HybridOptions `json:"hybrid,omitempty"`
+}
+
+type HostSelector struct {
+	Hostname   string `json:"hostname,omitempty"`
+	MACAddress string `json:"macAddress,omitempty"`
+	CIDR       string `json:"cidr,omitempty"`
+	Serial     string `json:"serial,omitempty"`
 }
 
 type NodeConfigStatus struct {
@@ -99,6 +113,7 @@ const (
 
 type InstanceOptions struct {
 	LocalStorage LocalStorageOptions `json:"localStorage,omitempty"`
+	Swap         SwapPolicy          `json:"swap,omitempty"`
 }
 
 type LocalStorageOptions struct {
@@ -112,19 +127,29 @@ const (
 	LocalStorageMount LocalStorageStrategy = "Mount"
 )
 
+type SwapPolicy string
+
+const (
+	SwapPolicyDisable SwapPolicy = "disable"
+	SwapPolicyLimited SwapPolicy = "limited"
+)
+
 type NodeType string
 
 const (
 	Ssm              NodeType = "ssm"
 	IamRolesAnywhere NodeType = "iam-ra"
+	ExternalProcess  NodeType = "credential-process"
 	Ec2              NodeType = "ec2"
 	Outpost          NodeType = "outpost"
 )
 
 type HybridOptions struct {
-	EnableCredentialsFile bool              `json:"enableCredentialsFile,omitempty"`
-	IAMRolesAnywhere      *IAMRolesAnywhere `json:"iamRolesAnywhere,omitempty"`
-	SSM                   *SSM              `json:"ssm,omitempty"`
+	EnableCredentialsFile bool               `json:"enableCredentialsFile,omitempty"`
+	IAMRolesAnywhere      *IAMRolesAnywhere  `json:"iamRolesAnywhere,omitempty"`
+	SSM                   *SSM               `json:"ssm,omitempty"`
+	CredentialProcess     *CredentialProcess `json:"credentialProcess,omitempty"`
+	Artifacts             *ArtifactSource    `json:"artifacts,omitempty"`
 }
 
 func (nc NodeConfig) IsHybridNode() bool {
@@ -144,11 +169,17 @@ func (nc NodeConfig) IsSSM() bool {
 	return nc.Spec.Hybrid != nil && nc.Spec.Hybrid.SSM != nil
 }
 
+func (nc NodeConfig) IsCredentialProcess() bool {
+	return nc.Spec.Hybrid != nil && nc.Spec.Hybrid.CredentialProcess != nil
+}
+
 func (nc NodeConfig) GetNodeType() NodeType {
 	if nc.IsSSM() {
 		return Ssm
 	} else if nc.IsIAMRolesAnywhere() {
 		return IamRolesAnywhere
+	} else if nc.IsCredentialProcess() {
+		return ExternalProcess
 	} else if nc.IsOutpostNode() {
 		return Outpost
 	}
@@ -169,3 +200,15 @@ type SSM struct {
 	ActivationCode string `json:"activationCode,omitempty"`
 	ActivationID   string `json:"activationId,omitempty"`
 }
+
+type CredentialProcess struct {
+	NodeName      string `json:"nodeName,omitempty"`
+	Command       string `json:"command,omitempty"`
+	AwsConfigPath string `json:"awsConfigPath,omitempty"`
+}
+
+type ArtifactSource struct {
+	ManifestURL    string `json:"manifestUrl,omitempty"`
+	MirrorURL      string `json:"mirrorUrl,omitempty"`
+	MirrorCABundle string `json:"mirrorCaBundle,omitempty"`
+}
@@ -0,0 +1,114 @@
+package artifact
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+// Backup is a copy of a set of files taken before they are modified, so they can
+// be restored to their original state.
+type Backup struct {
+	// Dir is the directory holding the copies.
+	Dir       string
+	CreatedAt time.Time
+	Files     []BackupFile
+}
+
+// BackupFile maps a file to its copy in the backup directory.
+type BackupFile struct {
+	Path       string
+	BackupPath string
+	Mode       fs.FileMode
+	// Missing is true when the file didn't exist when the backup was taken,
+	// in which case restoring the backup removes it.
+	Missing bool
+}
+
+// NewBackup copies every file in paths into dir with the Host in the context.
+// Directories are copied recursively. Any previous content of dir is removed.
+func NewBackup(ctx context.Context, dir string, paths []string) (*Backup, error) {
+	h := host.FromContext(ctx)
+	if err := h.RemoveAll(dir); err != nil {
+		return nil, errors.Wrapf(err, "removing previous backup %s", dir)
+	}
+	backup := &Backup{
+		Dir:       dir,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, path := range paths {
+		if err := backup.add(h, path); err != nil {
+			return nil, errors.Wrapf(err, "backing up %s", path)
+		}
+	}
+	return backup, nil
+}
+
+func (b *Backup) add(h host.Host, root string) error {
+	if _, err := os.Lstat(root); os.IsNotExist(err) {
+		b.Files = append(b.Files, BackupFile{Path: root, Missing: true})
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		backupPath := filepath.Join(b.Dir, path)
+		if err := copyFile(h, path, backupPath, info.Mode().Perm()); err != nil {
+			return err
+		}
+		b.Files = append(b.Files, BackupFile{
+			Path:       path,
+			BackupPath: backupPath,
+			Mode:       info.Mode().Perm(),
+		})
+		return nil
+	})
+}
+
+// Restore copies every file in the backup back to its original location and
+// removes the files that didn't exist when the backup was taken.
+func (b *Backup) Restore(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	for _, file := range b.Files {
+		if file.Missing {
+			if err := h.RemoveAll(file.Path); err != nil {
+				return errors.Wrapf(err, "removing %s", file.Path)
+			}
+			continue
+		}
+		if err := copyFile(h, file.BackupPath, file.Path, file.Mode); err != nil {
+			return errors.Wrapf(err, "restoring %s", file.Path)
+		}
+	}
+	return nil
+}
+
+// Remove deletes the backup directory.
+func (b *Backup) Remove(ctx context.Context) error {
+	return host.FromContext(ctx).RemoveAll(b.Dir)
+}
+
+func copyFile(h host.Host, src, dst string, perms fs.FileMode) error {
+	fh, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	return h.WriteFile(dst, fh, perms)
+}
@@ -0,0 +1,49 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBackupRestore(t *testing.T) {
+	g := NewWithT(t)
+	root := t.TempDir()
+
+	binPath := filepath.Join(root, "usr/bin/kubelet")
+	pluginDir := filepath.Join(root, "opt/cni/bin")
+	pluginPath := filepath.Join(pluginDir, "bridge")
+	missingPath := filepath.Join(root, "etc/kubernetes/new-config")
+	g.Expect(InstallFile(binPath, strings.NewReader("old kubelet"), 0o755)).To(Succeed())
+	g.Expect(InstallFile(pluginPath, strings.NewReader("old bridge"), 0o755)).To(Succeed())
+
+	backup, err := NewBackup(context.Background(), filepath.Join(root, "backup"), []string{binPath, pluginDir, missingPath})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(backup.Files).To(HaveLen(3))
+
+	g.Expect(InstallFile(binPath, strings.NewReader("new kubelet"), 0o755)).To(Succeed())
+	g.Expect(InstallFile(pluginPath, strings.NewReader("new bridge"), 0o755)).To(Succeed())
+	g.Expect(InstallFile(missingPath, strings.NewReader("new config"), 0o644)).To(Succeed())
+
+	g.Expect(backup.Restore(context.Background())).To(Succeed())
+
+	data, err := os.ReadFile(binPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal("old kubelet"))
+	info, err := os.Stat(binPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(BeEquivalentTo(0o755))
+
+	data, err = os.ReadFile(pluginPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal("old bridge"))
+
+	g.Expect(missingPath).NotTo(BeAnExistingFile())
+
+	g.Expect(backup.Remove(context.Background())).To(Succeed())
+	g.Expect(backup.Dir).NotTo(BeADirectory())
+}
@@ -2,6 +2,7 @@ package artifact
 
 const (
 	CniPlugins              = "cniPlugins"
+	CredentialProcess       = "credentialProcess"
 	IamAuthenticator        = "iamAuthenticator"
 	IamRolesAnywhere        = "iamRolesAnywhere"
 	ImageCredentialProvider = "imageCredentialProvider"
@@ -9,5 +10,7 @@ const (
 	Kubelet                 = "kubelet"
 	Ssm                     = "ssm"
 	Containerd              = "containerd"
+	ContainerdShim          = "containerdShim"
+	Runc                    = "runc"
 	Iptables                = "iptables"
 )
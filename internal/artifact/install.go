@@ -1,52 +1,37 @@
 package artifact
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
-	"os"
 	"os/exec"
-	"path"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 // DefaultDirPerms are the permissions assigned to a directory when an Install* func is called
 // and it has to create the parent directories for the destination.
-const DefaultDirPerms = fs.ModeDir | 0o755
+const DefaultDirPerms = host.DefaultDirPerms
 
 // InstallFile installs src to dst with perms permissions. It ensures any base paths exist
 // before installing.
 func InstallFile(dst string, src io.Reader, perms fs.FileMode) error {
-	if err := os.RemoveAll(dst); err != nil {
-		return err
-	}
-	if err := os.MkdirAll(path.Dir(dst), DefaultDirPerms); err != nil {
-		return err
-	}
-
-	fh, err := os.OpenFile(dst, os.O_CREATE|os.O_RDWR|os.O_TRUNC, perms)
-	if err != nil {
-		return err
-	}
-	defer fh.Close()
-
-	_, err = io.Copy(fh, src)
-	return err
+	return host.OS{}.WriteFile(dst, src, perms)
 }
 
 // InstallTarGz untars the src file into the dst directory and deletes the src tgz file
-func InstallTarGz(dst, src string) error {
-	if err := os.MkdirAll(dst, DefaultDirPerms); err != nil {
+// using the Host in the context.
+func InstallTarGz(ctx context.Context, dst, src string) error {
+	h := host.FromContext(ctx)
+	if err := h.MkdirAll(dst, DefaultDirPerms); err != nil {
 		return err
 	}
 
-	tgzExtractCmd := exec.Command("tar", "xvf", src, "-C", dst)
-	if err := tgzExtractCmd.Run(); err != nil {
-		return fmt.Errorf("unable to untar: %v", err)
+	if out, err := h.Run(exec.Command("tar", "xvf", src, "-C", dst)); err != nil {
+		return fmt.Errorf("unable to untar: %s: %v", out, err)
 	}
 
 	// Remove the tgz file
-	if err := os.Remove(src); err != nil {
-		return err
-	}
-	return nil
+	return h.RemoveAll(src)
 }
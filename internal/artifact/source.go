@@ -56,3 +56,38 @@ func WithNopChecksum(rc io.ReadCloser) Source {
 		ChecksumVerifier: nopChecksumVerifier{},
 	}
 }
+
+// Metadata describes where the data of a Source comes from.
+type Metadata struct {
+	// Version is the release version the artifact belongs to.
+	Version string
+	// URI is the location the artifact is read from.
+	URI string
+}
+
+type metadataSource interface {
+	Metadata() Metadata
+}
+
+// WithMetadata attaches m to src. It can be retrieved with GetMetadata.
+func WithMetadata(src Source, m Metadata) Source {
+	return sourceWithMetadata{Source: src, metadata: m}
+}
+
+// GetMetadata returns the Metadata attached to src with WithMetadata.
+// If src has no Metadata, it returns the zero value.
+func GetMetadata(src Source) Metadata {
+	if s, ok := src.(metadataSource); ok {
+		return s.Metadata()
+	}
+	return Metadata{}
+}
+
+type sourceWithMetadata struct {
+	Source
+	metadata Metadata
+}
+
+func (s sourceWithMetadata) Metadata() Metadata {
+	return s.metadata
+}
@@ -2,6 +2,7 @@ package artifact
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 // checksumMatch compares the checksum of the installed artifact with the expected checksum
@@ -29,15 +32,15 @@ func checksumMatch(installedArtifactPath string, src Source) (bool, error) {
 }
 
 // Upgrade upgrades an artifact from the source only if the expected checksum doesn't match with the
-// checksum of artifact already installed.
-func Upgrade(artifactName, path string, source Source, perms fs.FileMode, log *zap.Logger) error {
+// checksum of artifact already installed. The artifact is written with the Host in the context.
+func Upgrade(ctx context.Context, artifactName, path string, source Source, perms fs.FileMode, log *zap.Logger) error {
 	match, err := checksumMatch(path, source)
 	if err != nil {
 		return err
 	}
 
 	if !match {
-		if err := InstallFile(path, source, perms); err != nil {
+		if err := host.FromContext(ctx).WriteFile(path, source, perms); err != nil {
 			return errors.Wrapf(err, "installing %s", artifactName)
 		}
 
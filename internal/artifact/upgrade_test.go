@@ -2,6 +2,7 @@ package artifact
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"io"
@@ -106,7 +107,7 @@ func TestUpgrade(t *testing.T) {
 			source, err := WithChecksum(io.NopCloser(bytes.NewBufferString(tt.upgradedData)), sha256.New(), tt.sourceChecksum)
 			g.Expect(err).To(BeNil())
 
-			err = Upgrade("dummyArtifact", artifact.Name(), source, 0o755, zap.NewNop())
+			err = Upgrade(context.Background(), "dummyArtifact", artifact.Name(), source, 0o755, zap.NewNop())
 			g.Expect(err).To(BeNil())
 
 			// Check upgraded written data
@@ -0,0 +1,298 @@
+package aws
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	bundleManifestFile   = "manifest.yaml"
+	bundleArtifactsDir   = "artifacts"
+	bundleFilePerms      = 0o644
+	bundleExtractDirName = "nodeadm-bundle-*"
+	// bundleOS is the only OS nodeadm runs on, so it's the only one bundled
+	// regardless of where the bundle is created.
+	bundleOS = "linux"
+)
+
+// CreateBundle resolves the EKS release for eksVersion, the latest IAM Roles Anywhere release
+// and the containerd release, if any, from the release manifest and writes a gzipped tarball
+// to w. The bundle contains a manifest restricted to those releases plus every artifact and
+// checksum file for linux on the given arch, so it can later be installed without network access
+// with OpenBundle.
+func CreateBundle(ctx context.Context, eksVersion, arch string, w io.Writer, opts ...SourceOption) (Source, error) {
+	source, err := GetLatestSource(ctx, eksVersion, opts...)
+	if err != nil {
+		return Source{}, err
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	eksArtifacts, err := addBundleArtifacts(ctx, tw, "eks "+source.Eks.Version, source.Eks.Artifacts, arch, source.httpOpts...)
+	if err != nil {
+		return Source{}, err
+	}
+	iamArtifacts, err := addBundleArtifacts(ctx, tw, "iam-roles-anywhere "+source.Iam.Version, source.Iam.Artifacts, arch, source.httpOpts...)
+	if err != nil {
+		return Source{}, err
+	}
+	containerdArtifacts, err := addBundleArtifacts(ctx, tw, "containerd "+source.Containerd.Version, source.Containerd.Artifacts, arch, source.httpOpts...)
+	if err != nil {
+		return Source{}, err
+	}
+
+	eksRelease := source.Eks
+	eksRelease.Artifacts = eksArtifacts
+	iamRelease := source.Iam
+	iamRelease.Artifacts = iamArtifacts
+
+	bundleManifest := Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  strings.TrimPrefix(semver.MajorMinor("v"+eksVersion), "v"),
+				LatestPatchVersion: eksRelease.PatchVersion,
+				PatchReleases:      []EksPatchRelease{eksRelease},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{iamRelease},
+	}
+	if source.Containerd.Version != "" {
+		containerdRelease := source.Containerd
+		containerdRelease.Artifacts = containerdArtifacts
+		bundleManifest.ContainerdReleases = []ContainerdRelease{containerdRelease}
+	}
+	manifestData, err := yaml.Marshal(bundleManifest)
+	if err != nil {
+		return Source{}, errors.Wrap(err, "marshalling bundle manifest")
+	}
+	if err := writeTarFile(tw, bundleManifestFile, int64(len(manifestData)), bytes.NewReader(manifestData)); err != nil {
+		return Source{}, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return Source{}, errors.Wrap(err, "closing bundle tar")
+	}
+	if err := gzw.Close(); err != nil {
+		return Source{}, errors.Wrap(err, "closing bundle gzip")
+	}
+
+	return source, nil
+}
+
+// addBundleArtifacts downloads every linux artifact for arch into the tarball, verifying its checksum,
+// and returns the artifacts with their URIs rewritten to paths relative to the bundle root.
+// It fails if the release has artifacts but none of them are for linux on arch.
+func addBundleArtifacts(ctx context.Context, tw *tar.Writer, release string, artifacts []Artifact, arch string, httpOpts ...util.HttpOption) ([]Artifact, error) {
+	var bundled []Artifact
+	for _, releaseArtifact := range artifacts {
+		if releaseArtifact.Arch != arch || releaseArtifact.OS != bundleOS {
+			continue
+		}
+
+		dir := path.Join(bundleArtifactsDir, releaseArtifact.OS, releaseArtifact.Arch, releaseArtifact.Name)
+		artifactPath, err := bundlePath(dir, releaseArtifact.URI)
+		if err != nil {
+			return nil, err
+		}
+		checksumPath, err := bundlePath(dir, releaseArtifact.ChecksumURI)
+		if err != nil {
+			return nil, err
+		}
+
+		checksum, err := getArtifactFile(ctx, releaseArtifact.ChecksumURI, httpOpts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "downloading checksum for %s", releaseArtifact.Name)
+		}
+		if err := addBundleArtifact(ctx, tw, artifactPath, releaseArtifact.URI, checksum, httpOpts...); err != nil {
+			return nil, errors.Wrapf(err, "adding %s to bundle", releaseArtifact.Name)
+		}
+		if err := writeTarFile(tw, checksumPath, int64(len(checksum)), bytes.NewReader(checksum)); err != nil {
+			return nil, err
+		}
+
+		releaseArtifact.URI = artifactPath
+		releaseArtifact.ChecksumURI = checksumPath
+		bundled = append(bundled, releaseArtifact)
+	}
+	if len(artifacts) > 0 && len(bundled) == 0 {
+		return nil, fmt.Errorf("no %s/%s artifacts found for %s release", bundleOS, arch, release)
+	}
+	return bundled, nil
+}
+
+// addBundleArtifact downloads the artifact to a temporary file, since the tar header
+// needs the size upfront, and then copies it into the tarball.
+func addBundleArtifact(ctx context.Context, tw *tar.Writer, name, uri string, checksum []byte, httpOpts ...util.HttpOption) error {
+	obj, err := getArtifactReader(ctx, uri, httpOpts...)
+	if err != nil {
+		return err
+	}
+	source, err := artifact.WithChecksum(obj, sha256.New(), checksum)
+	if err != nil {
+		obj.Close()
+		return err
+	}
+	defer source.Close()
+
+	tmp, err := os.CreateTemp("", path.Base(name))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, source)
+	if err != nil {
+		return err
+	}
+	if !source.VerifyChecksum() {
+		return artifact.NewChecksumError(source)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return writeTarFile(tw, name, size, tmp)
+}
+
+func writeTarFile(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     bundleFilePerms,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Wrapf(err, "writing tar header for %s", name)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return errors.Wrapf(err, "writing %s to bundle", name)
+	}
+	return nil
+}
+
+func bundlePath(dir, uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing artifact uri %s", uri)
+	}
+	return path.Join(dir, path.Base(parsed.Path)), nil
+}
+
+// Bundle is a local copy of the release manifest and its artifacts,
+// extracted from a tarball created with CreateBundle.
+type Bundle struct {
+	dir      string
+	manifest *Manifest
+}
+
+// OpenBundle extracts the bundle tarball at bundlePath into a temporary directory
+// and loads its manifest. Callers must call Close once they are done with the Bundle.
+func OpenBundle(bundlePath string) (*Bundle, error) {
+	dir, err := os.MkdirTemp("", bundleExtractDirName)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{dir: dir}
+
+	if err := extractBundle(bundlePath, dir); err != nil {
+		bundle.Close()
+		return nil, errors.Wrapf(err, "extracting bundle %s", bundlePath)
+	}
+
+	manifestData, err := os.ReadFile(filepath.Join(dir, bundleManifestFile))
+	if err != nil {
+		bundle.Close()
+		return nil, errors.Wrap(err, "reading bundle manifest")
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(manifestData, &manifest); err != nil {
+		bundle.Close()
+		return nil, errors.Wrap(err, "invalid yaml data in bundle manifest")
+	}
+	bundle.manifest = &manifest
+
+	return bundle, nil
+}
+
+// GetSource returns a Source for the given eks and containerd versions backed by the files
+// in the bundle. An empty containerdVersion uses the containerd release in the bundle, if any.
+// Checksums are still verified when the artifacts are read.
+func (b *Bundle) GetSource(eksVersion, containerdVersion string) (Source, error) {
+	source, err := getLatestSourceFromManifest(eksVersion, b.manifest, containerdVersion)
+	if err != nil {
+		return Source{}, errors.Wrap(err, "bundle does not contain the requested version")
+	}
+	source.Eks.Artifacts = b.localArtifacts(source.Eks.Artifacts)
+	source.Iam.Artifacts = b.localArtifacts(source.Iam.Artifacts)
+	source.Containerd.Artifacts = b.localArtifacts(source.Containerd.Artifacts)
+	return source, nil
+}
+
+func (b *Bundle) localArtifacts(artifacts []Artifact) []Artifact {
+	local := make([]Artifact, 0, len(artifacts))
+	for _, a := range artifacts {
+		a.URI = fileURIScheme + filepath.Join(b.dir, a.URI)
+		a.ChecksumURI = fileURIScheme + filepath.Join(b.dir, a.ChecksumURI)
+		local = append(local, a)
+	}
+	return local
+}
+
+// Close removes the extracted bundle files.
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.dir)
+}
+
+func extractBundle(bundlePath, dst string) error {
+	fh, err := os.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	gzr, err := gzip.NewReader(fh)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		target := filepath.Join(dst, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(dst)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file path in bundle: %s", header.Name)
+		}
+		if err := artifact.InstallFile(target, tr, bundleFilePerms); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+func newManifestServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write(data); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testChecksum(data []byte, name string) []byte {
+	return []byte(fmt.Sprintf("%x %s", sha256.Sum256(data), name))
+}
+
+func setManifestURL(t *testing.T, url string) {
+	original := manifestUrl
+	manifestUrl = url
+	t.Cleanup(func() { manifestUrl = original })
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	kubelet := []byte("kubelet binary")
+	signingHelper := []byte("signing helper binary")
+	files := map[string][]byte{
+		"/1.31.2/kubelet":                kubelet,
+		"/1.31.2/kubelet.sha256":         testChecksum(kubelet, "kubelet"),
+		"/1.31.2/other-arch/kubelet":     []byte("other arch"),
+		"/iam/aws_signing_helper":        signingHelper,
+		"/iam/aws_signing_helper.sha256": testChecksum(signingHelper, "aws_signing_helper"),
+		"/1.31.1/kubelet":                []byte("old kubelet"),
+		"/1.31.1/kubelet.sha256":         testChecksum([]byte("old kubelet"), "kubelet"),
+	}
+	server := newManifestServer(t, files)
+
+	otherArch := "arm64"
+	if runtime.GOARCH == otherArch {
+		otherArch = "amd64"
+	}
+	artifactFor := func(name, path string) Artifact {
+		return Artifact{Name: name, Arch: runtime.GOARCH, OS: bundleOS, URI: server.URL + path, ChecksumURI: server.URL + path + ".sha256"}
+	}
+	manifest := Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.1", PatchVersion: "1", ReleaseDate: "2024-10-01", Artifacts: []Artifact{artifactFor("kubelet", "/1.31.1/kubelet")}},
+					{Version: "1.31.2", PatchVersion: "2", ReleaseDate: "2024-11-01", Artifacts: []Artifact{
+						artifactFor("kubelet", "/1.31.2/kubelet"),
+						{Name: "kubelet", Arch: otherArch, OS: bundleOS, URI: server.URL + "/1.31.2/other-arch/kubelet"},
+					}},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{
+			{Version: "v1.2.0", Artifacts: []Artifact{artifactFor("aws_signing_helper", "/iam/aws_signing_helper")}},
+		},
+	}
+	manifestData, err := yaml.Marshal(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+	files["/manifest.yaml"] = manifestData
+	setManifestURL(t, server.URL+"/manifest.yaml")
+
+	var buf bytes.Buffer
+	source, err := CreateBundle(ctx, "1.31", runtime.GOARCH, &buf)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.Eks.Version).To(Equal("1.31.2"))
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tgz")
+	g.Expect(os.WriteFile(bundlePath, buf.Bytes(), 0o644)).To(Succeed())
+
+	// Make sure the bundle doesn't depend on the server anymore.
+	server.Close()
+
+	bundle, err := OpenBundle(bundlePath)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer bundle.Close()
+
+	bundleSource, err := bundle.GetSource("1.31", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(bundleSource.Eks.Version).To(Equal("1.31.2"))
+	g.Expect(bundleSource.Eks.Artifacts).To(HaveLen(1))
+
+	kubeletSource, err := bundleSource.GetKubelet(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer kubeletSource.Close()
+	data, err := io.ReadAll(kubeletSource)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(kubelet))
+	g.Expect(kubeletSource.VerifyChecksum()).To(BeTrue())
+
+	helperSource, err := bundleSource.GetSigningHelper(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer helperSource.Close()
+	data, err = io.ReadAll(helperSource)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(signingHelper))
+	g.Expect(helperSource.VerifyChecksum()).To(BeTrue())
+
+	_, err = bundle.GetSource("1.30", "")
+	g.Expect(err).To(MatchError(ContainSubstring("bundle does not contain the requested version")))
+
+	_, err = bundle.GetSource("1.31", "1.7")
+	g.Expect(err).To(MatchError(ContainSubstring("no containerd release found for version 1.7")))
+}
+
+func TestCreateBundleChecksumMismatch(t *testing.T) {
+	g := NewWithT(t)
+
+	kubelet := []byte("kubelet binary")
+	files := map[string][]byte{
+		"/kubelet":        kubelet,
+		"/kubelet.sha256": testChecksum([]byte("something else"), "kubelet"),
+	}
+	server := newManifestServer(t, files)
+	manifest := Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.2", PatchVersion: "2", Artifacts: []Artifact{
+						{Name: "kubelet", Arch: runtime.GOARCH, OS: bundleOS, URI: server.URL + "/kubelet", ChecksumURI: server.URL + "/kubelet.sha256"},
+					}},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{{Version: "v1.2.0"}},
+	}
+	manifestData, err := yaml.Marshal(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+	files["/manifest.yaml"] = manifestData
+	setManifestURL(t, server.URL+"/manifest.yaml")
+
+	_, err = CreateBundle(context.Background(), "1.31", runtime.GOARCH, io.Discard)
+	g.Expect(err).To(MatchError(ContainSubstring("checksum mismatch")))
+}
+
+func TestCreateBundleNoMatchingArtifacts(t *testing.T) {
+	g := NewWithT(t)
+
+	files := map[string][]byte{}
+	server := newManifestServer(t, files)
+	manifest := Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.2", PatchVersion: "2", Artifacts: []Artifact{
+						{Name: "kubelet", Arch: runtime.GOARCH, OS: "darwin", URI: server.URL + "/kubelet", ChecksumURI: server.URL + "/kubelet.sha256"},
+					}},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{{Version: "v1.2.0"}},
+	}
+	manifestData, err := yaml.Marshal(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+	files["/manifest.yaml"] = manifestData
+	setManifestURL(t, server.URL+"/manifest.yaml")
+
+	_, err = CreateBundle(context.Background(), "1.31", runtime.GOARCH, io.Discard)
+	g.Expect(err).To(MatchError(ContainSubstring("no linux/" + runtime.GOARCH + " artifacts found for eks 1.31.2 release")))
+}
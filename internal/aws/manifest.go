@@ -16,6 +16,7 @@ type Manifest struct {
 	SupportedEksReleases     []SupportedEksRelease     `json:"supported_eks_releases"`
 	IamRolesAnywhereReleases []IamRolesAnywhereRelease `json:"iam_roles_anywhere_releases"`
 	SsmReleases              []SsmRelease              `json:"ssm_releases"`
+	ContainerdReleases       []ContainerdRelease       `json:"containerd_releases,omitempty"`
 }
 
 type SupportedEksRelease struct {
@@ -41,6 +42,13 @@ type SsmRelease struct {
 	Artifacts []Artifact `json:"artifacts"`
 }
 
+// ContainerdRelease holds the containerd, runc and containerd shim archives
+// installed with the eks containerd source.
+type ContainerdRelease struct {
+	Version   string     `json:"version"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
 type Artifact struct {
 	Name        string `json:"name"`
 	Arch        string `json:"arch"`
@@ -50,8 +58,8 @@ type Artifact struct {
 }
 
 // Read from the manifest file on s3 and parse into Manifest struct
-func getReleaseManifest(ctx context.Context) (*Manifest, error) {
-	yamlFileData, err := util.GetHttpFile(ctx, manifestUrl)
+func getReleaseManifest(ctx context.Context, url string, opts ...util.HttpOption) (*Manifest, error) {
+	yamlFileData, err := util.GetHttpFile(ctx, url, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// SourceOption configures where the release manifest and artifacts are downloaded from.
+type SourceOption func(*sourceOptions)
+
+type sourceOptions struct {
+	manifestURL       string
+	mirror            *Mirror
+	containerdVersion string
+}
+
+// WithManifestURL overrides the build-time release manifest URL.
+func WithManifestURL(url string) SourceOption {
+	return func(o *sourceOptions) {
+		if url != "" {
+			o.manifestURL = url
+		}
+	}
+}
+
+// WithMirror redirects all artifact downloads to mirror.
+func WithMirror(mirror Mirror) SourceOption {
+	return func(o *sourceOptions) {
+		if mirror.URL != "" {
+			o.mirror = &mirror
+		}
+	}
+}
+
+// WithContainerdVersion selects the containerd release matching the major.minor[.patch]
+// version instead of the latest one.
+func WithContainerdVersion(version string) SourceOption {
+	return func(o *sourceOptions) {
+		o.containerdVersion = version
+	}
+}
+
+func newSourceOptions(opts ...SourceOption) sourceOptions {
+	o := sourceOptions{
+		manifestURL: manifestUrl,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Mirror is a private copy of the artifacts listed in the release manifest.
+// The scheme and host of every artifact and checksum URI are replaced by URL,
+// and the original path is appended to the path of URL.
+type Mirror struct {
+	// URL is the mirror prefix, for example https://mirror.example.com/eks-hybrid.
+	URL string
+	// CABundlePath is an optional PEM file with additional CAs to trust when talking to the mirror.
+	CABundlePath string
+	// Username and Password configure basic auth for the mirror.
+	Username string
+	Password string
+	// BearerToken configures bearer token auth for the mirror. It takes precedence over basic auth.
+	BearerToken string
+}
+
+// RewriteURI returns uri pointing to the mirror.
+func (m Mirror) RewriteURI(uri string) (string, error) {
+	mirrorURL, err := url.Parse(m.URL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing mirror url %s", m.URL)
+	}
+	artifactURL, err := url.Parse(uri)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing artifact uri %s", uri)
+	}
+
+	rewritten := *mirrorURL
+	rewritten.Path = path.Join("/", mirrorURL.Path, artifactURL.Path)
+	rewritten.RawQuery = artifactURL.RawQuery
+	return rewritten.String(), nil
+}
+
+// isMirrorURI returns true if uri points to the same host as the mirror.
+func (m Mirror) isMirrorURI(uri string) bool {
+	mirrorURL, err := url.Parse(m.URL)
+	if err != nil {
+		return false
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == mirrorURL.Scheme && parsed.Host == mirrorURL.Host
+}
+
+func (m Mirror) httpOptions() ([]util.HttpOption, error) {
+	var opts []util.HttpOption
+
+	if m.CABundlePath != "" {
+		client, err := newHttpClientWithCABundle(m.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, util.WithHttpClient(client))
+	}
+
+	if m.BearerToken != "" {
+		opts = append(opts, util.WithHeader("Authorization", "Bearer "+m.BearerToken))
+	} else if m.Username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(m.Username + ":" + m.Password))
+		opts = append(opts, util.WithHeader("Authorization", "Basic "+credentials))
+	}
+
+	return opts, nil
+}
+
+func (m Mirror) apply(source Source) (Source, error) {
+	httpOpts, err := m.httpOptions()
+	if err != nil {
+		return Source{}, err
+	}
+
+	eksArtifacts, err := m.rewriteArtifacts(source.Eks.Artifacts)
+	if err != nil {
+		return Source{}, err
+	}
+	iamArtifacts, err := m.rewriteArtifacts(source.Iam.Artifacts)
+	if err != nil {
+		return Source{}, err
+	}
+
+	containerdArtifacts, err := m.rewriteArtifacts(source.Containerd.Artifacts)
+	if err != nil {
+		return Source{}, err
+	}
+
+	source.Eks.Artifacts = eksArtifacts
+	source.Iam.Artifacts = iamArtifacts
+	source.Containerd.Artifacts = containerdArtifacts
+	source.httpOpts = httpOpts
+	return source, nil
+}
+
+func (m Mirror) rewriteArtifacts(artifacts []Artifact) ([]Artifact, error) {
+	rewritten := make([]Artifact, 0, len(artifacts))
+	for _, a := range artifacts {
+		var err error
+		if a.URI, err = m.RewriteURI(a.URI); err != nil {
+			return nil, err
+		}
+		if a.ChecksumURI, err = m.RewriteURI(a.ChecksumURI); err != nil {
+			return nil, err
+		}
+		rewritten = append(rewritten, a)
+	}
+	return rewritten, nil
+}
+
+func newHttpClientWithCABundle(caBundlePath string) (*http.Client, error) {
+	caBundle, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading mirror CA bundle")
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no valid certificates found in mirror CA bundle %s", caBundlePath)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}, nil
+}
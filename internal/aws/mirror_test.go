@@ -0,0 +1,126 @@
+package aws
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMirrorRewriteURI(t *testing.T) {
+	tests := []struct {
+		name   string
+		mirror string
+		uri    string
+		want   string
+	}{
+		{
+			name:   "host only mirror",
+			mirror: "https://mirror.example.com",
+			uri:    "https://hybrid-assets.eks.amazonaws.com/releases/v1.31.2/bin/linux/amd64/kubelet",
+			want:   "https://mirror.example.com/releases/v1.31.2/bin/linux/amd64/kubelet",
+		},
+		{
+			name:   "mirror with path prefix",
+			mirror: "http://mirror.example.com:8080/eks-hybrid/",
+			uri:    "https://hybrid-assets.eks.amazonaws.com/releases/v1.31.2/bin/linux/amd64/kubelet.sha256",
+			want:   "http://mirror.example.com:8080/eks-hybrid/releases/v1.31.2/bin/linux/amd64/kubelet.sha256",
+		},
+		{
+			name:   "keeps query",
+			mirror: "https://mirror.example.com/prefix",
+			uri:    "https://bucket.s3.amazonaws.com/kubelet?versionId=1",
+			want:   "https://mirror.example.com/prefix/kubelet?versionId=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := Mirror{URL: tt.mirror}.RewriteURI(tt.uri)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetLatestSourceWithMirror(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	kubelet := []byte("kubelet binary")
+	manifest := Manifest{
+		SupportedEksReleases: []SupportedEksRelease{
+			{
+				MajorMinorVersion:  "1.31",
+				LatestPatchVersion: "2",
+				PatchReleases: []EksPatchRelease{
+					{Version: "1.31.2", PatchVersion: "2", Artifacts: []Artifact{
+						{
+							Name:        "kubelet",
+							Arch:        runtime.GOARCH,
+							OS:          runtime.GOOS,
+							URI:         "https://hybrid-assets.eks.amazonaws.com/releases/kubelet",
+							ChecksumURI: "https://hybrid-assets.eks.amazonaws.com/releases/kubelet.sha256",
+						},
+					}},
+				},
+			},
+		},
+		IamRolesAnywhereReleases: []IamRolesAnywhereRelease{{Version: "v1.2.0"}},
+	}
+	manifestData, err := yaml.Marshal(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	files := map[string][]byte{
+		"/mirror/manifest.yaml":           manifestData,
+		"/mirror/releases/kubelet":        kubelet,
+		"/mirror/releases/kubelet.sha256": testChecksum(kubelet, "kubelet"),
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	g.Expect(os.WriteFile(caBundlePath, caBundle, 0o644)).To(Succeed())
+
+	mirror := Mirror{
+		URL:          server.URL + "/mirror",
+		CABundlePath: caBundlePath,
+		Username:     "user",
+		Password:     "pass",
+	}
+	source, err := GetLatestSource(ctx, "1.31", WithManifestURL(server.URL+"/mirror/manifest.yaml"), WithMirror(mirror))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(source.Eks.Artifacts[0].URI).To(Equal(server.URL + "/mirror/releases/kubelet"))
+
+	kubeletSource, err := source.GetKubelet(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer kubeletSource.Close()
+	data, err := io.ReadAll(kubeletSource)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(kubelet))
+	g.Expect(kubeletSource.VerifyChecksum()).To(BeTrue())
+
+	_, err = GetLatestSource(ctx, "1.31", WithManifestURL(server.URL+"/mirror/manifest.yaml"), WithMirror(Mirror{URL: server.URL + "/mirror"}))
+	g.Expect(err).To(HaveOccurred())
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
 	"strings"
 	"time"
@@ -15,19 +17,54 @@ import (
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
+const fileURIScheme = "file://"
+
+// MinContainerdVersion, included, and MaxContainerdVersion, excluded, bound the
+// containerd releases of the manifest that can be installed.
+const (
+	MinContainerdVersion = "1.7.0"
+	MaxContainerdVersion = "2.0.0"
+)
+
 // Source defines a single version source for aws provided artifacts
 type Source struct {
 	Eks EksPatchRelease
 	Iam IamRolesAnywhereRelease
+	// Containerd is empty if the manifest doesn't list containerd releases.
+	Containerd ContainerdRelease
+
+	httpOpts []util.HttpOption
 }
 
 // GetLatestSource gets the source for latest version of aws provided artifacts
-func GetLatestSource(ctx context.Context, eksVersion string) (Source, error) {
-	manifest, err := getReleaseManifest(ctx)
+func GetLatestSource(ctx context.Context, eksVersion string, opts ...SourceOption) (Source, error) {
+	o := newSourceOptions(opts...)
+
+	var manifestHttpOpts []util.HttpOption
+	if o.mirror != nil && o.mirror.isMirrorURI(o.manifestURL) {
+		var err error
+		if manifestHttpOpts, err = o.mirror.httpOptions(); err != nil {
+			return Source{}, err
+		}
+	}
+
+	manifest, err := getReleaseManifest(ctx, o.manifestURL, manifestHttpOpts...)
 	if err != nil {
 		return Source{}, err
 	}
 
+	source, err := getLatestSourceFromManifest(eksVersion, manifest, o.containerdVersion)
+	if err != nil {
+		return Source{}, err
+	}
+
+	if o.mirror != nil {
+		return o.mirror.apply(source)
+	}
+	return source, nil
+}
+
+func getLatestSourceFromManifest(eksVersion string, manifest *Manifest, containerdVersion string) (Source, error) {
 	eksPatchRelease, err := getLatestEksSource(eksVersion, manifest)
 	if err != nil {
 		return Source{}, errors.Wrap(err, "getting latest eks release")
@@ -38,12 +75,42 @@ func GetLatestSource(ctx context.Context, eksVersion string) (Source, error) {
 		return Source{}, errors.Wrap(err, "getting iam roles anywhere release")
 	}
 
+	containerdRelease, err := getContainerdSource(manifest, containerdVersion)
+	if err != nil {
+		return Source{}, errors.Wrap(err, "getting containerd release")
+	}
+
 	return Source{
-		Eks: eksPatchRelease,
-		Iam: iamRolesAnywhereRelease,
+		Eks:        eksPatchRelease,
+		Iam:        iamRolesAnywhereRelease,
+		Containerd: containerdRelease,
 	}, nil
 }
 
+// getContainerdSource returns the latest supported containerd release matching the
+// major.minor[.patch] version, or the latest supported release if version is empty.
+// Manifests without supported containerd releases return an empty release unless a
+// version is requested.
+func getContainerdSource(manifest *Manifest, version string) (ContainerdRelease, error) {
+	var latestRelease ContainerdRelease
+	for _, release := range manifest.ContainerdReleases {
+		releaseVersion := strings.TrimPrefix(release.Version, "v")
+		if semver.Compare("v"+releaseVersion, "v"+MinContainerdVersion) < 0 || semver.Compare("v"+releaseVersion, "v"+MaxContainerdVersion) >= 0 {
+			continue
+		}
+		if version != "" && releaseVersion != version && !strings.HasPrefix(releaseVersion, version+".") {
+			continue
+		}
+		if latestRelease.Version == "" || semver.Compare("v"+strings.TrimPrefix(latestRelease.Version, "v"), "v"+releaseVersion) < 0 {
+			latestRelease = release
+		}
+	}
+	if version != "" && latestRelease.Version == "" {
+		return ContainerdRelease{}, fmt.Errorf("no containerd release found for version %s", version)
+	}
+	return latestRelease, nil
+}
+
 func getLatestIamRolesAnywhereSource(manifest *Manifest) (IamRolesAnywhereRelease, error) {
 	if len(manifest.IamRolesAnywhereReleases) < 1 {
 		return IamRolesAnywhereRelease{}, fmt.Errorf("no iam signer helper releases found")
@@ -157,23 +224,42 @@ func (as Source) GetCniPlugins(ctx context.Context) (artifact.Source, error) {
 }
 
 func (as Source) getEksSource(ctx context.Context, artifactName string) (artifact.Source, error) {
-	return getSource(ctx, artifactName, as.Eks.Artifacts)
+	return as.getSource(ctx, artifactName, as.Eks.Version, as.Eks.Artifacts)
+}
+
+// GetContainerdArchive satisfies containerd.ReleaseSource.
+func (as Source) GetContainerdArchive(ctx context.Context) (artifact.Source, error) {
+	return as.getContainerdSource(ctx, "containerd")
+}
+
+// GetRuncArchive satisfies containerd.ReleaseSource.
+func (as Source) GetRuncArchive(ctx context.Context) (artifact.Source, error) {
+	return as.getContainerdSource(ctx, "runc")
+}
+
+// GetContainerdShimArchive satisfies containerd.ReleaseSource.
+func (as Source) GetContainerdShimArchive(ctx context.Context) (artifact.Source, error) {
+	return as.getContainerdSource(ctx, "containerd-shim-runc-v2")
+}
+
+func (as Source) getContainerdSource(ctx context.Context, artifactName string) (artifact.Source, error) {
+	return as.getSource(ctx, artifactName, as.Containerd.Version, as.Containerd.Artifacts)
 }
 
 // GetSingingHelper satisfies iamrolesanywhere.SigningHelperSource
 func (as Source) GetSigningHelper(ctx context.Context) (artifact.Source, error) {
-	return getSource(ctx, "aws_signing_helper", as.Iam.Artifacts)
+	return as.getSource(ctx, "aws_signing_helper", as.Iam.Version, as.Iam.Artifacts)
 }
 
-func getSource(ctx context.Context, artifactName string, availableArtifacts []Artifact) (artifact.Source, error) {
+func (as Source) getSource(ctx context.Context, artifactName, version string, availableArtifacts []Artifact) (artifact.Source, error) {
 	for _, releaseArtifact := range availableArtifacts {
 		if releaseArtifact.Name == artifactName && releaseArtifact.Arch == runtime.GOARCH && releaseArtifact.OS == runtime.GOOS {
-			obj, err := util.GetHttpFileReader(ctx, releaseArtifact.URI)
+			obj, err := getArtifactReader(ctx, releaseArtifact.URI, as.httpOpts...)
 			if err != nil {
 				return nil, fmt.Errorf("getting artifact file reader: %w", err)
 			}
 
-			artifactChecksum, err := util.GetHttpFile(ctx, releaseArtifact.ChecksumURI)
+			artifactChecksum, err := getArtifactFile(ctx, releaseArtifact.ChecksumURI, as.httpOpts...)
 			if err != nil {
 				obj.Close()
 				return nil, fmt.Errorf("getting artifact checksum file reader: %w", err)
@@ -184,8 +270,29 @@ func getSource(ctx context.Context, artifactName string, availableArtifacts []Ar
 				obj.Close()
 				return nil, fmt.Errorf("getting artifact with checksum: %w", err)
 			}
-			return source, nil
+			return artifact.WithMetadata(source, artifact.Metadata{
+				Version: version,
+				URI:     releaseArtifact.URI,
+			}), nil
 		}
 	}
 	return nil, fmt.Errorf("could not find artifact for %s arch and %s os", runtime.GOARCH, runtime.GOOS)
 }
+
+// getArtifactReader returns a reader for the artifact at uri. Besides http(s), it supports
+// file:// uris, which are used by artifacts that are read from a local bundle.
+func getArtifactReader(ctx context.Context, uri string, opts ...util.HttpOption) (io.ReadCloser, error) {
+	if path, ok := strings.CutPrefix(uri, fileURIScheme); ok {
+		return os.Open(path)
+	}
+	return util.GetHttpFileReader(ctx, uri, opts...)
+}
+
+func getArtifactFile(ctx context.Context, uri string, opts ...util.HttpOption) ([]byte, error) {
+	reader, err := getArtifactReader(ctx, uri, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGetContainerdSource(t *testing.T) {
+	manifest := &Manifest{
+		ContainerdReleases: []ContainerdRelease{
+			{Version: "v1.7.22"},
+			{Version: "v1.7.24"},
+			{Version: "v1.6.36"},
+			{Version: "v2.0.2"},
+		},
+	}
+	tests := []struct {
+		name        string
+		manifest    *Manifest
+		version     string
+		wantVersion string
+		wantErr     string
+	}{
+		{name: "latest", manifest: manifest, wantVersion: "v1.7.24"},
+		{name: "minor version", manifest: manifest, version: "1.7", wantVersion: "v1.7.24"},
+		{name: "unsupported version", manifest: manifest, version: "1.6", wantErr: "no containerd release found for version 1.6"},
+		{name: "only unsupported releases", manifest: &Manifest{ContainerdReleases: []ContainerdRelease{{Version: "v2.0.2"}}}},
+		{name: "patch version", manifest: manifest, version: "1.7.22", wantVersion: "v1.7.22"},
+		{name: "missing version", manifest: manifest, version: "1.7.2", wantErr: "no containerd release found for version 1.7.2"},
+		{name: "no containerd releases", manifest: &Manifest{}},
+		{name: "no containerd releases with version", manifest: &Manifest{}, version: "1.7", wantErr: "no containerd release found for version 1.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			release, err := getContainerdSource(tt.manifest, tt.version)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(release.Version).To(Equal(tt.wantVersion))
+		})
+	}
+}
@@ -31,6 +31,13 @@ func CheckEndpointAccess(ctx context.Context, config aws.Config) error {
 	return nil
 }
 
+// CheckAuthentication verifies config provides valid AWS credentials by calling GetCallerIdentity.
+func CheckAuthentication(ctx context.Context, config aws.Config) error {
+	client := sts_sdk.NewFromConfig(config)
+	_, err := client.GetCallerIdentity(ctx, &sts_sdk.GetCallerIdentityInput{})
+	return err
+}
+
 // AuthenticationValidator validates if the machine can authenticate against AWS.
 type AuthenticationValidator struct {
 	aws aws.Config
@@ -58,9 +65,7 @@ func (a AuthenticationValidator) Run(ctx context.Context, informer validation.In
 		informer.Done(ctx, "sts-authentication", err)
 	}()
 
-	client := sts_sdk.NewFromConfig(a.aws)
-	_, err = client.GetCallerIdentity(ctx, &sts_sdk.GetCallerIdentityInput{})
-	if err != nil {
+	if err = CheckAuthentication(ctx, a.aws); err != nil {
 		err = validation.WithRemediation(err, "Check your AWS configuration and make sure you can obtain valid AWS credentials.")
 		return err
 	}
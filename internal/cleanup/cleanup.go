@@ -1,12 +1,14 @@
 package cleanup
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 // Directories to clean up when force flag is enabled
@@ -44,18 +46,19 @@ func New(logger *zap.Logger, opts ...Option) *Force {
 	return f
 }
 
-// Cleanup removes all configured directories.
-func (c *Force) Cleanup() error {
+// Cleanup removes all configured directories with the Host in the context.
+func (c *Force) Cleanup(ctx context.Context) error {
+	h := host.FromContext(ctx)
 	for _, dir := range cleanupDirs {
 		fullPath := filepath.Join(c.rootDir, strings.TrimPrefix(dir, "/"))
-		if err := c.removeDir(fullPath); err != nil {
+		if err := c.removeDir(h, fullPath); err != nil {
 			return fmt.Errorf("removing directory %s: %w", dir, err)
 		}
 	}
 	return nil
 }
 
-func (c *Force) removeDir(dir string) error {
+func (c *Force) removeDir(h host.Host, dir string) error {
 	c.logger.Info("Removing directory", zap.String("path", dir))
-	return os.RemoveAll(dir)
+	return h.RemoveAll(dir)
 }
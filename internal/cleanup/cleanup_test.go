@@ -1,6 +1,7 @@
 package cleanup_test
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -45,7 +46,7 @@ func TestCleanup(t *testing.T) {
 
 			logger := zaptest.NewLogger(t)
 			force := cleanup.New(logger, cleanup.WithRootDir(tmpRoot))
-			err := force.Cleanup()
+			err := force.Cleanup(context.Background())
 
 			if tt.expectError == "" {
 				g.Expect(err).ToNot(HaveOccurred(), "Unexpected error occurred")
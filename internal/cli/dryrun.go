@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"io"
+
+	"github.com/integrii/flaggy"
+
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+// DryRunFlags holds the --dry-run flag shared by the commands that change the host.
+type DryRunFlags struct {
+	Enabled bool
+	dryRun  *host.DryRun
+}
+
+// RegisterFlags adds the dry run flag to the subcommand.
+func (f *DryRunFlags) RegisterFlags(fc *flaggy.Subcommand) {
+	fc.Bool(&f.Enabled, "", "dry-run", "Print the files, commands, daemon operations and AWS API calls that would change the host without applying them.")
+}
+
+// Context returns ctx with a host that records changes instead of applying them
+// when the dry run flag is set.
+func (f *DryRunFlags) Context(ctx context.Context) context.Context {
+	if !f.Enabled {
+		return ctx
+	}
+	if f.dryRun == nil {
+		f.dryRun = host.NewDryRun()
+	}
+	return host.NewContext(ctx, f.dryRun)
+}
+
+// DaemonManager wraps manager so daemon operations are recorded when the dry run flag is set.
+// It must be called after Context.
+func (f *DryRunFlags) DaemonManager(manager daemon.DaemonManager) daemon.DaemonManager {
+	if f.dryRun == nil {
+		return manager
+	}
+	return f.dryRun.DaemonManager(manager)
+}
+
+// Print writes the recorded changes to w. It's a no-op when the dry run flag is not set.
+func (f *DryRunFlags) Print(w io.Writer) error {
+	if f.dryRun == nil {
+		return nil
+	}
+	return f.dryRun.Print(w)
+}
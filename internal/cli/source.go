@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/integrii/flaggy"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/aws"
+)
+
+const (
+	manifestURLEnv       = "NODEADM_MANIFEST_URL"
+	mirrorURLEnv         = "NODEADM_MIRROR_URL"
+	mirrorCABundleEnv    = "NODEADM_MIRROR_CA_BUNDLE"
+	mirrorUsernameEnv    = "NODEADM_MIRROR_USERNAME"
+	mirrorPasswordEnv    = "NODEADM_MIRROR_PASSWORD"
+	mirrorBearerTokenEnv = "NODEADM_MIRROR_BEARER_TOKEN"
+)
+
+// ArtifactSourceFlags configure where the release manifest and the artifacts are
+// downloaded from. Every flag can also be set with an environment variable or in
+// spec.hybrid.artifacts of the node config. Mirror credentials are only read from the
+// environment so they don't end up in shell history or in the node config.
+type ArtifactSourceFlags struct {
+	ManifestURL    string
+	MirrorURL      string
+	MirrorCABundle string
+}
+
+// RegisterFlags adds the artifact source flags to the subcommand.
+func (f *ArtifactSourceFlags) RegisterFlags(fc *flaggy.Subcommand) {
+	fc.String(&f.ManifestURL, "", "manifest-url", "URL of the release manifest. Can also be set with "+manifestURLEnv+".")
+	fc.String(&f.MirrorURL, "", "mirror-url", "URL prefix of a mirror that replaces the host of every artifact in the release manifest. Can also be set with "+mirrorURLEnv+". "+
+		"Credentials for the mirror are read from "+mirrorUsernameEnv+"/"+mirrorPasswordEnv+" or "+mirrorBearerTokenEnv+".")
+	fc.String(&f.MirrorCABundle, "", "mirror-ca-bundle", "Path to a PEM CA bundle to trust when downloading from the mirror. Can also be set with "+mirrorCABundleEnv+".")
+}
+
+// IsSet reports whether any of the artifact source flags was set in the command line.
+func (f *ArtifactSourceFlags) IsSet() bool {
+	return f.ManifestURL != "" || f.MirrorURL != "" || f.MirrorCABundle != ""
+}
+
+// SourceOptions builds the aws.SourceOption for the configured flags, falling back
+// to environment variables and then to the artifact source of node, which can be nil,
+// for the ones that are not set.
+func (f *ArtifactSourceFlags) SourceOptions(node *api.NodeConfig) []aws.SourceOption {
+	var defaults api.ArtifactSource
+	if node != nil && node.Spec.Hybrid != nil && node.Spec.Hybrid.Artifacts != nil {
+		defaults = *node.Spec.Hybrid.Artifacts
+	}
+	return []aws.SourceOption{
+		aws.WithManifestURL(firstSet(f.ManifestURL, os.Getenv(manifestURLEnv), defaults.ManifestURL)),
+		aws.WithMirror(aws.Mirror{
+			URL:          firstSet(f.MirrorURL, os.Getenv(mirrorURLEnv), defaults.MirrorURL),
+			CABundlePath: firstSet(f.MirrorCABundle, os.Getenv(mirrorCABundleEnv), defaults.MirrorCABundle),
+			Username:     os.Getenv(mirrorUsernameEnv),
+			Password:     os.Getenv(mirrorPasswordEnv),
+			BearerToken:  os.Getenv(mirrorBearerTokenEnv),
+		}),
+	}
+}
+
+// firstSet returns the first non empty value.
+func firstSet(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -2,13 +2,14 @@ package cni
 
 import (
 	"context"
-	"os"
+	"fmt"
 	"path/filepath"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -54,7 +55,7 @@ func installFromSource(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing cni-plugins")
 	}
 
-	if err := artifact.InstallTarGz(filepath.Join(opts.InstallRoot, BinPath), filepath.Join(opts.InstallRoot, TgzPath)); err != nil {
+	if err := artifact.InstallTarGz(ctx, filepath.Join(opts.InstallRoot, BinPath), filepath.Join(opts.InstallRoot, TgzPath)); err != nil {
 		return errors.Wrap(err, "extracting and installing cni-plugins")
 	}
 
@@ -81,7 +82,7 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	}
 	defer cniPlugins.Close()
 
-	if err := artifact.InstallFile(filepath.Join(opts.InstallRoot, TgzPath), cniPlugins, 0o755); err != nil {
+	if err := host.FromContext(ctx).WriteFile(filepath.Join(opts.InstallRoot, TgzPath), cniPlugins, 0o755); err != nil {
 		return errors.Wrap(err, "installing cni-plugins archive")
 	}
 
@@ -89,20 +90,35 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 		return errors.Errorf("cni-plugins checksum mismatch: %v", artifact.NewChecksumError(cniPlugins))
 	}
 
+	opts.Tracker.Record(artifact.CniPlugins, BinPath, cniPlugins)
+
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(rootDir)
+func Uninstall(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	return h.RemoveAll(rootDir)
 }
 
-// Upgrade re-installs the cni-plugins available from the source
-// Since cni-plugins is delivered as a tarball, its not possible to check if they are due for an upgrade
-// todo: (@vignesh-goutham) check if we can publish cni-plugins independently with their checksum on our manifest
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+// Upgrade re-installs the cni-plugins available from the source.
+// Since cni-plugins is delivered as a tarball, the installed binaries can't be compared with the
+// source, so the upgrade is skipped only if the tracker recorded the same tarball checksum.
+func Upgrade(ctx context.Context, src Source, tr *tracker.Tracker, log *zap.Logger) error {
+	cniPlugins, err := src.GetCniPlugins(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting cni-plugins source")
+	}
+	current := tr.IsCurrent(artifact.CniPlugins, cniPlugins)
+	cniPlugins.Close()
+	if current {
+		log.Info(fmt.Sprintf("No new version found for artifact %s. Skipping upgrade.", artifactName))
+		return nil
+	}
+
 	opts := InstallOptions{
-		Source: src,
-		Logger: log,
+		Source:  src,
+		Tracker: tr,
+		Logger:  log,
 	}
 	if err := installFromSource(ctx, opts); err != nil {
 		return errors.Wrapf(err, "upgrading cni-plugins")
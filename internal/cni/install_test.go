@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/test"
@@ -59,6 +60,7 @@ func TestInstall(t *testing.T) {
 		},
 		Verify: func(g *GomegaWithT, tempDir string, tr *tracker.Tracker) {
 			g.Expect(tr.Artifacts.CniPlugins).To(BeTrue())
+			g.Expect(tr.Records).To(HaveKeyWithValue(artifact.CniPlugins, HaveField("InstallPath", cni.BinPath)))
 		},
 		VerifyFilePaths: []string{filepath.Join(cni.BinPath, "fake-plugin")},
 	})
@@ -0,0 +1,167 @@
+// Package configgen generates NodeConfig documents for hybrid nodes.
+package configgen
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/api"
+	"github.com/aws/eks-hybrid/api/v1alpha1"
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/node/hybrid"
+)
+
+const (
+	// CredentialProviderSSM authenticates the node with an SSM hybrid activation.
+	CredentialProviderSSM = "ssm"
+	// CredentialProviderIAMRolesAnywhere authenticates the node with IAM Roles Anywhere.
+	CredentialProviderIAMRolesAnywhere = "iam-ra"
+)
+
+// Options are the values of the generated NodeConfig.
+type Options struct {
+	CredentialProvider string
+	ClusterName        string
+	Region             string
+
+	// SSM
+	ActivationCode string
+	ActivationID   string
+
+	// IAM Roles Anywhere
+	NodeName        string
+	TrustAnchorARN  string
+	ProfileARN      string
+	RoleARN         string
+	CertificatePath string
+	PrivateKeyPath  string
+}
+
+// Generate builds a NodeConfig from opts and validates it with the same rules as init.
+// For IAM Roles Anywhere, it also checks the certificate and private key exist on this
+// host, form a pair and the certificate hasn't expired.
+func Generate(opts Options) (*v1alpha1.NodeConfig, error) {
+	nodeConfig := &v1alpha1.NodeConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       api.KindNodeConfig,
+		},
+		Spec: v1alpha1.NodeConfigSpec{
+			Cluster: v1alpha1.ClusterDetails{
+				Name:   opts.ClusterName,
+				Region: opts.Region,
+			},
+		},
+	}
+
+	switch opts.CredentialProvider {
+	case CredentialProviderSSM:
+		nodeConfig.Spec.Hybrid = &v1alpha1.HybridOptions{
+			SSM: &v1alpha1.SSM{
+				ActivationCode: opts.ActivationCode,
+				ActivationID:   opts.ActivationID,
+			},
+		}
+	case CredentialProviderIAMRolesAnywhere:
+		nodeConfig.Spec.Hybrid = &v1alpha1.HybridOptions{
+			IAMRolesAnywhere: &v1alpha1.IAMRolesAnywhere{
+				NodeName:        opts.NodeName,
+				TrustAnchorARN:  opts.TrustAnchorARN,
+				ProfileARN:      opts.ProfileARN,
+				RoleARN:         opts.RoleARN,
+				CertificatePath: opts.CertificatePath,
+				PrivateKeyPath:  opts.PrivateKeyPath,
+			},
+		}
+	default:
+		return nil, fmt.Errorf("invalid credential provider %q, supported providers: [%s, %s]", opts.CredentialProvider, CredentialProviderSSM, CredentialProviderIAMRolesAnywhere)
+	}
+
+	if err := validate(nodeConfig); err != nil {
+		return nil, err
+	}
+	if opts.CredentialProvider == CredentialProviderIAMRolesAnywhere {
+		if err := validateCertificate(nodeConfig.Spec.Hybrid.IAMRolesAnywhere, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return nodeConfig, nil
+}
+
+// validate decodes nodeConfig as init does and runs the hybrid node validations.
+func validate(nodeConfig *v1alpha1.NodeConfig) error {
+	data, err := yaml.Marshal(nodeConfig)
+	if err != nil {
+		return err
+	}
+	internalConfig, err := apibridge.DecodeStrictNodeConfig(data)
+	if err != nil {
+		return err
+	}
+	hybrid.PopulateNodeConfigDefaults(internalConfig)
+	return hybrid.ValidateNodeConfig(internalConfig)
+}
+
+func validateCertificate(rolesAnywhere *v1alpha1.IAMRolesAnywhere, now time.Time) error {
+	certificatePath := rolesAnywhere.CertificatePath
+	if certificatePath == "" {
+		certificatePath = iamrolesanywhere.DefaultCertificatePath
+	}
+	privateKeyPath := rolesAnywhere.PrivateKeyPath
+	if privateKeyPath == "" {
+		privateKeyPath = iamrolesanywhere.DefaultPrivateKeyPath
+	}
+	certificate, err := os.ReadFile(certificatePath)
+	if err != nil {
+		return fmt.Errorf("reading IAM Roles Anywhere certificate: %w", err)
+	}
+	privateKey, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return fmt.Errorf("reading IAM Roles Anywhere private key: %w", err)
+	}
+	if _, err := tls.X509KeyPair(certificate, privateKey); err != nil {
+		return fmt.Errorf("IAM Roles Anywhere certificate %s and private key %s don't form a valid pair: %w", certificatePath, privateKeyPath, err)
+	}
+
+	leaf, err := iamrolesanywhere.ReadCertificate(certificatePath)
+	if err != nil {
+		return err
+	}
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("IAM Roles Anywhere certificate %s expired on %s", certificatePath, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Marshal returns nodeConfig as YAML, without the empty fields.
+func Marshal(nodeConfig *v1alpha1.NodeConfig) ([]byte, error) {
+	data, err := yaml.Marshal(nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(prune(document))
+}
+
+// prune removes the null values and empty objects from document.
+func prune(document map[string]any) map[string]any {
+	for key, value := range document {
+		if nested, ok := value.(map[string]any); ok {
+			value = prune(nested)
+			document[key] = value
+		}
+		if nested, ok := value.(map[string]any); (ok && len(nested) == 0) || value == nil {
+			delete(document, key)
+		}
+	}
+	return document
+}
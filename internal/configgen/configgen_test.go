@@ -0,0 +1,207 @@
+package configgen_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/configgen"
+)
+
+func TestGenerateSSM(t *testing.T) {
+	g := NewWithT(t)
+	nodeConfig, err := configgen.Generate(configgen.Options{
+		CredentialProvider: configgen.CredentialProviderSSM,
+		ClusterName:        "my-cluster",
+		Region:             "us-west-2",
+		ActivationCode:     "code",
+		ActivationID:       "id",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	data, err := configgen.Marshal(nodeConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal(`apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+  hybrid:
+    ssm:
+      activationCode: code
+      activationId: id
+`))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    configgen.Options
+		wantErr string
+	}{
+		{
+			name:    "invalid credential provider",
+			opts:    configgen.Options{CredentialProvider: "ec2", ClusterName: "my-cluster", Region: "us-west-2"},
+			wantErr: `invalid credential provider "ec2"`,
+		},
+		{
+			name:    "missing region",
+			opts:    configgen.Options{CredentialProvider: configgen.CredentialProviderSSM, ClusterName: "my-cluster", ActivationCode: "code", ActivationID: "id"},
+			wantErr: "Region is missing in cluster configuration",
+		},
+		{
+			name:    "missing activation id",
+			opts:    configgen.Options{CredentialProvider: configgen.CredentialProviderSSM, ClusterName: "my-cluster", Region: "us-west-2", ActivationCode: "code"},
+			wantErr: "ActivationID",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := configgen.Generate(tc.opts)
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
+
+func TestGenerateIAMRolesAnywhere(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		notAfter time.Time
+		otherKey bool
+		noFiles  bool
+		wantErr  string
+	}{
+		{
+			name:     "valid certificate",
+			notAfter: now.Add(time.Hour),
+		},
+		{
+			name:     "expired certificate",
+			notAfter: now.Add(-time.Hour),
+			wantErr:  "expired on",
+		},
+		{
+			name:     "key of another certificate",
+			notAfter: now.Add(time.Hour),
+			otherKey: true,
+			wantErr:  "don't form a valid pair",
+		},
+		{
+			name:    "missing files",
+			noFiles: true,
+			wantErr: "server.pem not found",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dir := t.TempDir()
+			certificatePath := filepath.Join(dir, "server.pem")
+			privateKeyPath := filepath.Join(dir, "server.key")
+			if !tc.noFiles {
+				certificate, privateKey := selfSignedCertificate(g, "my-node", now.Add(-2*time.Hour), tc.notAfter)
+				if tc.otherKey {
+					_, privateKey = selfSignedCertificate(g, "my-node", now.Add(-2*time.Hour), tc.notAfter)
+				}
+				g.Expect(os.WriteFile(certificatePath, certificate, 0o600)).To(Succeed())
+				g.Expect(os.WriteFile(privateKeyPath, privateKey, 0o600)).To(Succeed())
+			}
+
+			nodeConfig, err := configgen.Generate(configgen.Options{
+				CredentialProvider: configgen.CredentialProviderIAMRolesAnywhere,
+				ClusterName:        "my-cluster",
+				Region:             "us-west-2",
+				NodeName:           "my-node",
+				TrustAnchorARN:     "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/anchor",
+				ProfileARN:         "arn:aws:rolesanywhere:us-west-2:123456789012:profile/profile",
+				RoleARN:            "arn:aws:iam::123456789012:role/hybrid-node",
+				CertificatePath:    certificatePath,
+				PrivateKeyPath:     privateKeyPath,
+			})
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(nodeConfig.Spec.Hybrid.IAMRolesAnywhere.NodeName).To(Equal("my-node"))
+			g.Expect(nodeConfig.Spec.Hybrid.IAMRolesAnywhere.CertificatePath).To(Equal(certificatePath))
+		})
+	}
+}
+
+func TestPrompterComplete(t *testing.T) {
+	g := NewWithT(t)
+	// The region is asked twice because the first answer is empty, and the
+	// certificate and private key paths take their defaults.
+	answers := strings.Join([]string{
+		"iam-ra",
+		"",
+		"us-west-2",
+		"my-node",
+		"arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/anchor",
+		"arn:aws:rolesanywhere:us-west-2:123456789012:profile/profile",
+		"arn:aws:iam::123456789012:role/hybrid-node",
+		"",
+		"/etc/pki/node.key",
+	}, "\n")
+	opts := configgen.Options{ClusterName: "my-cluster"}
+	var out strings.Builder
+
+	g.Expect(configgen.NewPrompter(strings.NewReader(answers), &out).Complete(&opts)).To(Succeed())
+	g.Expect(opts).To(Equal(configgen.Options{
+		CredentialProvider: configgen.CredentialProviderIAMRolesAnywhere,
+		ClusterName:        "my-cluster",
+		Region:             "us-west-2",
+		NodeName:           "my-node",
+		TrustAnchorARN:     "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/anchor",
+		ProfileARN:         "arn:aws:rolesanywhere:us-west-2:123456789012:profile/profile",
+		RoleARN:            "arn:aws:iam::123456789012:role/hybrid-node",
+		CertificatePath:    "/etc/iam/pki/server.pem",
+		PrivateKeyPath:     "/etc/pki/node.key",
+	}))
+	g.Expect(out.String()).NotTo(ContainSubstring("EKS cluster name"))
+	g.Expect(strings.Count(out.String(), "AWS region of the cluster")).To(Equal(2))
+	g.Expect(out.String()).To(ContainSubstring("Certificate path [/etc/iam/pki/server.pem]: "))
+}
+
+func TestPrompterCompleteErrors(t *testing.T) {
+	g := NewWithT(t)
+	opts := configgen.Options{}
+	err := configgen.NewPrompter(strings.NewReader("ssm\nmy-cluster\n"), &strings.Builder{}).Complete(&opts)
+	g.Expect(err).To(MatchError(ContainSubstring(`reading answer to "AWS region of the cluster"`)))
+
+	opts = configgen.Options{}
+	err = configgen.NewPrompter(strings.NewReader("ec2\nmy-cluster\nus-west-2\n"), &strings.Builder{}).Complete(&opts)
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid credential provider "ec2"`)))
+}
+
+func selfSignedCertificate(g *WithT, commonName string, notBefore, notAfter time.Time) (certificate, privateKey []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
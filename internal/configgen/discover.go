@@ -0,0 +1,51 @@
+package configgen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/aws/eks"
+)
+
+// DiscoverCluster sets the options that are not set from the AWS account of awsConfig:
+// the region configured for the AWS SDK and, if the region has a single EKS cluster,
+// its name.
+func DiscoverCluster(ctx context.Context, awsConfig aws.Config, opts *Options) error {
+	if opts.Region == "" {
+		opts.Region = awsConfig.Region
+	}
+	if opts.ClusterName != "" || opts.Region == "" {
+		return nil
+	}
+
+	awsConfig.Region = opts.Region
+	clusters, err := awseks.NewFromConfig(awsConfig).ListClusters(ctx, &awseks.ListClustersInput{})
+	if err != nil {
+		return fmt.Errorf("listing EKS clusters in %s: %w", opts.Region, err)
+	}
+	if len(clusters.Clusters) == 1 {
+		opts.ClusterName = clusters.Clusters[0]
+	}
+	return nil
+}
+
+// VerifyCluster checks the cluster in opts exists and is active, using eks.ReadClusterDetails.
+func VerifyCluster(ctx context.Context, awsConfig aws.Config, opts Options) error {
+	awsConfig.Region = opts.Region
+	node := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Name:   opts.ClusterName,
+				Region: opts.Region,
+			},
+		},
+	}
+	if _, err := eks.ReadClusterDetails(ctx, awsConfig, node); err != nil {
+		return fmt.Errorf("reading EKS cluster %s in %s: %w", opts.ClusterName, opts.Region, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package configgen
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+)
+
+// Prompter asks for the options that are not set.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter returns a Prompter that reads the answers from in and writes the questions to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+type question struct {
+	value        *string
+	text         string
+	defaultValue string
+}
+
+// Complete asks for every option needed for the credential provider that is not set in opts.
+func (p *Prompter) Complete(opts *Options) error {
+	questions := []question{
+		{value: &opts.CredentialProvider, text: fmt.Sprintf("Credential provider (%s or %s)", CredentialProviderSSM, CredentialProviderIAMRolesAnywhere)},
+		{value: &opts.ClusterName, text: "EKS cluster name"},
+		{value: &opts.Region, text: "AWS region of the cluster"},
+	}
+	if err := p.askAll(questions); err != nil {
+		return err
+	}
+
+	switch opts.CredentialProvider {
+	case CredentialProviderSSM:
+		questions = []question{
+			{value: &opts.ActivationCode, text: "SSM activation code"},
+			{value: &opts.ActivationID, text: "SSM activation ID"},
+		}
+	case CredentialProviderIAMRolesAnywhere:
+		questions = []question{
+			{value: &opts.NodeName, text: "Node name"},
+			{value: &opts.TrustAnchorARN, text: "IAM Roles Anywhere trust anchor ARN"},
+			{value: &opts.ProfileARN, text: "IAM Roles Anywhere profile ARN"},
+			{value: &opts.RoleARN, text: "IAM role ARN"},
+			{value: &opts.CertificatePath, text: "Certificate path", defaultValue: iamrolesanywhere.DefaultCertificatePath},
+			{value: &opts.PrivateKeyPath, text: "Private key path", defaultValue: iamrolesanywhere.DefaultPrivateKeyPath},
+		}
+	default:
+		return fmt.Errorf("invalid credential provider %q, supported providers: [%s, %s]", opts.CredentialProvider, CredentialProviderSSM, CredentialProviderIAMRolesAnywhere)
+	}
+	return p.askAll(questions)
+}
+
+func (p *Prompter) askAll(questions []question) error {
+	for _, q := range questions {
+		if err := p.ask(q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ask reads the answer to q into its value, unless the value is already set. An empty
+// answer takes the default value or, if there is none, repeats the question.
+func (p *Prompter) ask(q question) error {
+	for *q.value == "" {
+		if q.defaultValue != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", q.text, q.defaultValue)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", q.text)
+		}
+		answer, err := p.in.ReadString('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && answer != "") {
+			return fmt.Errorf("reading answer to %q: %w", q.text, err)
+		}
+		*q.value = strings.TrimSpace(answer)
+		if *q.value == "" {
+			*q.value = q.defaultValue
+		}
+	}
+	return nil
+}
@@ -2,14 +2,56 @@ package configprovider
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	// StdinSource is the config source to read the configuration from stdin.
+	StdinSource = "-"
+
+	caBundleEnv = "NODEADM_CONFIG_SOURCE_CA_BUNDLE"
+	headersEnv  = "NODEADM_CONFIG_SOURCE_HEADERS"
 )
 
 // BuildConfigProvider returns a ConfigProvider appropriate for the given source URL.
 // The source URL must have a scheme, and the supported schemes are:
 // - `file`. To use configuration from the filesystem: `file:///path/to/file/or/directory`.
 // - `imds`. To use configuration from the instance's user data: `imds://user-data`.
+// - `https`. To download the configuration from a web server: `https://example.com/nodeConfig.yaml`.
+// A PEM CA bundle to trust can be set with NODEADM_CONFIG_SOURCE_CA_BUNDLE and request headers,
+// one `Name: value` per line, with NODEADM_CONFIG_SOURCE_HEADERS.
+// - `s3`. To read the configuration from an S3 object with the host's AWS credentials:
+// `s3://bucket/key`. The bucket region can be set with the `region` query parameter.
+// The source `-` reads the configuration from stdin.
+//
+// The returned provider merges the host overrides matching the current host into the
+// configuration and then resolves its secret references.
 func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
+	provider, err := buildConfigProvider(rawConfigSourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return withSecrets(withHostOverrides(provider)), nil
+}
+
+// BuildConfigProviderWithoutSecrets returns the ConfigProvider of BuildConfigProvider
+// without resolving the secret references, for commands that don't use the values
+// that can hold secrets.
+func BuildConfigProviderWithoutSecrets(rawConfigSourceURL string) (ConfigProvider, error) {
+	provider, err := buildConfigProvider(rawConfigSourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return withHostOverrides(provider), nil
+}
+
+func buildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
+	if rawConfigSourceURL == StdinSource {
+		return NewReaderConfigProvider(os.Stdin), nil
+	}
 	parsedURL, err := url.Parse(rawConfigSourceURL)
 	if err != nil {
 		return nil, err
@@ -20,6 +62,18 @@ func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 	case "file":
 		source := getURLWithoutScheme(parsedURL)
 		return NewFileConfigProvider(source), nil
+	case "https":
+		headers, err := parseHeaders(os.Getenv(headersEnv))
+		if err != nil {
+			return nil, err
+		}
+		return NewHTTPConfigProvider(parsedURL.String(), os.Getenv(caBundleEnv), headers)
+	case "s3":
+		key := strings.TrimPrefix(parsedURL.Path, "/")
+		if parsedURL.Host == "" || key == "" {
+			return nil, fmt.Errorf("invalid s3 config source %s, the format is s3://bucket/key", rawConfigSourceURL)
+		}
+		return NewS3ConfigProvider(parsedURL.Host, key, parsedURL.Query().Get("region")), nil
 	default:
 		return nil, fmt.Errorf("unsupported scheme: %s", parsedURL.Scheme)
 	}
@@ -28,3 +82,22 @@ func BuildConfigProvider(rawConfigSourceURL string) (ConfigProvider, error) {
 func getURLWithoutScheme(url *url.URL) string {
 	return fmt.Sprintf("%s%s", url.Host, url.Path)
 }
+
+// parseHeaders parses one `Name: value` header per line.
+func parseHeaders(raw string) (http.Header, error) {
+	headers := http.Header{}
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			// The value is not included in the error since it usually holds credentials.
+			return nil, fmt.Errorf("invalid header in %s, the format is 'Name: value'", headersEnv)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
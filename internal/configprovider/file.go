@@ -2,17 +2,26 @@ package configprovider
 
 import (
 	"fmt"
-	"io"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	internalapi "github.com/aws/eks-hybrid/internal/api"
 	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
 )
 
+const configFileExtension = ".yaml"
+
 type fileConfigProvider struct {
 	path string
 }
 
+// NewFileConfigProvider returns a ConfigProvider that reads the node configuration from path.
+// If path is a directory, every *.yaml file in it is read in lexical order and merged,
+// so files later in the order override the values of earlier ones. Files can hold
+// multiple YAML documents, which are merged in the order they appear.
 func NewFileConfigProvider(path string) ConfigProvider {
 	return &fileConfigProvider{
 		path: path,
@@ -20,25 +29,87 @@ func NewFileConfigProvider(path string) ConfigProvider {
 }
 
 func (fcs *fileConfigProvider) Provide() (*internalapi.NodeConfig, error) {
-	file, err := os.Open(fcs.path)
+	info, err := os.Stat(fcs.path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-	info, err := file.Stat()
+	if !info.IsDir() {
+		return readConfigFile(fcs.path)
+	}
+
+	files, err := configFiles(fcs.path)
 	if err != nil {
 		return nil, err
 	}
-	if info.IsDir() {
-		return nil, fmt.Errorf("path is a directory, which is not currently supported: %s", fcs.path)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s files found in directory %s", configFileExtension, fcs.path)
+	}
+
+	var configs []*internalapi.NodeConfig
+	for _, file := range files {
+		fileConfigs, err := readConfigDocuments(file)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, fileConfigs...)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no NodeConfig found in directory %s", fcs.path)
 	}
-	data, err := io.ReadAll(file)
+	return mergeNodeConfigs(configs)
+}
+
+// ConfigFiles returns the files the file config source reads for path: path itself or,
+// if it's a directory, the *.yaml files in it in lexical order.
+func ConfigFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
-	config, err := apibridge.DecodeStrictNodeConfig(data)
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	return configFiles(path)
+}
+
+// configFiles returns the paths of the config files in dir in lexical order.
+// Subdirectories are not read.
+func configFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
+	var files []string
+	// ReadDir returns the entries sorted by filename.
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), configFileExtension) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	return files, nil
+}
+
+func readConfigFile(path string) (*internalapi.NodeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := decodeStrictNodeConfig(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
 	return config, nil
 }
+
+func readConfigDocuments(path string) ([]*internalapi.NodeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := apibridge.DecodeStrictNodeConfigs(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return configs, nil
+}
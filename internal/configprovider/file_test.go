@@ -0,0 +1,142 @@
+package configprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	baseNodeConfig = `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+  kubelet:
+    config:
+      maxPods: 110
+    flags:
+      - --node-labels=team=base
+`
+	siteNodeConfig = `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  kubelet:
+    config:
+      maxPods: 150
+      shutdownGracePeriod: 30s
+`
+	hostNodeConfig = `# host overlay
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  kubelet:
+    flags:
+      - --node-labels=rack=r1
+---
+apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  hybrid:
+    ssm:
+      activationCode: code
+      activationId: id
+`
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileConfigProviderDirectory(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"30-host.yaml":    hostNodeConfig,
+		"00-base.yaml":    baseNodeConfig,
+		"10-site.yaml":    siteNodeConfig,
+		"20-notes.txt":    "not a config",
+		"99-ignored.yml":  "not read: only .yaml files are",
+		"README.markdown": "# docs",
+	})
+	g.Expect(os.Mkdir(filepath.Join(dir, "nested.yaml"), 0o755)).To(Succeed())
+
+	config, err := NewFileConfigProvider(dir).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(config.Spec.Hybrid.SSM.ActivationID).To(Equal("id"))
+	g.Expect(config.Spec.Kubelet.Config).To(HaveKeyWithValue("maxPods", runtime.RawExtension{Raw: []byte("150")}))
+	g.Expect(config.Spec.Kubelet.Config).To(HaveKeyWithValue("shutdownGracePeriod", runtime.RawExtension{Raw: []byte(`"30s"`)}))
+	g.Expect(config.Spec.Kubelet.Flags).To(Equal([]string{"--node-labels=team=base", "--node-labels=rack=r1"}))
+}
+
+func TestFileConfigProviderLexicalOrder(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.yaml": "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  cluster:\n    name: first\n",
+		"b.yaml": "apiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  cluster:\n    name: second\n",
+	})
+
+	config, err := NewFileConfigProvider(dir).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("second"))
+}
+
+func TestFileConfigProviderMultiDocumentFile(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	g.Expect(os.WriteFile(path, []byte(baseNodeConfig+"---\n"+siteNodeConfig), 0o644)).To(Succeed())
+
+	config, err := NewFileConfigProvider(path).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(config.Spec.Kubelet.Config).To(HaveKeyWithValue("maxPods", runtime.RawExtension{Raw: []byte("150")}))
+}
+
+func TestFileConfigProviderErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "empty directory",
+			wantErr: "no .yaml files found in directory",
+		},
+		{
+			name:    "only comments",
+			files:   map[string]string{"00-base.yaml": "# nothing here\n---\n"},
+			wantErr: "no NodeConfig found in directory",
+		},
+		{
+			name: "unknown field",
+			files: map[string]string{
+				"00-base.yaml": baseNodeConfig,
+				"10-site.yaml": siteNodeConfig + "---\napiVersion: node.eks.aws/v1alpha1\nkind: NodeConfig\nspec:\n  unknown: true\n",
+			},
+			wantErr: "10-site.yaml: decoding yaml document 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dir := t.TempDir()
+			writeFiles(t, dir, tc.files)
+
+			_, err := NewFileConfigProvider(dir).Provide()
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package configprovider
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strings"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+const dmiSerialPath = "/sys/class/dmi/id/product_serial"
+
+// HostFacts are the properties of the host used to select the host overrides.
+type HostFacts struct {
+	Hostname     string
+	MACAddresses []net.HardwareAddr
+	IPs          []net.IP
+	Serial       string
+}
+
+// ReadHostFacts reads the facts of the current host. The DMI serial is left empty if
+// it can't be read, for example on hosts without DMI.
+func ReadHostFacts() (HostFacts, error) {
+	var facts HostFacts
+	var err error
+	if facts.Hostname, err = os.Hostname(); err != nil {
+		return HostFacts{}, err
+	}
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return HostFacts{}, err
+	}
+	for _, iface := range interfaces {
+		if len(iface.HardwareAddr) > 0 {
+			facts.MACAddresses = append(facts.MACAddresses, iface.HardwareAddr)
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return HostFacts{}, err
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				facts.IPs = append(facts.IPs, ipNet.IP)
+			}
+		}
+	}
+
+	if serial, err := os.ReadFile(dmiSerialPath); err == nil {
+		facts.Serial = strings.TrimSpace(string(serial))
+	}
+	return facts, nil
+}
+
+// hostOverridesConfigProvider merges the host overrides matching the current host
+// into the node configuration read by the wrapped provider.
+type hostOverridesConfigProvider struct {
+	provider  ConfigProvider
+	readFacts func() (HostFacts, error)
+}
+
+func withHostOverrides(provider ConfigProvider) ConfigProvider {
+	return &hostOverridesConfigProvider{
+		provider:  provider,
+		readFacts: ReadHostFacts,
+	}
+}
+
+func (hcs *hostOverridesConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	config, err := hcs.provider.Provide()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Spec.Hosts) == 0 {
+		return config, nil
+	}
+	facts, err := hcs.readFacts()
+	if err != nil {
+		return nil, fmt.Errorf("reading host facts to select host overrides: %w", err)
+	}
+	if err := ApplyHostOverrides(config, facts); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ApplyHostOverrides merges the host overrides in node that match facts into its spec,
+// in the order they are listed, and removes them from the spec.
+func ApplyHostOverrides(node *internalapi.NodeConfig, facts HostFacts) error {
+	overrides := node.Spec.Hosts
+	node.Spec.Hosts = nil
+	for i, override := range overrides {
+		match, err := matchesHost(override.Selector, facts)
+		if err != nil {
+			return fmt.Errorf("invalid selector in host override %d: %w", i, err)
+		}
+		if !match {
+			continue
+		}
+		partial := &internalapi.NodeConfig{
+			Spec: internalapi.NodeConfigSpec{
+				Kubelet: override.Kubelet,
+				Hybrid:  override.Hybrid,
+			},
+		}
+		if err := node.Merge(partial); err != nil {
+			return fmt.Errorf("merging host override %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func matchesHost(selector internalapi.HostSelector, facts HostFacts) (bool, error) {
+	if selector == (internalapi.HostSelector{}) {
+		return false, fmt.Errorf("at least one of hostname, macAddress, cidr or serial must be set")
+	}
+
+	if selector.Hostname != "" {
+		match, err := path.Match(selector.Hostname, facts.Hostname)
+		if err != nil {
+			return false, fmt.Errorf("hostname %s: %w", selector.Hostname, err)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+
+	if selector.MACAddress != "" {
+		mac, err := net.ParseMAC(selector.MACAddress)
+		if err != nil {
+			return false, err
+		}
+		if !containsMAC(facts.MACAddresses, mac) {
+			return false, nil
+		}
+	}
+
+	if selector.CIDR != "" {
+		_, cidr, err := net.ParseCIDR(selector.CIDR)
+		if err != nil {
+			return false, err
+		}
+		if !containsIP(cidr, facts.IPs) {
+			return false, nil
+		}
+	}
+
+	if selector.Serial != "" && selector.Serial != facts.Serial {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func containsMAC(macs []net.HardwareAddr, mac net.HardwareAddr) bool {
+	for _, m := range macs {
+		if m.String() == mac.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIP(cidr *net.IPNet, ips []net.IP) bool {
+	for _, ip := range ips {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
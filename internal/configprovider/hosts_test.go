@@ -0,0 +1,163 @@
+package configprovider
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+const fleetNodeConfig = `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+  kubelet:
+    config:
+      maxPods: 110
+    flags:
+      - --node-labels=fleet=bare-metal
+  hybrid:
+    iamRolesAnywhere:
+      trustAnchorArn: arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/anchor
+      profileArn: arn:aws:rolesanywhere:us-west-2:123456789012:profile/profile
+      roleArn: arn:aws:iam::123456789012:role/hybrid-node
+  hosts:
+    - selector:
+        hostname: rack1-*
+      kubelet:
+        flags:
+          - --node-labels=rack=rack1
+    - selector:
+        macAddress: 0A:1B:2C:3D:4E:5F
+      hybrid:
+        iamRolesAnywhere:
+          nodeName: node-by-mac
+    - selector:
+        cidr: 10.20.0.0/16
+        serial: SN-1234
+      kubelet:
+        config:
+          maxPods: 250
+    - selector:
+        hostname: rack2-*
+      hybrid:
+        iamRolesAnywhere:
+          nodeName: rack2-node
+`
+
+func mustParseMAC(t *testing.T, s string) net.HardwareAddr {
+	t.Helper()
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mac
+}
+
+func TestHostOverridesConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	facts := HostFacts{
+		Hostname:     "rack1-node-7",
+		MACAddresses: []net.HardwareAddr{mustParseMAC(t, "0a:1b:2c:3d:4e:5f")},
+		IPs:          []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("10.20.3.4")},
+		Serial:       "SN-1234",
+	}
+	provider := &hostOverridesConfigProvider{
+		provider:  NewReaderConfigProvider(strings.NewReader(fleetNodeConfig)),
+		readFacts: func() (HostFacts, error) { return facts, nil },
+	}
+
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Hosts).To(BeEmpty())
+	g.Expect(config.Spec.Kubelet.Flags).To(Equal([]string{"--node-labels=fleet=bare-metal", "--node-labels=rack=rack1"}))
+	g.Expect(config.Spec.Kubelet.Config).To(HaveKeyWithValue("maxPods", runtime.RawExtension{Raw: []byte("250")}))
+	rolesAnywhere := config.Spec.Hybrid.IAMRolesAnywhere
+	g.Expect(rolesAnywhere.NodeName).To(Equal("node-by-mac"))
+	g.Expect(rolesAnywhere.TrustAnchorARN).To(Equal("arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/anchor"))
+	g.Expect(rolesAnywhere.RoleARN).To(Equal("arn:aws:iam::123456789012:role/hybrid-node"))
+}
+
+func TestHostOverridesConfigProviderNoMatch(t *testing.T) {
+	g := NewWithT(t)
+	provider := &hostOverridesConfigProvider{
+		provider: NewReaderConfigProvider(strings.NewReader(fleetNodeConfig)),
+		readFacts: func() (HostFacts, error) {
+			// Matches the CIDR but not the serial.
+			return HostFacts{Hostname: "other", IPs: []net.IP{net.ParseIP("10.20.3.4")}, Serial: "SN-9999"}, nil
+		},
+	}
+
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Kubelet.Flags).To(Equal([]string{"--node-labels=fleet=bare-metal"}))
+	g.Expect(config.Spec.Kubelet.Config).To(HaveKeyWithValue("maxPods", runtime.RawExtension{Raw: []byte("110")}))
+	g.Expect(config.Spec.Hybrid.IAMRolesAnywhere.NodeName).To(BeEmpty())
+}
+
+func TestHostOverridesConfigProviderWithoutOverrides(t *testing.T) {
+	g := NewWithT(t)
+	provider := &hostOverridesConfigProvider{
+		provider: NewReaderConfigProvider(strings.NewReader(minimalNodeConfig)),
+		readFacts: func() (HostFacts, error) {
+			t.Fatal("host facts should not be read")
+			return HostFacts{}, nil
+		},
+	}
+
+	_, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestApplyHostOverridesInvalidSelector(t *testing.T) {
+	testCases := []struct {
+		name     string
+		selector internalapi.HostSelector
+		wantErr  string
+	}{
+		{
+			name:    "empty",
+			wantErr: "invalid selector in host override 0: at least one of hostname, macAddress, cidr or serial must be set",
+		},
+		{
+			name:     "bad glob",
+			selector: internalapi.HostSelector{Hostname: "rack[1"},
+			wantErr:  "invalid selector in host override 0: hostname rack[1: syntax error in pattern",
+		},
+		{
+			name:     "bad mac",
+			selector: internalapi.HostSelector{MACAddress: "not-a-mac"},
+			wantErr:  "invalid selector in host override 0: address not-a-mac: invalid MAC address",
+		},
+		{
+			name:     "bad cidr",
+			selector: internalapi.HostSelector{CIDR: "10.0.0.0/33"},
+			wantErr:  "invalid selector in host override 0: invalid CIDR address: 10.0.0.0/33",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			node := &internalapi.NodeConfig{
+				Spec: internalapi.NodeConfigSpec{
+					Hosts: []internalapi.HostOverride{{Selector: tc.selector}},
+				},
+			}
+			g.Expect(ApplyHostOverrides(node, HostFacts{Hostname: "rack1"})).To(MatchError(tc.wantErr))
+		})
+	}
+}
+
+func TestReadHostFacts(t *testing.T) {
+	g := NewWithT(t)
+	facts, err := ReadHostFacts()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(facts.Hostname).NotTo(BeEmpty())
+}
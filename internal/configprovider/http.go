@@ -0,0 +1,101 @@
+package configprovider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+type httpConfigProvider struct {
+	url     string
+	client  *http.Client
+	headers http.Header
+	retry   retryConfig
+}
+
+// NewHTTPConfigProvider returns a ConfigProvider that downloads the node configuration
+// from url. caBundlePath is an optional PEM file with additional CAs to trust and
+// headers are added to the request, for example to authenticate with the server.
+func NewHTTPConfigProvider(url, caBundlePath string, headers http.Header) (ConfigProvider, error) {
+	client := http.DefaultClient
+	if caBundlePath != "" {
+		var err error
+		if client, err = newHTTPClientWithCABundle(caBundlePath); err != nil {
+			return nil, err
+		}
+	}
+	return &httpConfigProvider{
+		url:     url,
+		client:  client,
+		headers: headers,
+		retry:   defaultRetryConfig(),
+	}, nil
+}
+
+func (hcs *httpConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	data, err := hcs.retry.fetch(context.Background(), hcs.get)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading node configuration from %s", hcs.url)
+	}
+	return decodeStrictNodeConfig(data)
+}
+
+func (hcs *httpConfigProvider) get(ctx context.Context) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, hcs.url, nil)
+	if err != nil {
+		return nil, permanent(err)
+	}
+	for key, values := range hcs.headers {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	resp, err := hcs.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		// Client errors won't be fixed by retrying, except for timeouts and throttling.
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+			resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, permanent(err)
+		}
+		return nil, err
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func newHTTPClientWithCABundle(caBundlePath string) (*http.Client, error) {
+	caBundle, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading config source CA bundle")
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no valid certificates found in config source CA bundle %s", caBundlePath)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,32 @@
+package configprovider
+
+import (
+	"fmt"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	apibridge "github.com/aws/eks-hybrid/internal/api/bridge"
+)
+
+// decodeStrictNodeConfig decodes every YAML document in data and merges them in order.
+func decodeStrictNodeConfig(data []byte) (*internalapi.NodeConfig, error) {
+	configs, err := apibridge.DecodeStrictNodeConfigs(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no NodeConfig found")
+	}
+	return mergeNodeConfigs(configs)
+}
+
+// mergeNodeConfigs merges configs into the first one, in order, so later configs
+// override the values of earlier ones. configs must not be empty.
+func mergeNodeConfigs(configs []*internalapi.NodeConfig) (*internalapi.NodeConfig, error) {
+	config := configs[0]
+	for _, nodeConfig := range configs[1:] {
+		if err := config.Merge(nodeConfig); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,244 @@
+package configprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	. "github.com/onsi/gomega"
+)
+
+const minimalNodeConfig = `apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  cluster:
+    name: my-cluster
+    region: us-west-2
+`
+
+var fastRetry = retryConfig{attempts: 3}
+
+func TestBuildConfigProvider(t *testing.T) {
+	testCases := []struct {
+		name    string
+		source  string
+		want    ConfigProvider
+		wantErr string
+	}{
+		{
+			name:   "file",
+			source: "file:///etc/nodeadm/config.yaml",
+			want:   &fileConfigProvider{path: "/etc/nodeadm/config.yaml"},
+		},
+		{
+			name:   "stdin",
+			source: "-",
+			want:   &readerConfigProvider{reader: os.Stdin},
+		},
+		{
+			name:   "s3",
+			source: "s3://my-bucket/nodes/config.yaml",
+			want:   &s3ConfigProvider{bucket: "my-bucket", key: "nodes/config.yaml"},
+		},
+		{
+			name:   "s3 with region",
+			source: "s3://my-bucket/config.yaml?region=eu-west-1",
+			want:   &s3ConfigProvider{bucket: "my-bucket", key: "config.yaml", region: "eu-west-1"},
+		},
+		{
+			name:    "s3 without key",
+			source:  "s3://my-bucket",
+			wantErr: "invalid s3 config source s3://my-bucket, the format is s3://bucket/key",
+		},
+		{
+			name:    "plain http",
+			source:  "http://example.com/config.yaml",
+			wantErr: "unsupported scheme: http",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := buildConfigProvider(tc.source)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if s3Provider, ok := got.(*s3ConfigProvider); ok {
+				s3Provider.newClient = nil
+				s3Provider.retry = retryConfig{}
+			}
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestBuildConfigProviderHTTPSFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(headersEnv, "Authorization: Bearer my-token\nX-Node: node-1")
+
+	provider, err := buildConfigProvider("https://example.com/config.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	httpProvider := provider.(*httpConfigProvider)
+	g.Expect(httpProvider.url).To(Equal("https://example.com/config.yaml"))
+	g.Expect(httpProvider.headers.Get("Authorization")).To(Equal("Bearer my-token"))
+	g.Expect(httpProvider.headers.Get("X-Node")).To(Equal("node-1"))
+
+	t.Setenv(headersEnv, "Bearer my-token")
+	_, err = BuildConfigProvider("https://example.com/config.yaml")
+	g.Expect(err).To(MatchError("invalid header in NODEADM_CONFIG_SOURCE_HEADERS, the format is 'Name: value'"))
+}
+
+func TestHTTPConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server is not ready the first time, like during boot.
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(minimalNodeConfig))
+	}))
+	defer server.Close()
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	g.Expect(os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o644)).To(Succeed())
+
+	provider, err := NewHTTPConfigProvider(server.URL, caBundle, http.Header{"Authorization": []string{"Bearer my-token"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	provider.(*httpConfigProvider).retry = fastRetry
+
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(requests.Load()).To(BeEquivalentTo(2))
+}
+
+func TestHTTPConfigProviderClientErrorIsNotRetried(t *testing.T) {
+	g := NewWithT(t)
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	provider := &httpConfigProvider{url: server.URL, client: server.Client(), retry: fastRetry}
+	_, err := provider.Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected status code: 403")))
+	g.Expect(requests.Load()).To(BeEquivalentTo(1))
+}
+
+func TestHTTPConfigProviderUntrustedCertificate(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(minimalNodeConfig))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPConfigProvider(server.URL, "", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	provider.(*httpConfigProvider).retry = retryConfig{attempts: 1}
+
+	_, err = provider.Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("certificate")))
+}
+
+func TestHTTPConfigProviderInvalidCABundle(t *testing.T) {
+	g := NewWithT(t)
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	g.Expect(os.WriteFile(caBundle, []byte("not a certificate"), 0o644)).To(Succeed())
+
+	_, err := NewHTTPConfigProvider("https://example.com", caBundle, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("no valid certificates found in config source CA bundle")))
+}
+
+type fakeS3Client struct {
+	calls int
+	errs  []error
+	body  string
+}
+
+func (f *fakeS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(f.body))}, nil
+}
+
+func newFakeS3Provider(client *fakeS3Client) *s3ConfigProvider {
+	return &s3ConfigProvider{
+		bucket: "my-bucket",
+		key:    "config.yaml",
+		newClient: func(ctx context.Context, region string) (S3Client, error) {
+			return client, nil
+		},
+		retry: fastRetry,
+	}
+}
+
+func TestS3ConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeS3Client{
+		errs: []error{errors.New("no EC2 IMDS role found")},
+		body: minimalNodeConfig,
+	}
+
+	config, err := newFakeS3Provider(client).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(client.calls).To(Equal(2))
+}
+
+func TestS3ConfigProviderNoSuchKey(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeS3Client{errs: []error{&types.NoSuchKey{}}}
+
+	_, err := newFakeS3Provider(client).Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("reading node configuration from s3://my-bucket/config.yaml")))
+	g.Expect(client.calls).To(Equal(1))
+}
+
+func TestS3ConfigProviderRetriesExhausted(t *testing.T) {
+	g := NewWithT(t)
+	unreachable := errors.New("dial tcp: no route to host")
+	client := &fakeS3Client{errs: []error{unreachable, unreachable, unreachable}}
+
+	_, err := newFakeS3Provider(client).Provide()
+	g.Expect(err).To(MatchError(unreachable))
+	g.Expect(client.calls).To(Equal(3))
+}
+
+func TestReaderConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	config, err := NewReaderConfigProvider(strings.NewReader(minimalNodeConfig)).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Cluster.Name).To(Equal("my-cluster"))
+
+	_, err = NewReaderConfigProvider(&bytes.Buffer{}).Provide()
+	g.Expect(err).To(MatchError("no node configuration received on stdin"))
+
+	_, err = NewReaderConfigProvider(strings.NewReader(minimalNodeConfig + "  unknownField: true\n")).Provide()
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,76 @@
+package configprovider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	defaultRetryAttempts   = 10
+	defaultRetryBackoff    = time.Second
+	defaultMaxRetryBackoff = 30 * time.Second
+)
+
+// fetchFunc downloads the raw node configuration from a remote source.
+type fetchFunc func(ctx context.Context) ([]byte, error)
+
+// retryConfig controls how many times a remote source is retried. Sources might
+// not be reachable yet when the node boots, for example while the network is
+// still being configured.
+type retryConfig struct {
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func defaultRetryConfig() retryConfig {
+	return retryConfig{
+		attempts:   defaultRetryAttempts,
+		backoff:    defaultRetryBackoff,
+		maxBackoff: defaultMaxRetryBackoff,
+	}
+}
+
+// fetch calls f until it succeeds, it returns a permanent error or the attempts
+// are exhausted, doubling the wait between attempts up to the max backoff.
+func (r retryConfig) fetch(ctx context.Context, f fetchFunc) ([]byte, error) {
+	var err error
+	wait := r.backoff
+	for attempt := 1; ; attempt++ {
+		var data []byte
+		if data, err = f(ctx); err == nil {
+			return data, nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return nil, permanent.err
+		}
+		if attempt >= r.attempts {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, r.maxBackoff)
+	}
+}
+
+// permanentError is an error that won't go away by retrying, like a missing object.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
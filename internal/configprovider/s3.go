@@ -0,0 +1,91 @@
+package configprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	pkgerrors "github.com/pkg/errors"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+// S3Client is the subset of the S3 API used to read the node configuration.
+type S3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+type s3ConfigProvider struct {
+	bucket    string
+	key       string
+	region    string
+	newClient func(ctx context.Context, region string) (S3Client, error)
+	retry     retryConfig
+}
+
+// NewS3ConfigProvider returns a ConfigProvider that reads the node configuration from
+// the object key in bucket, using the AWS credentials available on the host.
+// If region is empty, it's read from the host's AWS configuration.
+func NewS3ConfigProvider(bucket, key, region string) ConfigProvider {
+	return &s3ConfigProvider{
+		bucket:    bucket,
+		key:       key,
+		region:    region,
+		newClient: newS3Client,
+		retry:     defaultRetryConfig(),
+	}
+}
+
+func (scs *s3ConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	ctx := context.Background()
+	data, err := scs.retry.fetch(ctx, scs.get)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "reading node configuration from s3://%s/%s", scs.bucket, scs.key)
+	}
+	return decodeStrictNodeConfig(data)
+}
+
+func (scs *s3ConfigProvider) get(ctx context.Context) ([]byte, error) {
+	// The client is built on every attempt since the credentials might not be
+	// available until the host finishes booting.
+	client, err := scs.newClient(ctx, scs.region)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(scs.bucket),
+		Key:    aws.String(scs.key),
+	})
+	var noSuchKey *types.NoSuchKey
+	var noSuchBucket *types.NoSuchBucket
+	if errors.As(err, &noSuchKey) || errors.As(err, &noSuchBucket) {
+		return nil, permanent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+func newS3Client(ctx context.Context, region string) (S3Client, error) {
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		return nil, permanent(fmt.Errorf("no AWS region configured, set it with the region query parameter, for example s3://bucket/key?region=us-west-2"))
+	}
+	return s3.NewFromConfig(cfg), nil
+}
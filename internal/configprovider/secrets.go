@@ -0,0 +1,66 @@
+package configprovider
+
+import (
+	"context"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/secrets"
+)
+
+// secretsConfigProvider resolves the secret references in the node configuration
+// read by the wrapped provider, so validations and consumers see the actual values.
+type secretsConfigProvider struct {
+	provider     ConfigProvider
+	resolverOpts []secrets.ResolverOpt
+}
+
+func withSecrets(provider ConfigProvider, opts ...secrets.ResolverOpt) ConfigProvider {
+	return &secretsConfigProvider{
+		provider:     provider,
+		resolverOpts: opts,
+	}
+}
+
+func (scs *secretsConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	config, err := scs.provider.Provide()
+	if err != nil {
+		return nil, err
+	}
+	resolver := secrets.NewResolver(config.Spec.Cluster.Region, scs.resolverOpts...)
+	if err := ResolveSecrets(context.Background(), config, resolver); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ResolveSecrets replaces the secret references in the fields of node that accept
+// them with their values:
+//   - spec.hybrid.ssm.activationCode
+//   - spec.hybrid.ssm.activationId
+//
+// A spec.hybrid.iamRolesAnywhere.privateKeyPath reference is kept as is: the signing
+// helper needs a file, which the IAM Roles Anywhere provider writes when configuring AWS.
+//
+// The resolved node config must not be written to disk.
+func ResolveSecrets(ctx context.Context, node *internalapi.NodeConfig, resolver *secrets.Resolver) error {
+	if node.Spec.Hybrid == nil {
+		return nil
+	}
+
+	var fields []*string
+	if ssm := node.Spec.Hybrid.SSM; ssm != nil {
+		fields = append(fields, &ssm.ActivationCode, &ssm.ActivationID)
+	}
+
+	for _, field := range fields {
+		if !secrets.IsReference(*field) {
+			continue
+		}
+		value, err := resolver.Resolve(ctx, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+	return nil
+}
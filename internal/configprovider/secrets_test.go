@@ -0,0 +1,78 @@
+package configprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+func TestSecretsConfigProvider(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("ACTIVATION_CODE", "resolved-code")
+	t.Setenv("ACTIVATION_ID", "resolved-id")
+
+	data := minimalNodeConfig + `  hybrid:
+    ssm:
+      activationCode: env:ACTIVATION_CODE
+      activationId: env:ACTIVATION_ID
+`
+	config, err := withSecrets(NewReaderConfigProvider(strings.NewReader(data))).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Hybrid.SSM.ActivationCode).To(Equal("resolved-code"))
+	g.Expect(config.Spec.Hybrid.SSM.ActivationID).To(Equal("resolved-id"))
+}
+
+func TestSecretsConfigProviderKeepsPrivateKeyReference(t *testing.T) {
+	g := NewWithT(t)
+	data := minimalNodeConfig + `  hybrid:
+    iamRolesAnywhere:
+      privateKeyPath: file:/does/not/exist
+`
+	config, err := withSecrets(NewReaderConfigProvider(strings.NewReader(data))).Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath).To(Equal("file:/does/not/exist"))
+}
+
+func TestSecretsConfigProviderError(t *testing.T) {
+	g := NewWithT(t)
+	data := minimalNodeConfig + `  hybrid:
+    ssm:
+      activationCode: file:/does/not/exist
+`
+	_, err := withSecrets(NewReaderConfigProvider(strings.NewReader(data))).Provide()
+	g.Expect(err).To(MatchError(ContainSubstring("resolving secret file:/does/not/exist")))
+}
+
+func TestResolveSecretsWithoutHybrid(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(ResolveSecrets(context.Background(), &internalapi.NodeConfig{}, nil)).To(Succeed())
+}
+
+func TestBuildConfigProviderWithoutSecrets(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "nodeConfig.yaml")
+	data := minimalNodeConfig + `  hybrid:
+    ssm:
+      activationCode: env:UNSET_ACTIVATION_CODE
+    artifacts:
+      manifestUrl: https://mirror.example.com/manifest.yaml
+      mirrorUrl: https://mirror.example.com
+`
+	g.Expect(os.WriteFile(path, []byte(data), 0o644)).To(Succeed())
+
+	provider, err := BuildConfigProviderWithoutSecrets("file://" + path)
+	g.Expect(err).NotTo(HaveOccurred())
+	config, err := provider.Provide()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Spec.Hybrid.SSM.ActivationCode).To(Equal("env:UNSET_ACTIVATION_CODE"))
+	g.Expect(config.Spec.Hybrid.Artifacts).To(Equal(&internalapi.ArtifactSource{
+		ManifestURL: "https://mirror.example.com/manifest.yaml",
+		MirrorURL:   "https://mirror.example.com",
+	}))
+}
@@ -0,0 +1,33 @@
+package configprovider
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	internalapi "github.com/aws/eks-hybrid/internal/api"
+)
+
+type readerConfigProvider struct {
+	reader io.Reader
+}
+
+// NewReaderConfigProvider returns a ConfigProvider that reads the node configuration from r.
+// It's used to read the configuration from stdin.
+func NewReaderConfigProvider(r io.Reader) ConfigProvider {
+	return &readerConfigProvider{
+		reader: r,
+	}
+}
+
+func (rcs *readerConfigProvider) Provide() (*internalapi.NodeConfig, error) {
+	data, err := io.ReadAll(rcs.reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading node configuration from stdin")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no node configuration received on stdin")
+	}
+	return decodeStrictNodeConfig(data)
+}
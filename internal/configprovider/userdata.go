@@ -94,13 +94,7 @@ func parseMultipart(userDataReader *multipart.Reader) (*internalapi.NodeConfig,
 		}
 	}
 	if len(nodeConfigs) > 0 {
-		config := nodeConfigs[0]
-		for _, nodeConfig := range nodeConfigs[1:] {
-			if err := config.Merge(nodeConfig); err != nil {
-				return nil, err
-			}
-		}
-		return config, nil
+		return mergeNodeConfigs(nodeConfigs)
 	} else {
 		return nil, fmt.Errorf("Could not find NodeConfig within UserData")
 	}
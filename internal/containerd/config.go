@@ -6,8 +6,6 @@ import (
 	"path/filepath"
 	"text/template"
 
-	"go.uber.org/zap"
-
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/util"
 )
@@ -35,22 +33,18 @@ type containerdTemplateVars struct {
 	SandboxImage string
 }
 
-func writeContainerdConfig(cfg *api.NodeConfig) error {
-	// write nodeadm's generated containerd config to the default path
+func renderContainerdConfig(cfg *api.NodeConfig) ([]util.RenderedFile, error) {
+	// nodeadm's generated containerd config goes to the default path
 	containerdConfig, err := generateContainerdConfig(cfg)
 	if err != nil {
-		return err
-	}
-	zap.L().Info("Writing containerd config to file..", zap.String("path", containerdConfigFile))
-	if err := util.WriteFileWithDir(containerdConfigFile, containerdConfig, containerdConfigPerm); err != nil {
-		return err
+		return nil, err
 	}
+	files := []util.RenderedFile{{Path: containerdConfigFile, Content: containerdConfig, Perms: containerdConfigPerm}}
 	if len(cfg.Spec.Containerd.Config) > 0 {
 		containerConfigImportPath := filepath.Join(containerdConfigImportDir, "00-nodeadm.toml")
-		zap.L().Info("Writing user containerd config to drop-in file..", zap.String("path", containerConfigImportPath))
-		return util.WriteFileWithDir(containerConfigImportPath, []byte(cfg.Spec.Containerd.Config), containerdConfigPerm)
+		files = append(files, util.RenderedFile{Path: containerConfigImportPath, Content: []byte(cfg.Spec.Containerd.Config), Perms: containerdConfigPerm})
 	}
-	return nil
+	return files, nil
 }
 
 func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
@@ -64,6 +58,11 @@ func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func writeContainerdKernelModulesConfig() error {
-	return util.WriteFileWithDir(containerdKernelModulesConfigFile, []byte(containerdKernelModulesFileData), containerdConfigPerm)
+func renderContainerdKernelModulesConfig() util.RenderedFile {
+	return util.RenderedFile{Path: containerdKernelModulesConfigFile, Content: []byte(containerdKernelModulesFileData), Perms: containerdConfigPerm}
+}
+
+// ConfigPaths returns the files and directories written when containerd is configured.
+func ConfigPaths() []string {
+	return []string{containerdConfigDir}
 }
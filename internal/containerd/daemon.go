@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -35,11 +36,24 @@ func NewContainerdDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig
 	}
 }
 
-func (cd *containerd) Configure() error {
-	if err := writeContainerdConfig(cd.nodeConfig); err != nil {
+func (cd *containerd) Configure(ctx context.Context) error {
+	files, err := cd.RenderConfig()
+	if err != nil {
 		return err
 	}
-	return writeContainerdKernelModulesConfig()
+	for _, file := range files {
+		cd.logger.Info("Writing containerd config to file..", zap.String("path", file.Path))
+	}
+	return util.WriteRenderedFiles(ctx, files)
+}
+
+// RenderConfig returns the files written by Configure without writing them.
+func (cd *containerd) RenderConfig() ([]util.RenderedFile, error) {
+	files, err := renderContainerdConfig(cd.nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, renderContainerdKernelModulesConfig()), nil
 }
 
 // EnsureRunning ensures containerd is running with the written configuration
@@ -70,8 +84,8 @@ func (cd *containerd) EnsureRunning(ctx context.Context) error {
 	return nil
 }
 
-func (cd *containerd) PostLaunch() error {
-	return cacheSandboxImage(cd.awsConfig)
+func (cd *containerd) PostLaunch(ctx context.Context) error {
+	return cacheSandboxImage(ctx, cd.awsConfig)
 }
 
 func (cd *containerd) Stop() error {
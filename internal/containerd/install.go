@@ -3,7 +3,6 @@ package containerd
 import (
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/system"
 	"github.com/aws/eks-hybrid/internal/tracker"
 	"github.com/aws/eks-hybrid/internal/util/cmd"
@@ -22,6 +22,8 @@ const (
 	ContainerdSourceNone   SourceName = "none"
 	ContainerdSourceDistro SourceName = "distro"
 	ContainerdSourceDocker SourceName = "docker"
+	// ContainerdSourceEks installs the containerd, runc and shim archives from the release manifest.
+	ContainerdSourceEks SourceName = "eks"
 	// pin containerd to major version 1.x
 	ContainerdVersion = "1.*"
 
@@ -34,12 +36,15 @@ type Source interface {
 	GetContainerd(version string) artifact.Package
 }
 
-func Install(ctx context.Context, tracker *tracker.Tracker, source Source, containerdSource SourceName) error {
+// Install installs containerd from source if it's not already installed. An empty version
+// installs the latest version supported by nodeadm. It fails if containerd is already
+// installed with a different version than the requested one.
+func Install(ctx context.Context, tracker *tracker.Tracker, source Source, containerdSource SourceName, version string) error {
 	if containerdSource == ContainerdSourceNone {
 		return nil
 	}
 	if isContainerdNotInstalled() {
-		containerd := source.GetContainerd(ContainerdVersion)
+		containerd := source.GetContainerd(packageVersion(version))
 		// Sometimes install fails due to conflicts with other processes
 		// updating packages, specially when automating at machine startup.
 		// We assume errors are transient and just retry for a bit.
@@ -47,6 +52,14 @@ func Install(ctx context.Context, tracker *tracker.Tracker, source Source, conta
 			return errors.Wrap(err, "failed to install containerd")
 		}
 		tracker.MarkContainerd(string(containerdSource))
+	} else if version != "" {
+		installed, err := installedVersion()
+		if err != nil {
+			return err
+		}
+		if !versionMatches(version, installed) {
+			return fmt.Errorf("containerd %s is already installed and doesn't match --containerd-version %s, uninstall it first or use --containerd-source none", installed, version)
+		}
 	}
 	return nil
 }
@@ -58,33 +71,52 @@ func Uninstall(ctx context.Context, source Source) error {
 			return errors.Wrap(err, "failed to uninstall containerd")
 		}
 
-		if err := os.RemoveAll(containerdConfigDir); err != nil {
+		if err := host.FromContext(ctx).RemoveAll(containerdConfigDir); err != nil {
 			return errors.Wrap(err, "failed to uninstall containerd config files")
 		}
 	}
 	return nil
 }
 
-func Upgrade(ctx context.Context, source Source) error {
-	containerd := source.GetContainerd(ContainerdVersion)
-	if err := cmd.Retry(ctx, containerd.UpgradeCmd, 5*time.Second); err != nil {
+// UninstallKernelModulesConfig removes the config loading the kernel modules required by containerd.
+func UninstallKernelModulesConfig(ctx context.Context) error {
+	if err := host.FromContext(ctx).RemoveAll(containerdKernelModulesConfigFile); err != nil {
+		return errors.Wrap(err, "failed to uninstall containerd kernel modules config")
+	}
+	return nil
+}
+
+// Upgrade upgrades containerd to version. An empty version upgrades to the latest version
+// supported by nodeadm.
+func Upgrade(ctx context.Context, source Source, version string) error {
+	containerd := source.GetContainerd(packageVersion(version))
+	upgradeCmd := containerd.UpgradeCmd
+	if version != "" {
+		// Installing a specific version moves the package to that version, while
+		// upgrading would ignore it if a newer version is already installed.
+		upgradeCmd = containerd.InstallCmd
+	}
+	if err := cmd.Retry(ctx, upgradeCmd, 5*time.Second); err != nil {
 		return errors.Wrap(err, "upgrading containerd")
 	}
 	return nil
 }
 
 func ValidateContainerdSource(source SourceName) error {
-	osName := system.GetOsName()
+	osFamily := system.GetOsFamily()
 	switch source {
 	case ContainerdSourceNone:
 		return nil
 	case ContainerdSourceDocker:
-		if osName == system.AmazonOsName {
+		if osFamily == system.AmazonOsName {
 			return fmt.Errorf("docker source for containerd is not supported on AL2023. Please provide `none` or `distro` to the --containerd-source flag")
 		}
+		if osFamily == system.SlesOsName {
+			return fmt.Errorf("docker source for containerd is not supported on SLES. Please provide `none` or `distro` to the --containerd-source flag")
+		}
 	case ContainerdSourceDistro:
-		if osName == system.RhelOsName {
-			return fmt.Errorf("distro source for containerd is not supported on RHEL. Please provide `none` or `docker` to the --containerd-source flag")
+		if osFamily == system.RhelOsName {
+			return fmt.Errorf("distro source for containerd is not supported on RHEL based operating systems. Please provide `none` or `docker` to the --containerd-source flag")
 		}
 	}
 	return nil
@@ -111,6 +143,8 @@ func GetContainerdSource(containerdSource string) SourceName {
 		return ContainerdSourceDistro
 	case string(ContainerdSourceDocker):
 		return ContainerdSourceDocker
+	case string(ContainerdSourceEks):
+		return ContainerdSourceEks
 	default:
 		return ContainerdSourceNone
 	}
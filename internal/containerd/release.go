@@ -0,0 +1,170 @@
+package containerd
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+const (
+	// ReleaseRoot is where the archives of the eks containerd source are extracted.
+	// Each archive contains its binaries under bin/.
+	ReleaseRoot = "/opt/nodeadm/containerd"
+
+	// UnitPath is the path to the containerd systemd unit installed with the eks containerd source.
+	UnitPath = "/etc/systemd/system/containerd.service"
+
+	releaseBinDir  = ReleaseRoot + "/bin"
+	releaseLinkDir = "/usr/local/bin"
+	archiveDir     = ReleaseRoot + "/archives"
+
+	shimBinaryName = "containerd-shim-runc-v2"
+)
+
+//go:embed containerd.service
+var containerdUnitFile []byte
+
+// releaseBinaries are linked into releaseLinkDir so containerd finds the shim and runc,
+// and nodeadm and users find containerd and ctr, in $PATH.
+var releaseBinaries = []string{containerdPackageName, "ctr", shimBinaryName, runcPackageName}
+
+// ReleaseSource serves the containerd, runc and containerd shim archives listed in
+// the release manifest.
+type ReleaseSource interface {
+	GetContainerdArchive(context.Context) (artifact.Source, error)
+	GetRuncArchive(context.Context) (artifact.Source, error)
+	GetContainerdShimArchive(context.Context) (artifact.Source, error)
+}
+
+type releaseArchive struct {
+	// name is the artifact name recorded in the tracker.
+	name string
+	get  func(ReleaseSource, context.Context) (artifact.Source, error)
+}
+
+var releaseArchives = []releaseArchive{
+	{name: artifact.Containerd, get: ReleaseSource.GetContainerdArchive},
+	{name: artifact.Runc, get: ReleaseSource.GetRuncArchive},
+	{name: artifact.ContainerdShim, get: ReleaseSource.GetContainerdShimArchive},
+}
+
+// InstallRelease installs containerd, runc and the containerd shim from the archives in
+// source, together with a containerd systemd unit owned by nodeadm.
+func InstallRelease(ctx context.Context, tr *tracker.Tracker, source ReleaseSource, log *zap.Logger) error {
+	if isContainerdInstalled() && tr.Artifacts.Containerd != string(ContainerdSourceEks) {
+		return fmt.Errorf("containerd is already installed. Please uninstall it or provide `none` to the --containerd-source flag to use it")
+	}
+	if err := installReleaseArchives(ctx, tr, source, log, false); err != nil {
+		return err
+	}
+	if err := installReleaseFiles(ctx); err != nil {
+		return err
+	}
+	tr.MarkContainerd(string(ContainerdSourceEks))
+	return nil
+}
+
+// UpgradeRelease re-installs the archives in source whose checksum is different from
+// the one recorded in the tracker.
+func UpgradeRelease(ctx context.Context, tr *tracker.Tracker, source ReleaseSource, log *zap.Logger) error {
+	if err := installReleaseArchives(ctx, tr, source, log, true); err != nil {
+		return errors.Wrap(err, "upgrading containerd")
+	}
+	return installReleaseFiles(ctx)
+}
+
+// UninstallRelease removes the files installed by InstallRelease and reloads systemd,
+// so the removed containerd unit is unloaded.
+func UninstallRelease(ctx context.Context, daemonManager daemon.DaemonManager) error {
+	h := host.FromContext(ctx)
+	paths := []string{ReleaseRoot, UnitPath, containerdConfigDir}
+	for _, binary := range releaseBinaries {
+		paths = append(paths, filepath.Join(releaseLinkDir, binary))
+	}
+	for _, path := range paths {
+		if err := h.RemoveAll(path); err != nil {
+			return errors.Wrap(err, "uninstalling containerd")
+		}
+	}
+	if err := daemonManager.DaemonReload(); err != nil {
+		return errors.Wrap(err, "reloading systemd after removing the containerd unit")
+	}
+	return nil
+}
+
+// ReleasePaths returns the files replaced when the eks containerd source is upgraded.
+func ReleasePaths() []string {
+	return []string{releaseBinDir, UnitPath}
+}
+
+func installReleaseArchives(ctx context.Context, tr *tracker.Tracker, source ReleaseSource, log *zap.Logger, skipCurrent bool) error {
+	for _, archive := range releaseArchives {
+		// Retry up to 3 times to download and validate the checksum
+		var err error
+		for range 3 {
+			err = installReleaseArchive(ctx, tr, source, archive, log, skipCurrent)
+			if err == nil {
+				break
+			}
+			log.Error("Downloading containerd archive failed. Retrying...", zap.String("artifact", archive.name), zap.Error(err))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func installReleaseArchive(ctx context.Context, tr *tracker.Tracker, source ReleaseSource, archive releaseArchive, log *zap.Logger, skipCurrent bool) error {
+	src, err := archive.get(source, ctx)
+	if err != nil {
+		return errors.Wrapf(err, "getting %s source", archive.name)
+	}
+	defer src.Close()
+
+	if skipCurrent && tr.IsCurrent(archive.name, src) {
+		log.Info(fmt.Sprintf("No new version found for artifact %s. Skipping upgrade.", archive.name))
+		return nil
+	}
+
+	archivePath := filepath.Join(archiveDir, archive.name+".tar.gz")
+	if err := host.FromContext(ctx).WriteFile(archivePath, src, 0o644); err != nil {
+		return errors.Wrapf(err, "downloading %s archive", archive.name)
+	}
+	if !src.VerifyChecksum() {
+		return errors.Errorf("%s checksum mismatch: %v", archive.name, artifact.NewChecksumError(src))
+	}
+	if err := artifact.InstallTarGz(ctx, ReleaseRoot, archivePath); err != nil {
+		return errors.Wrapf(err, "extracting %s archive", archive.name)
+	}
+
+	tr.Record(archive.name, releaseBinDir, src)
+	return nil
+}
+
+func installReleaseFiles(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	for _, binary := range releaseBinaries {
+		link := filepath.Join(releaseLinkDir, binary)
+		if err := h.RemoveAll(link); err != nil {
+			return errors.Wrapf(err, "replacing %s link", binary)
+		}
+		if err := h.Symlink(filepath.Join(releaseBinDir, binary), link); err != nil {
+			return errors.Wrapf(err, "linking %s", binary)
+		}
+	}
+	if err := h.WriteFile(UnitPath, bytes.NewReader(containerdUnitFile), 0o644); err != nil {
+		return errors.Wrap(err, "installing containerd systemd unit")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package containerd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+type fakeReleaseSource map[string][]byte
+
+func (s fakeReleaseSource) get(name string) (artifact.Source, error) {
+	data := s[name]
+	checksum := []byte(fmt.Sprintf("%x %s.tar.gz", sha256.Sum256([]byte(name)), name))
+	return artifact.WithChecksum(io.NopCloser(bytes.NewReader(data)), sha256.New(), checksum)
+}
+
+func (s fakeReleaseSource) GetContainerdArchive(context.Context) (artifact.Source, error) {
+	return s.get(artifact.Containerd)
+}
+
+func (s fakeReleaseSource) GetRuncArchive(context.Context) (artifact.Source, error) {
+	return s.get(artifact.Runc)
+}
+
+func (s fakeReleaseSource) GetContainerdShimArchive(context.Context) (artifact.Source, error) {
+	return s.get(artifact.ContainerdShim)
+}
+
+func validReleaseSource() fakeReleaseSource {
+	return fakeReleaseSource{
+		artifact.Containerd:     []byte(artifact.Containerd),
+		artifact.Runc:           []byte(artifact.Runc),
+		artifact.ContainerdShim: []byte(artifact.ContainerdShim),
+	}
+}
+
+func TestInstallRelease(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	dryRun := host.NewDryRun()
+	ctx := host.NewContext(context.Background(), dryRun)
+	tr := &tracker.Tracker{Artifacts: &tracker.InstalledArtifacts{}}
+
+	require.NoError(t, InstallRelease(ctx, tr, validReleaseSource(), zap.NewNop()))
+
+	assert.Equal(t, string(ContainerdSourceEks), tr.Artifacts.Containerd)
+	for _, name := range []string{artifact.Containerd, artifact.Runc, artifact.ContainerdShim} {
+		require.Contains(t, tr.Records, name)
+		assert.Equal(t, releaseBinDir, tr.Records[name].InstallPath)
+	}
+
+	var targets []string
+	for _, action := range dryRun.Actions() {
+		if action.Kind == host.ActionSymlink || action.Kind == host.ActionWriteFile {
+			targets = append(targets, action.Target)
+		}
+	}
+	assert.Contains(t, targets, "/usr/local/bin/containerd")
+	assert.Contains(t, targets, "/usr/local/bin/runc")
+	assert.Contains(t, targets, "/usr/local/bin/containerd-shim-runc-v2")
+	assert.Contains(t, targets, UnitPath)
+}
+
+func TestInstallReleaseChecksumMismatch(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	ctx := host.NewContext(context.Background(), host.NewDryRun())
+	tr := &tracker.Tracker{Artifacts: &tracker.InstalledArtifacts{}}
+	source := validReleaseSource()
+	source[artifact.Runc] = []byte("tampered")
+
+	err := InstallRelease(ctx, tr, source, zap.NewNop())
+	assert.ErrorContains(t, err, "runc checksum mismatch")
+	assert.Empty(t, tr.Artifacts.Containerd)
+}
+
+func TestUpgradeReleaseSkipsCurrentArchives(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	ctx := host.NewContext(context.Background(), host.NewDryRun())
+	tr := &tracker.Tracker{Artifacts: &tracker.InstalledArtifacts{}}
+	require.NoError(t, InstallRelease(ctx, tr, validReleaseSource(), zap.NewNop()))
+	installedAt := tr.Records[artifact.Containerd].InstalledAt
+
+	dryRun := host.NewDryRun()
+	ctx = host.NewContext(context.Background(), dryRun)
+	require.NoError(t, UpgradeRelease(ctx, tr, validReleaseSource(), zap.NewNop()))
+
+	assert.Equal(t, installedAt, tr.Records[artifact.Containerd].InstalledAt)
+	for _, action := range dryRun.Actions() {
+		assert.NotEqual(t, host.ActionRun, action.Kind, "archives should not be extracted again")
+	}
+}
+
+func TestUninstallReleaseReloadsSystemd(t *testing.T) {
+	dryRun := host.NewDryRun()
+	ctx := host.NewContext(context.Background(), dryRun)
+
+	require.NoError(t, UninstallRelease(ctx, dryRun.DaemonManager(nil)))
+
+	actions := dryRun.Actions()
+	assert.Contains(t, actions, host.Action{Kind: host.ActionRemove, Target: UnitPath})
+	assert.Equal(t, host.Action{Kind: host.ActionDaemon, Target: "systemd", Detail: "daemon-reload"}, actions[len(actions)-1])
+}
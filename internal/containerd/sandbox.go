@@ -1,6 +1,7 @@
 package containerd
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -12,12 +13,13 @@ import (
 	v1 "k8s.io/cri-api/pkg/apis/runtime/v1"
 
 	"github.com/aws/eks-hybrid/internal/aws/ecr"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
 var containerdSandboxImageRegex = regexp.MustCompile(`sandbox_image = "(.*)"`)
 
-func cacheSandboxImage(awsConfig *aws.Config) error {
+func cacheSandboxImage(ctx context.Context, awsConfig *aws.Config) error {
 	zap.L().Info("Looking up current sandbox image in containerd config..")
 	// capture the output of a `containerd config dump`, which is the final
 	// containerd configuration used after all of the applied transformations
@@ -45,13 +47,15 @@ func cacheSandboxImage(awsConfig *aws.Config) error {
 	imageSpec := &v1.ImageSpec{Image: sandboxImage}
 	authConfig := &v1.AuthConfig{Auth: ecrUserToken}
 
-	return util.RetryExponentialBackoff(3, 2*time.Second, func() error {
-		zap.L().Info("Pulling sandbox image..", zap.String("image", sandboxImage))
-		imageRef, err := client.PullImage(imageSpec, authConfig, nil)
-		if err != nil {
-			return err
-		}
-		zap.L().Info("Finished pulling sandbox image", zap.String("image-ref", imageRef))
-		return nil
+	return host.FromContext(ctx).CallAPI("cri:PullImage "+sandboxImage, func() error {
+		return util.RetryExponentialBackoff(3, 2*time.Second, func() error {
+			zap.L().Info("Pulling sandbox image..", zap.String("image", sandboxImage))
+			imageRef, err := client.PullImage(imageSpec, authConfig, nil)
+			if err != nil {
+				return err
+			}
+			zap.L().Info("Finished pulling sandbox image", zap.String("image-ref", imageRef))
+			return nil
+		})
 	})
 }
@@ -0,0 +1,111 @@
+package containerd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// ValidateConfig parses the user-provided containerd config, failing on TOML syntax
+// errors and warning about the keys that override a default set by nodeadm.
+func ValidateConfig(ctx context.Context, informer validation.Informer, node *api.NodeConfig) error {
+	var err error
+	informer.Starting(ctx, "containerd-config", "Validating containerd configuration")
+	defer func() {
+		informer.Done(ctx, "containerd-config", err)
+	}()
+	err = validateConfig(node)
+	return err
+}
+
+func validateConfig(node *api.NodeConfig) error {
+	if node.Spec.Containerd.Config == "" {
+		return nil
+	}
+
+	userConfig, err := parseConfig(node.Spec.Containerd.Config)
+	if err != nil {
+		return validation.WithRemediation(fmt.Errorf("invalid containerd config: %w", err),
+			"Fix the TOML syntax of spec.containerd.config")
+	}
+
+	defaultConfigData, err := generateContainerdConfig(node)
+	if err != nil {
+		return err
+	}
+	defaultConfig, err := parseConfig(string(defaultConfigData))
+	if err != nil {
+		return err
+	}
+
+	if overrides := overriddenKeys(defaultConfig, userConfig); len(overrides) > 0 {
+		return validation.NewWarning(
+			fmt.Errorf("containerd config overrides defaults set by nodeadm: %s", strings.Join(overrides, ", ")),
+			"Make sure these values are intended, the containerd config is applied on top of nodeadm's defaults",
+		)
+	}
+	return nil
+}
+
+func parseConfig(config string) (map[string]any, error) {
+	var parsed map[string]any
+	if err := toml.Unmarshal([]byte(config), &parsed); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			row, column := decodeErr.Position()
+			return nil, fmt.Errorf("line %d, column %d: %w", row, column, err)
+		}
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// overriddenKeys returns the keys, sorted, set in both configs with different values.
+func overriddenKeys(defaults, config map[string]any) []string {
+	defaultValues := flatten("", defaults)
+	var overridden []string
+	for key, value := range flatten("", config) {
+		if defaultValue, ok := defaultValues[key]; ok && !reflect.DeepEqual(defaultValue, value) {
+			overridden = append(overridden, key)
+		}
+	}
+	slices.Sort(overridden)
+	return overridden
+}
+
+// flatten returns the leaf values of a parsed TOML document by their dotted key.
+func flatten(prefix string, table map[string]any) map[string]any {
+	values := map[string]any{}
+	for _, key := range slices.Sorted(maps.Keys(table)) {
+		name := tomlKey(key)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if nested, ok := table[key].(map[string]any); ok {
+			maps.Copy(values, flatten(name, nested))
+			continue
+		}
+		values[name] = table[key]
+	}
+	return values
+}
+
+// tomlKey quotes key if it's not a valid bare TOML key.
+func tomlKey(key string) string {
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
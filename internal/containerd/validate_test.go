@@ -0,0 +1,63 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		wantErr     string
+		wantWarning bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "new keys",
+			config: `[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+endpoint = ["https://mirror.example.com"]`,
+		},
+		{
+			name: "same value as default",
+			config: `version = 2
+[plugins."io.containerd.grpc.v1.cri".containerd]
+default_runtime_name = "runc"`,
+		},
+		{
+			name: "overrides defaults",
+			config: `[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+SystemdCgroup = false`,
+			wantErr: `containerd config overrides defaults set by nodeadm: plugins."io.containerd.grpc.v1.cri".containerd.discard_unpacked_layers, ` +
+				`plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options.SystemdCgroup`,
+			wantWarning: true,
+		},
+		{
+			name: "syntax error",
+			config: `[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = `,
+			wantErr: "invalid containerd config: line 2, column 27",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := &api.NodeConfig{Spec: api.NodeConfigSpec{Containerd: api.ContainerdOptions{Config: test.config}}}
+			err := validateConfig(node)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.wantErr)
+			assert.Equal(t, test.wantWarning, validation.IsWarning(err))
+		})
+	}
+}
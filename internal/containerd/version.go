@@ -0,0 +1,105 @@
+package containerd
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/aws/eks-hybrid/internal/aws"
+)
+
+// VersionRange is a range of containerd versions, from Min included to Max excluded.
+type VersionRange struct {
+	Min string
+	Max string
+}
+
+func (r VersionRange) String() string {
+	return fmt.Sprintf(">= %s, < %s", r.Min, r.Max)
+}
+
+// supportedVersions are the containerd versions that can be requested with --containerd-version
+// for each containerd source.
+var supportedVersions = map[SourceName]VersionRange{
+	ContainerdSourceDistro: {Min: "1.6.0", Max: "2.0.0"},
+	ContainerdSourceDocker: {Min: "1.6.0", Max: "2.0.0"},
+	ContainerdSourceEks:    {Min: aws.MinContainerdVersion, Max: aws.MaxContainerdVersion},
+}
+
+// versionRegex matches a major.minor[.patch] version, optionally followed by the package release.
+var versionRegex = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(-.+)?$`)
+
+// installedVersionRegex matches the version printed by containerd --version, like
+// "containerd github.com/containerd/containerd v1.7.24 88bf19b2105c8b17560993bee28a01ddc2f97182".
+var installedVersionRegex = regexp.MustCompile(`\sv?(\d+\.\d+\.\d+)\S*`)
+
+// ValidateVersion validates that version is in the supported range for source.
+// An empty version is valid and installs the latest supported version.
+func ValidateVersion(source SourceName, version string) error {
+	if version == "" {
+		return nil
+	}
+	supported, ok := supportedVersions[source]
+	if !ok {
+		return fmt.Errorf("--containerd-version is not supported with containerd source %s", source)
+	}
+	match := versionRegex.FindStringSubmatch(version)
+	if match == nil {
+		return fmt.Errorf("invalid containerd version %s, expected format major.minor[.patch]", version)
+	}
+	lowest, highest := match[3], match[3]
+	if match[3] == "" {
+		// A minor version is supported if any of its patches is in the range.
+		lowest, highest = "0", "999999"
+	}
+	if semver.Compare(fmt.Sprintf("v%s.%s.%s", match[1], match[2], highest), "v"+supported.Min) < 0 ||
+		semver.Compare(fmt.Sprintf("v%s.%s.%s", match[1], match[2], lowest), "v"+supported.Max) >= 0 {
+		return fmt.Errorf("containerd version %s is not supported with containerd source %s, supported versions are %s", version, source, supported)
+	}
+	return nil
+}
+
+// packageVersion returns the version pattern passed to the package manager for version.
+// Versions without a package release match any release of that version.
+func packageVersion(version string) string {
+	if version == "" {
+		return ContainerdVersion
+	}
+	match := versionRegex.FindStringSubmatch(version)
+	switch {
+	case match == nil, match[4] != "":
+		return version
+	case match[3] == "":
+		return version + ".*"
+	default:
+		return version + "-*"
+	}
+}
+
+// installedVersion returns the major.minor.patch version of the containerd in the PATH.
+func installedVersion() (string, error) {
+	out, err := exec.Command(containerdPackageName, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("getting installed containerd version: %w", err)
+	}
+	match := installedVersionRegex.FindStringSubmatch(string(out))
+	if match == nil {
+		return "", fmt.Errorf("no version in containerd --version output %q", out)
+	}
+	return match[1], nil
+}
+
+// versionMatches returns true if the installed major.minor.patch version is the one
+// requested with --containerd-version. The package release of requested is ignored.
+func versionMatches(requested, installed string) bool {
+	match := versionRegex.FindStringSubmatch(requested)
+	if match == nil {
+		return false
+	}
+	if match[3] == "" {
+		return semver.MajorMinor("v"+installed) == fmt.Sprintf("v%s.%s", match[1], match[2])
+	}
+	return installed == fmt.Sprintf("%s.%s.%s", match[1], match[2], match[3])
+}
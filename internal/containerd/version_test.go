@@ -0,0 +1,109 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  SourceName
+		version string
+		wantErr string
+	}{
+		{
+			name:   "empty version",
+			source: ContainerdSourceDistro,
+		},
+		{
+			name:    "patch version",
+			source:  ContainerdSourceDistro,
+			version: "1.7.24",
+		},
+		{
+			name:    "minor version",
+			source:  ContainerdSourceDocker,
+			version: "1.6",
+		},
+		{
+			name:    "version with package release",
+			source:  ContainerdSourceDocker,
+			version: "1.7.24-1",
+		},
+		{
+			name:    "too old",
+			source:  ContainerdSourceDistro,
+			version: "1.5.9",
+			wantErr: "containerd version 1.5.9 is not supported with containerd source distro, supported versions are >= 1.6.0, < 2.0.0",
+		},
+		{
+			name:    "too new",
+			source:  ContainerdSourceDistro,
+			version: "2.0",
+			wantErr: "containerd version 2.0 is not supported with containerd source distro, supported versions are >= 1.6.0, < 2.0.0",
+		},
+		{
+			name:    "invalid format",
+			source:  ContainerdSourceDistro,
+			version: "latest",
+			wantErr: "invalid containerd version latest, expected format major.minor[.patch]",
+		},
+		{
+			name:    "source none",
+			source:  ContainerdSourceNone,
+			version: "1.7.24",
+			wantErr: "--containerd-version is not supported with containerd source none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVersion(tt.source, tt.version)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPackageVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "", want: ContainerdVersion},
+		{version: "1.7", want: "1.7.*"},
+		{version: "1.7.24", want: "1.7.24-*"},
+		{version: "1.7.24-0ubuntu1~24.04.2", want: "1.7.24-0ubuntu1~24.04.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, packageVersion(tt.version))
+		})
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	assert.True(t, versionMatches("1.7", "1.7.24"))
+	assert.True(t, versionMatches("1.7.24", "1.7.24"))
+	assert.True(t, versionMatches("1.7.24-1", "1.7.24"))
+	assert.False(t, versionMatches("1.7", "1.6.36"))
+	assert.False(t, versionMatches("1.7.24", "1.7.25"))
+	assert.False(t, versionMatches("invalid", "1.7.24"))
+}
+
+func TestInstalledVersionRegex(t *testing.T) {
+	for output, want := range map[string]string{
+		"containerd github.com/containerd/containerd v1.7.24 88bf19b2105c8b17560993bee28a01ddc2f97182\n": "1.7.24",
+		"containerd github.com/containerd/containerd 1.6.36 \n":                                          "1.6.36",
+		"containerd containerd.io 1.7.25 bcc810d6b9066471b0b6fa75f557a15a1cbf31bb\n":                     "1.7.25",
+	} {
+		match := installedVersionRegex.FindStringSubmatch(output)
+		if assert.NotNil(t, match, output) {
+			assert.Equal(t, want, match[1])
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package credentialprocess
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"text/template"
+
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+const (
+	// DefaultAWSConfigPath is the path where the AWS config is written.
+	DefaultAWSConfigPath = iamrolesanywhere.DefaultAWSConfigPath
+
+	// ProfileName is the profile used when writing the AWS config. It's the same one used
+	// for IAM Roles Anywhere so the kubelet credential plugins are configured the same way.
+	ProfileName = iamrolesanywhere.ProfileName
+)
+
+//go:embed aws_config.tpl
+var unformattedRawAWSConfigTpl string
+
+var awsConfigTpl = template.Must(template.New("").Parse(fmt.Sprintf(unformattedRawAWSConfigTpl, ProfileName)))
+
+// AWSConfig defines the data for the AWS config file that gets credentials from an external command.
+type AWSConfig struct {
+	// Region is the region to target when authenticating.
+	Region string
+
+	// Command is the credential_process command line.
+	Command string
+
+	// ConfigPath is the path of the AWS config file. Defaults to /etc/aws/hybrid/config.
+	ConfigPath string
+}
+
+// WriteAWSConfig writes the AWS config file using the Host in the context.
+func WriteAWSConfig(ctx context.Context, cfg AWSConfig) error {
+	file, err := RenderAWSConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	h := host.FromContext(ctx)
+	if err := h.MkdirAll(path.Dir(file.Path), os.ModeDir); err != nil {
+		return err
+	}
+	if err := h.WriteFile(file.Path, bytes.NewReader(file.Content), file.Perms); err != nil {
+		return fmt.Errorf("writing AWS config file: %w", err)
+	}
+	return nil
+}
+
+// RenderAWSConfig returns the AWS config file written by WriteAWSConfig without writing it.
+func RenderAWSConfig(cfg AWSConfig) (util.RenderedFile, error) {
+	if cfg.ConfigPath == "" {
+		cfg.ConfigPath = DefaultAWSConfigPath
+	}
+
+	var errs []error
+	if cfg.Region == "" {
+		errs = append(errs, errors.New("Region cannot be empty"))
+	}
+	if cfg.Command == "" {
+		errs = append(errs, errors.New("Command cannot be empty"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return util.RenderedFile{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := awsConfigTpl.Execute(&buf, cfg); err != nil {
+		return util.RenderedFile{}, err
+	}
+
+	return util.RenderedFile{Path: cfg.ConfigPath, Content: buf.Bytes(), Perms: 0o644}, nil
+}
@@ -0,0 +1,3 @@
+[profile %v]
+region = {{ .Region }}
+credential_process = {{ .Command }}
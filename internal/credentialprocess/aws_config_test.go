@@ -0,0 +1,37 @@
+package credentialprocess_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/credentialprocess"
+)
+
+func TestWriteAWSConfig(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "aws", "config")
+
+	expected, err := os.ReadFile("testdata/aws-config")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(credentialprocess.WriteAWSConfig(context.Background(), credentialprocess.AWSConfig{
+		Region:     "us-west-2",
+		Command:    "/usr/local/bin/vault-aws-creds --role eks-hybrid",
+		ConfigPath: path,
+	})).To(Succeed())
+
+	received, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(received)).To(Equal(string(expected)))
+}
+
+func TestRenderAWSConfigValidation(t *testing.T) {
+	g := NewWithT(t)
+	_, err := credentialprocess.RenderAWSConfig(credentialprocess.AWSConfig{})
+	g.Expect(err).To(MatchError(ContainSubstring("Region cannot be empty")))
+	g.Expect(err).To(MatchError(ContainSubstring("Command cannot be empty")))
+}
@@ -0,0 +1,75 @@
+package credentialprocess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+const runTimeout = 30 * time.Second
+
+// output is the subset of the credential_process output used to validate it.
+// The secrets are intentionally not decoded so they can't end up in logs or errors.
+type output struct {
+	Version     int
+	AccessKeyID string `json:"AccessKeyId"`
+	Expiration  *time.Time
+}
+
+// CheckCommand runs the credential_process command the same way the AWS SDKs do
+// and verifies it prints credentials in the expected format.
+func CheckCommand(ctx context.Context, command string) error {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	// #nosec G204 the command comes from the node config, written by the node administrator
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("running credential process: %w", err)
+	}
+
+	var creds output
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return fmt.Errorf("credential process output is not valid JSON")
+	}
+	if creds.Version != 1 {
+		return fmt.Errorf("credential process output has unsupported Version %d, only 1 is supported", creds.Version)
+	}
+	if creds.AccessKeyID == "" {
+		return fmt.Errorf("credential process output is missing AccessKeyId")
+	}
+	if creds.Expiration != nil && creds.Expiration.Before(time.Now()) {
+		return fmt.Errorf("credential process returned credentials that expired at %s", creds.Expiration.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CommandValidator validates the credential process command returns AWS credentials.
+type CommandValidator struct{}
+
+// NewCommandValidator returns a new CommandValidator.
+func NewCommandValidator() CommandValidator {
+	return CommandValidator{}
+}
+
+func (v CommandValidator) Run(ctx context.Context, informer validation.Informer, node *api.NodeConfig) error {
+	var err error
+	informer.Starting(ctx, "credential-process", "Validating credential process returns AWS credentials")
+	defer func() {
+		informer.Done(ctx, "credential-process", err)
+	}()
+
+	if err = CheckCommand(ctx, node.Spec.Hybrid.CredentialProcess.Command); err != nil {
+		err = validation.WithRemediation(err, "Ensure the command in spec.hybrid.credentialProcess.command prints credentials as described in "+
+			"https://docs.aws.amazon.com/sdkref/latest/guide/feature-process-credentials.html")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package credentialprocess_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/credentialprocess"
+)
+
+func TestCheckCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		command string
+		wantErr string
+	}{
+		{
+			name:    "valid credentials",
+			command: `echo '{"Version": 1, "AccessKeyId": "AKIA", "SecretAccessKey": "secret", "SessionToken": "token", "Expiration": "2100-01-01T00:00:00Z"}'`,
+		},
+		{
+			name:    "long lived credentials",
+			command: `echo '{"Version": 1, "AccessKeyId": "AKIA", "SecretAccessKey": "secret"}'`,
+		},
+		{
+			name:    "command fails",
+			command: "exit 1",
+			wantErr: "running credential process",
+		},
+		{
+			name:    "invalid json",
+			command: "echo not-json",
+			wantErr: "not valid JSON",
+		},
+		{
+			name:    "unsupported version",
+			command: `echo '{"Version": 2, "AccessKeyId": "AKIA"}'`,
+			wantErr: "unsupported Version 2",
+		},
+		{
+			name:    "missing access key",
+			command: `echo '{"Version": 1, "SecretAccessKey": "secret"}'`,
+			wantErr: "missing AccessKeyId",
+		},
+		{
+			name:    "expired credentials",
+			command: `echo '{"Version": 1, "AccessKeyId": "AKIA", "SecretAccessKey": "secret", "Expiration": "2000-01-01T00:00:00Z"}'`,
+			wantErr: "expired at 2000-01-01T00:00:00Z",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := credentialprocess.CheckCommand(context.Background(), tc.command)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				g.Expect(err.Error()).NotTo(ContainSubstring("secret"))
+			}
+		})
+	}
+}
@@ -2,17 +2,16 @@ package creds
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"regexp"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/processcreds"
 
 	"github.com/aws/eks-hybrid/internal/api"
-	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 )
 
-const iamRoleAnywhereProfileName = "hybrid"
-
 func ReadConfig(ctx context.Context, node *api.NodeConfig, opts ...func(*config.LoadOptions) error) (aws.Config, error) {
 	if !node.IsHybridNode() {
 		if node.Spec.Cluster.Region != "" {
@@ -20,26 +19,41 @@ func ReadConfig(ctx context.Context, node *api.NodeConfig, opts ...func(*config.
 		}
 		return config.LoadDefaultConfig(ctx, opts...)
 	}
-	if node.IsSSM() {
-		opts = append(opts, config.WithRegion(node.Spec.Cluster.Region))
-		return config.LoadDefaultConfig(ctx, opts...)
+
+	provider, err := ForNodeConfig(node)
+	if err != nil {
+		return aws.Config{}, err
 	}
+	opts = append(opts, provider.LoadOptions(node)...)
+	return config.LoadDefaultConfig(ctx, opts...)
+}
 
-	if node.IsIAMRolesAnywhere() {
-		awsConfigPath := node.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath
+var credentialProcessRegex = regexp.MustCompile(`(?m)^credential_process = (.+)$`)
 
-		if awsConfigPath == "" {
-			awsConfigPath = iamrolesanywhere.DefaultAWSConfigPath
+// renderedConfig builds the AWS config provider loads once its AWS config file is
+// written, from the rendered file instead of the one in the host. It's used in dry
+// runs, where the file is not written and the host one might be missing or stale.
+func renderedConfig(ctx context.Context, provider Provider, node *api.NodeConfig) (aws.Config, error) {
+	files, err := provider.RenderConfig(node)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	configPath := provider.AWSConfigPath(node)
+	for _, file := range files {
+		if file.Path != configPath {
+			continue
 		}
-
-		opts = append(opts,
+		match := credentialProcessRegex.FindSubmatch(file.Content)
+		if match == nil {
+			return aws.Config{}, fmt.Errorf("no credential_process in rendered AWS config %s", configPath)
+		}
+		return config.LoadDefaultConfig(ctx,
 			config.WithRegion(node.Spec.Cluster.Region),
-			config.WithSharedConfigFiles([]string{awsConfigPath}),
-			config.WithSharedConfigProfile(iamRoleAnywhereProfileName),
+			// Don't read the shared files in the host, the credentials come from the rendered config.
+			config.WithSharedConfigFiles([]string{}),
+			config.WithSharedCredentialsFiles([]string{}),
+			config.WithCredentialsProvider(aws.NewCredentialsCache(processcreds.NewProvider(string(match[1])))),
 		)
-
-		return config.LoadDefaultConfig(ctx, opts...)
 	}
-
-	return aws.Config{}, errors.New("don't know how to build aws config for node config: only EC2, SSM or IAM Roles Anywhere are supported")
+	return aws.Config{}, fmt.Errorf("AWS config %s not rendered by %s", configPath, provider.Name())
 }
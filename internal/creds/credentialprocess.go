@@ -0,0 +1,144 @@
+package creds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/credentialprocess"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// CredentialProcessProvider gets credentials by running an external command that
+// follows the AWS credential_process protocol. Nothing is downloaded on install,
+// the command is expected to be available on the host.
+type CredentialProcessProvider struct{}
+
+var _ Provider = CredentialProcessProvider{}
+
+func (CredentialProcessProvider) Name() CredentialProvider {
+	return CredentialProcessCredentialProvider
+}
+
+func (CredentialProcessProvider) IsConfigured(node *api.NodeConfig) bool {
+	return node.IsCredentialProcess()
+}
+
+func (CredentialProcessProvider) IsInstalled(artifacts *tracker.InstalledArtifacts) bool {
+	return artifacts.CredentialProcess
+}
+
+func (CredentialProcessProvider) ValidateOS(_, _ string) error {
+	return nil
+}
+
+func (CredentialProcessProvider) Install(_ context.Context, opts InstallOptions) error {
+	opts.Logger.Info("Using external credential process, no artifacts to install")
+	return opts.Tracker.Add(artifact.CredentialProcess)
+}
+
+func (CredentialProcessProvider) Upgrade(_ context.Context, _ InstallOptions) error {
+	return nil
+}
+
+func (CredentialProcessProvider) BackupPaths() []string {
+	return nil
+}
+
+func (CredentialProcessProvider) Uninstall(ctx context.Context, opts UninstallOptions) error {
+	opts.Logger.Info("Removing credential process AWS config...")
+	h := host.FromContext(ctx)
+	for _, path := range append([]string{credentialprocess.DefaultAWSConfigPath}, opts.AWSConfigPaths...) {
+		if err := h.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (CredentialProcessProvider) PopulateDefaults(node *api.NodeConfig) {
+	node.Status.Hybrid.NodeName = node.Spec.Hybrid.CredentialProcess.NodeName
+	if node.Spec.Hybrid.CredentialProcess.AwsConfigPath == "" {
+		node.Spec.Hybrid.CredentialProcess.AwsConfigPath = credentialprocess.DefaultAWSConfigPath
+	}
+}
+
+func (CredentialProcessProvider) ValidateConfig(node *api.NodeConfig) error {
+	if node.Spec.Hybrid.CredentialProcess.NodeName == "" {
+		return fmt.Errorf("NodeName can't be empty in hybrid credential process configuration")
+	}
+	if len(node.Spec.Hybrid.CredentialProcess.NodeName) > 64 {
+		return fmt.Errorf("NodeName can't be longer than 64 characters in hybrid credential process configuration")
+	}
+	if node.Spec.Hybrid.CredentialProcess.Command == "" {
+		return fmt.Errorf("Command is missing in hybrid credential process configuration")
+	}
+	return nil
+}
+
+// ConfigureAWS writes an AWS config that uses the configured command as credential_process.
+func (p CredentialProcessProvider) ConfigureAWS(ctx context.Context, opts ConfigureOptions) (aws.Config, error) {
+	if err := credentialprocess.WriteAWSConfig(ctx, credentialProcessAWSConfig(opts.NodeConfig)); err != nil {
+		return aws.Config{}, err
+	}
+
+	if host.IsDryRun(ctx) {
+		// The AWS config was not written, build it from the rendered one.
+		return renderedConfig(ctx, p, opts.NodeConfig)
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, p.LoadOptions(opts.NodeConfig)...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("generating aws config for credential process: %w", err)
+	}
+	return awsConfig, nil
+}
+
+func (p CredentialProcessProvider) LoadOptions(node *api.NodeConfig) []func(*config.LoadOptions) error {
+	return []func(*config.LoadOptions) error{
+		config.WithRegion(node.Spec.Cluster.Region),
+		config.WithSharedConfigFiles([]string{p.AWSConfigPath(node)}),
+		config.WithSharedConfigProfile(credentialprocess.ProfileName),
+	}
+}
+
+func (CredentialProcessProvider) AWSConfigPath(node *api.NodeConfig) string {
+	if node.Spec.Hybrid.CredentialProcess.AwsConfigPath == "" {
+		return credentialprocess.DefaultAWSConfigPath
+	}
+	return node.Spec.Hybrid.CredentialProcess.AwsConfigPath
+}
+
+func (CredentialProcessProvider) RenderConfig(node *api.NodeConfig) ([]util.RenderedFile, error) {
+	awsConfig, err := credentialprocess.RenderAWSConfig(credentialProcessAWSConfig(node))
+	if err != nil {
+		return nil, err
+	}
+	return []util.RenderedFile{awsConfig}, nil
+}
+
+func (CredentialProcessProvider) Daemons(_ daemon.DaemonManager, _ *api.NodeConfig) []daemon.Daemon {
+	return nil
+}
+
+func (CredentialProcessProvider) Validations(_ aws.Config, _ *api.NodeConfig) []validation.Validation[*api.NodeConfig] {
+	return []validation.Validation[*api.NodeConfig]{
+		validation.New("credential-process", credentialprocess.NewCommandValidator().Run),
+	}
+}
+
+func credentialProcessAWSConfig(node *api.NodeConfig) credentialprocess.AWSConfig {
+	return credentialprocess.AWSConfig{
+		Region:     node.Spec.Cluster.Region,
+		Command:    node.Spec.Hybrid.CredentialProcess.Command,
+		ConfigPath: node.Spec.Hybrid.CredentialProcess.AwsConfigPath,
+	}
+}
@@ -0,0 +1,137 @@
+package creds_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+func credentialProcessNode(configPath string) *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Region: "us-west-2",
+			},
+			Hybrid: &api.HybridOptions{
+				CredentialProcess: &api.CredentialProcess{
+					NodeName:      "my-node",
+					Command:       "/usr/local/bin/get-creds --profile node",
+					AwsConfigPath: configPath,
+				},
+			},
+		},
+	}
+}
+
+func TestCredentialProcessProvider_ValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(*api.CredentialProcess)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*api.CredentialProcess) {},
+		},
+		{
+			name:    "missing node name",
+			modify:  func(c *api.CredentialProcess) { c.NodeName = "" },
+			wantErr: "NodeName can't be empty in hybrid credential process configuration",
+		},
+		{
+			name:    "node name too long",
+			modify:  func(c *api.CredentialProcess) { c.NodeName = strings.Repeat("a", 65) },
+			wantErr: "NodeName can't be longer than 64 characters in hybrid credential process configuration",
+		},
+		{
+			name:    "missing command",
+			modify:  func(c *api.CredentialProcess) { c.Command = "" },
+			wantErr: "Command is missing in hybrid credential process configuration",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			node := credentialProcessNode("")
+			tc.modify(node.Spec.Hybrid.CredentialProcess)
+			err := creds.CredentialProcessProvider{}.ValidateConfig(node)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestCredentialProcessProvider_PopulateDefaults(t *testing.T) {
+	g := NewWithT(t)
+	node := credentialProcessNode("")
+
+	creds.CredentialProcessProvider{}.PopulateDefaults(node)
+
+	g.Expect(node.Status.Hybrid.NodeName).To(Equal("my-node"))
+	g.Expect(node.Spec.Hybrid.CredentialProcess.AwsConfigPath).To(Equal("/etc/aws/hybrid/config"))
+}
+
+func TestCredentialProcessProvider_ConfigureAWS(t *testing.T) {
+	g := NewWithT(t)
+	configFile := filepath.Join(t.TempDir(), "config")
+	node := credentialProcessNode(configFile)
+	p := creds.CredentialProcessProvider{}
+
+	awsConfig, err := p.ConfigureAWS(context.Background(), creds.ConfigureOptions{NodeConfig: node})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(awsConfig.Region).To(Equal("us-west-2"))
+	g.Expect(configFile).To(BeAnExistingFile())
+	g.Expect(p.AWSConfigPath(node)).To(Equal(configFile))
+
+	files, err := p.RenderConfig(node)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(HaveLen(1))
+	g.Expect(files[0].Path).To(Equal(configFile))
+	g.Expect(string(files[0].Content)).To(ContainSubstring("credential_process = /usr/local/bin/get-creds --profile node"))
+}
+
+func TestCredentialProcessProvider_UninstallCustomConfigPath(t *testing.T) {
+	g := NewWithT(t)
+	dryRun := host.NewDryRun()
+	ctx := host.NewContext(context.Background(), dryRun)
+
+	err := creds.CredentialProcessProvider{}.Uninstall(ctx, creds.UninstallOptions{
+		Logger:         zap.NewNop(),
+		AWSConfigPaths: []string{"/etc/custom/aws/config"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dryRun.Actions()).To(Equal([]host.Action{
+		{Kind: host.ActionRemove, Target: "/etc/aws/hybrid/config"},
+		{Kind: host.ActionRemove, Target: "/etc/custom/aws/config"},
+	}))
+}
+
+func TestCredentialProcessProvider_ConfigureAWSDryRun(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dryRun := host.NewDryRun()
+	configFile := filepath.Join(t.TempDir(), "config")
+	node := credentialProcessNode(configFile)
+	node.Spec.Hybrid.CredentialProcess.Command = `echo '{"Version": 1, "AccessKeyId": "AKID", "SecretAccessKey": "secret"}'`
+
+	awsConfig, err := creds.CredentialProcessProvider{}.ConfigureAWS(host.NewContext(ctx, dryRun), creds.ConfigureOptions{NodeConfig: node})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(configFile).NotTo(BeAnExistingFile())
+	g.Expect(dryRun.Actions()).To(ContainElement(HaveField("Target", configFile)))
+	g.Expect(awsConfig.Region).To(Equal("us-west-2"))
+
+	credentials, err := awsConfig.Credentials.Retrieve(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(credentials.AccessKeyID).To(Equal("AKID"))
+}
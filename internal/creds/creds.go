@@ -1,8 +1,6 @@
 package creds
 
 import (
-	"fmt"
-
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
@@ -10,35 +8,37 @@ import (
 type CredentialProvider string
 
 const (
-	SsmCredentialProvider              CredentialProvider = "ssm"
-	IamRolesAnywhereCredentialProvider CredentialProvider = "iam-ra"
+	SsmCredentialProvider               CredentialProvider = "ssm"
+	IamRolesAnywhereCredentialProvider  CredentialProvider = "iam-ra"
+	CredentialProcessCredentialProvider CredentialProvider = "credential-process"
 )
 
+func init() {
+	Register(SSMProvider{})
+	Register(IAMRolesAnywhereProvider{})
+	Register(CredentialProcessProvider{})
+}
+
 func GetCredentialProvider(credProcess string) (CredentialProvider, error) {
-	switch credProcess {
-	case string(SsmCredentialProvider):
-		return SsmCredentialProvider, nil
-	case string(IamRolesAnywhereCredentialProvider):
-		return IamRolesAnywhereCredentialProvider, nil
-	default:
-		return "", fmt.Errorf("invalid credential process provided. Valid options are ssm and iam-ra")
+	provider, err := Get(CredentialProvider(credProcess))
+	if err != nil {
+		return "", err
 	}
+	return provider.Name(), nil
 }
 
 func GetCredentialProviderFromNodeConfig(nodeCfg *api.NodeConfig) (CredentialProvider, error) {
-	if nodeCfg.IsSSM() {
-		return SsmCredentialProvider, nil
-	} else if nodeCfg.IsIAMRolesAnywhere() {
-		return IamRolesAnywhereCredentialProvider, nil
+	provider, err := ForNodeConfig(nodeCfg)
+	if err != nil {
+		return "", err
 	}
-	return "", fmt.Errorf("no credential process provided in nodeConfig")
+	return provider.Name(), nil
 }
 
 func GetCredentialProviderFromInstalledArtifacts(artifacts *tracker.InstalledArtifacts) (CredentialProvider, error) {
-	if artifacts.Ssm {
-		return SsmCredentialProvider, nil
-	} else if artifacts.IamRolesAnywhere {
-		return IamRolesAnywhereCredentialProvider, nil
+	provider, err := ForInstalledArtifacts(artifacts)
+	if err != nil {
+		return "", err
 	}
-	return "", fmt.Errorf("no credential process found in installed artifacts")
+	return provider.Name(), nil
 }
@@ -0,0 +1,223 @@
+package creds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/secrets"
+	"github.com/aws/eks-hybrid/internal/system"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/util/file"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// IAMRolesAnywhereProvider gets credentials from IAM Roles Anywhere with the aws_signing_helper
+// using an X.509 certificate.
+type IAMRolesAnywhereProvider struct{}
+
+var _ Provider = IAMRolesAnywhereProvider{}
+
+func (IAMRolesAnywhereProvider) Name() CredentialProvider {
+	return IamRolesAnywhereCredentialProvider
+}
+
+func (IAMRolesAnywhereProvider) IsConfigured(node *api.NodeConfig) bool {
+	return node.IsIAMRolesAnywhere()
+}
+
+func (IAMRolesAnywhereProvider) IsInstalled(artifacts *tracker.InstalledArtifacts) bool {
+	return artifacts.IamRolesAnywhere
+}
+
+func (IAMRolesAnywhereProvider) ValidateOS(osName, osVersion string) error {
+	majorOsVersion, err := getMajorVersion(osVersion)
+	if err != nil {
+		return err
+	}
+
+	// Both RHEL8 and Ubuntu 20 have older version of glibc which iam roles anywhere credential helper doesn't work with
+	// Until we have a fix for that, we will validate and avoid these os version combinations
+	// https://github.com/aws/rolesanywhere-credential-helper/issues/90
+	if (osName == system.RhelOsName && majorOsVersion == "8") || (osName == system.UbuntuOsName && majorOsVersion == "20") {
+		return fmt.Errorf("iam-ra credential provider is not supported on %s %s based operating systems. Please use ssm credential provider", osName, osVersion)
+	}
+	return nil
+}
+
+func (IAMRolesAnywhereProvider) Install(ctx context.Context, opts InstallOptions) error {
+	opts.Logger.Info("Installing AWS signing helper...")
+	return iamrolesanywhere.Install(ctx, iamrolesanywhere.InstallOptions{
+		Tracker: opts.Tracker,
+		Source:  opts.Source,
+		Logger:  opts.Logger,
+	})
+}
+
+func (IAMRolesAnywhereProvider) Upgrade(ctx context.Context, opts InstallOptions) error {
+	opts.Logger.Info("Upgrading AWS signing helper...")
+	return iamrolesanywhere.Upgrade(ctx, opts.Source, opts.Tracker, opts.Logger)
+}
+
+func (IAMRolesAnywhereProvider) BackupPaths() []string {
+	return []string{iamrolesanywhere.SigningHelperBinPath}
+}
+
+func (IAMRolesAnywhereProvider) Uninstall(ctx context.Context, opts UninstallOptions) error {
+	opts.Logger.Info("Removing aws_signing_helper_update daemon...")
+	if status, err := opts.DaemonManager.GetDaemonStatus(iamrolesanywhere.DaemonName); err == nil || status != daemon.DaemonStatusUnknown {
+		if err = opts.DaemonManager.StopDaemon(iamrolesanywhere.DaemonName); err != nil {
+			opts.Logger.Info("Stopping aws_signing_helper_update daemon...")
+			return err
+		}
+	}
+
+	opts.Logger.Info("Uninstalling AWS signing helper...")
+	return iamrolesanywhere.Uninstall(ctx)
+}
+
+func (IAMRolesAnywhereProvider) PopulateDefaults(node *api.NodeConfig) {
+	node.Status.Hybrid.NodeName = node.Spec.Hybrid.IAMRolesAnywhere.NodeName
+	if node.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath == "" {
+		node.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath = iamrolesanywhere.DefaultAWSConfigPath
+	}
+
+	if node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath == "" {
+		node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath = iamrolesanywhere.DefaultCertificatePath
+	}
+	if node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath == "" {
+		node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath = iamrolesanywhere.DefaultPrivateKeyPath
+	}
+}
+
+func (IAMRolesAnywhereProvider) ValidateConfig(node *api.NodeConfig) error {
+	if node.Spec.Hybrid.IAMRolesAnywhere.RoleARN == "" {
+		return fmt.Errorf("RoleARN is missing in hybrid iam roles anywhere configuration")
+	}
+	if node.Spec.Hybrid.IAMRolesAnywhere.ProfileARN == "" {
+		return fmt.Errorf("ProfileARN is missing in hybrid iam roles anywhere configuration")
+	}
+	if node.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN == "" {
+		return fmt.Errorf("TrustAnchorARN is missing in hybrid iam roles anywhere configuration")
+	}
+	if node.Spec.Hybrid.IAMRolesAnywhere.NodeName == "" {
+		return fmt.Errorf("NodeName can't be empty in hybrid iam roles anywhere configuration")
+	}
+	if len(node.Spec.Hybrid.IAMRolesAnywhere.NodeName) > 64 {
+		return fmt.Errorf("NodeName can't be longer than 64 characters in hybrid iam roles anywhere configuration")
+	}
+	if node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath == "" {
+		return fmt.Errorf("CertificatePath is missing in hybrid iam roles anywhere configuration")
+	}
+	if node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath == "" {
+		return fmt.Errorf("PrivateKeyPath is missing in hybrid iam roles anywhere configuration")
+	}
+
+	if !file.Exists(node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath) {
+		return fmt.Errorf("IAM Roles Anywhere certificate %s not found", node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath)
+	}
+
+	// A private key given as a secret reference is written by ConfigureAWS.
+	if !secrets.IsReference(node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath) && !file.Exists(node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath) {
+		return fmt.Errorf("IAM Roles Anywhere private key %s not found", secrets.Redact(node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath))
+	}
+
+	return nil
+}
+
+// ConfigureAWS writes an AWS config that uses the aws_signing_helper as credential process.
+func (p IAMRolesAnywhereProvider) ConfigureAWS(ctx context.Context, opts ConfigureOptions) (aws.Config, error) {
+	if err := iamrolesanywhere.WriteAWSConfig(ctx, rolesAnywhereAWSConfig(opts.NodeConfig)); err != nil {
+		return aws.Config{}, err
+	}
+	if err := writeResolvedPrivateKey(ctx, opts.NodeConfig); err != nil {
+		return aws.Config{}, err
+	}
+
+	if host.IsDryRun(ctx) {
+		// The AWS config was not written, build it from the rendered one.
+		return renderedConfig(ctx, p, opts.NodeConfig)
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, p.LoadOptions(opts.NodeConfig)...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("generating aws config for IAM Roles Anywhere: %w", err)
+	}
+	return awsConfig, nil
+}
+
+func (p IAMRolesAnywhereProvider) LoadOptions(node *api.NodeConfig) []func(*config.LoadOptions) error {
+	return []func(*config.LoadOptions) error{
+		config.WithRegion(node.Spec.Cluster.Region),
+		config.WithSharedConfigFiles([]string{p.AWSConfigPath(node)}),
+		config.WithSharedConfigProfile(iamrolesanywhere.ProfileName),
+	}
+}
+
+func (IAMRolesAnywhereProvider) AWSConfigPath(node *api.NodeConfig) string {
+	if node.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath == "" {
+		return iamrolesanywhere.DefaultAWSConfigPath
+	}
+	return node.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath
+}
+
+func (IAMRolesAnywhereProvider) RenderConfig(node *api.NodeConfig) ([]util.RenderedFile, error) {
+	awsConfig, err := iamrolesanywhere.RenderAWSConfig(rolesAnywhereAWSConfig(node))
+	if err != nil {
+		return nil, err
+	}
+	return []util.RenderedFile{awsConfig}, nil
+}
+
+// Daemons returns the aws_signing_helper_update daemon when the credentials file is enabled.
+func (IAMRolesAnywhereProvider) Daemons(manager daemon.DaemonManager, node *api.NodeConfig) []daemon.Daemon {
+	if !node.Spec.Hybrid.EnableCredentialsFile {
+		return nil
+	}
+	return []daemon.Daemon{iamrolesanywhere.NewSigningHelperDaemon(manager, node)}
+}
+
+func (IAMRolesAnywhereProvider) Validations(config aws.Config, _ *api.NodeConfig) []validation.Validation[*api.NodeConfig] {
+	return []validation.Validation[*api.NodeConfig]{
+		validation.New("iam-ra-api-network", iamrolesanywhere.NewAccessValidator(config).Run),
+	}
+}
+
+// writeResolvedPrivateKey resolves a private key given as a secret reference and writes
+// it, readable only by root, where the signing helper reads it from.
+func writeResolvedPrivateKey(ctx context.Context, node *api.NodeConfig) error {
+	ref := node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath
+	if !secrets.IsReference(ref) {
+		return nil
+	}
+	privateKey, err := secrets.NewResolver(node.Spec.Cluster.Region).Resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if err := host.FromContext(ctx).WriteFile(iamrolesanywhere.ResolvedPrivateKeyPath, strings.NewReader(privateKey), 0o600); err != nil {
+		return fmt.Errorf("writing IAM Roles Anywhere private key: %w", err)
+	}
+	return nil
+}
+
+func rolesAnywhereAWSConfig(nodeConfig *api.NodeConfig) iamrolesanywhere.AWSConfig {
+	return iamrolesanywhere.AWSConfig{
+		TrustAnchorARN:       nodeConfig.Spec.Hybrid.IAMRolesAnywhere.TrustAnchorARN,
+		ProfileARN:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.ProfileARN,
+		RoleARN:              nodeConfig.Spec.Hybrid.IAMRolesAnywhere.RoleARN,
+		Region:               nodeConfig.Spec.Cluster.Region,
+		NodeName:             nodeConfig.Status.Hybrid.NodeName,
+		ConfigPath:           nodeConfig.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath,
+		SigningHelperBinPath: iamrolesanywhere.SigningHelperBinPath,
+		CertificatePath:      nodeConfig.Spec.Hybrid.IAMRolesAnywhere.CertificatePath,
+		PrivateKeyPath:       iamrolesanywhere.PrivateKeyFile(nodeConfig.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath),
+	}
+}
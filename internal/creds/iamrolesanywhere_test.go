@@ -0,0 +1,185 @@
+package creds_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+)
+
+func TestIAMRolesAnywhereProvider_ConfigureAWS(t *testing.T) {
+	testCases := []struct {
+		name    string
+		node    *api.NodeConfig
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			node: &api.NodeConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+					},
+					Hybrid: &api.HybridOptions{
+						IAMRolesAnywhere: &api.IAMRolesAnywhere{
+							NodeName:        "my-node",
+							TrustAnchorARN:  "trust-anchor-arn",
+							ProfileARN:      "profile-arn",
+							RoleARN:         "role-arn",
+							CertificatePath: "node.crt",
+							PrivateKeyPath:  "node.key",
+						},
+					},
+				},
+				Status: api.NodeConfigStatus{
+					Hybrid: api.HybridDetails{
+						NodeName: "my-node",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid node config",
+			node: &api.NodeConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-node",
+				},
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Region: "us-west-2",
+					},
+					Hybrid: &api.HybridOptions{
+						IAMRolesAnywhere: &api.IAMRolesAnywhere{
+							NodeName:        "my-node",
+							TrustAnchorARN:  "trust-anchor-arn",
+							ProfileARN:      "profile-arn",
+							RoleARN:         "role-arn",
+							CertificatePath: "node.crt",
+							PrivateKeyPath:  "node.key",
+						},
+					},
+				},
+			},
+			wantErr: "NodeName cannot be empty",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "aws-config.yaml")
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			p := creds.IAMRolesAnywhereProvider{}
+			tc.node.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath = configFile
+
+			_, err := p.ConfigureAWS(ctx, creds.ConfigureOptions{NodeConfig: tc.node})
+
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				g.Expect(configFile).NotTo(BeAnExistingFile())
+			} else {
+				g.Expect(err).To(Succeed())
+				g.Expect(configFile).To(BeAnExistingFile())
+			}
+		})
+	}
+}
+
+func TestIAMRolesAnywhereProvider_ConfigureAWSPrivateKeyReference(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("NODE_PRIVATE_KEY", "private-key")
+	dryRun := host.NewDryRun()
+	ctx := host.NewContext(context.Background(), dryRun)
+	node := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Region: "us-west-2",
+			},
+			Hybrid: &api.HybridOptions{
+				IAMRolesAnywhere: &api.IAMRolesAnywhere{
+					AwsConfigPath:   filepath.Join(t.TempDir(), "aws-config.yaml"),
+					NodeName:        "my-node",
+					TrustAnchorARN:  "trust-anchor-arn",
+					ProfileARN:      "profile-arn",
+					RoleARN:         "role-arn",
+					CertificatePath: "node.crt",
+					PrivateKeyPath:  "env:NODE_PRIVATE_KEY",
+				},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Hybrid: api.HybridDetails{
+				NodeName: "my-node",
+			},
+		},
+	}
+
+	_, err := creds.IAMRolesAnywhereProvider{}.ConfigureAWS(ctx, creds.ConfigureOptions{NodeConfig: node})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dryRun.Actions()).To(ContainElement(host.Action{
+		Kind:   host.ActionWriteFile,
+		Target: iamrolesanywhere.ResolvedPrivateKeyPath,
+		Detail: "-rw-------, 11 bytes",
+	}))
+	g.Expect(node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath).To(Equal("env:NODE_PRIVATE_KEY"))
+}
+
+func TestIAMRolesAnywhereProvider_LoadOptions(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "aws-config.yaml")
+	g := NewWithT(t)
+	ctx := context.Background()
+	node := &api.NodeConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-node",
+		},
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Region: "us-west-2",
+			},
+			Hybrid: &api.HybridOptions{
+				IAMRolesAnywhere: &api.IAMRolesAnywhere{
+					AwsConfigPath:   configFile,
+					NodeName:        "my-node",
+					TrustAnchorARN:  "trust-anchor-arn",
+					ProfileARN:      "profile-arn",
+					RoleARN:         "role-arn",
+					CertificatePath: "node.crt",
+					PrivateKeyPath:  "node.key",
+				},
+			},
+		},
+		Status: api.NodeConfigStatus{
+			Hybrid: api.HybridDetails{
+				NodeName: "my-node",
+			},
+		},
+	}
+
+	p := creds.IAMRolesAnywhereProvider{}
+	g.Expect(iamrolesanywhere.WriteAWSConfig(ctx, iamrolesanywhere.AWSConfig{
+		TrustAnchorARN:       "trust-anchor-arn",
+		ProfileARN:           "profile-arn",
+		RoleARN:              "role-arn",
+		Region:               "us-west-2",
+		NodeName:             "my-node",
+		ConfigPath:           configFile,
+		SigningHelperBinPath: iamrolesanywhere.SigningHelperBinPath,
+		CertificatePath:      "node.crt",
+		PrivateKeyPath:       "node.key",
+	})).To(Succeed())
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, p.LoadOptions(node)...)
+	g.Expect(err).To(Succeed())
+	g.Expect(awsConfig.Region).To(Equal("us-west-2"))
+}
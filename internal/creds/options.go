@@ -0,0 +1,37 @@
+package creds
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/aws"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+// InstallOptions are the inputs to Provider Install and Upgrade.
+type InstallOptions struct {
+	Tracker *tracker.Tracker
+	// Source provides the artifacts released with EKS.
+	Source aws.Source
+	// Region is the AWS region used to download regional artifacts.
+	Region string
+	Logger *zap.Logger
+}
+
+// UninstallOptions are the inputs to Provider Uninstall.
+type UninstallOptions struct {
+	DaemonManager  daemon.DaemonManager
+	PackageManager ssm.PkgSource
+	Logger         *zap.Logger
+	// AWSConfigPaths are the AWS config files init wrote, as recorded in the tracker.
+	AWSConfigPaths []string
+}
+
+// ConfigureOptions are the inputs to Provider ConfigureAWS.
+type ConfigureOptions struct {
+	NodeConfig    *api.NodeConfig
+	DaemonManager daemon.DaemonManager
+	Logger        *zap.Logger
+}
@@ -0,0 +1,126 @@
+package creds
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// Provider is a mechanism hybrid nodes use to get AWS credentials. Each provider is
+// registered with Register and selected by name on install, by node config on init
+// and by installed artifacts on upgrade and uninstall.
+type Provider interface {
+	// Name is the value used to select the provider with --credential-provider.
+	Name() CredentialProvider
+	// IsConfigured returns true if node is configured to use this provider.
+	IsConfigured(node *api.NodeConfig) bool
+	// IsInstalled returns true if artifacts show this provider was installed.
+	IsInstalled(artifacts *tracker.InstalledArtifacts) bool
+	// ValidateOS returns an error if the provider doesn't support the OS.
+	ValidateOS(osName, osVersion string) error
+
+	// Install installs the artifacts the provider needs and records them in the tracker.
+	Install(ctx context.Context, opts InstallOptions) error
+	// Upgrade replaces the artifacts installed by Install.
+	Upgrade(ctx context.Context, opts InstallOptions) error
+	// BackupPaths returns the files Upgrade can replace.
+	BackupPaths() []string
+	// Uninstall stops the provider daemons and removes everything Install and ConfigureAWS created.
+	Uninstall(ctx context.Context, opts UninstallOptions) error
+
+	// PopulateDefaults sets the default values of the provider configuration.
+	PopulateDefaults(node *api.NodeConfig)
+	// ValidateConfig validates the provider configuration.
+	ValidateConfig(node *api.NodeConfig) error
+	// ConfigureAWS sets up the host so the node can get AWS credentials and returns
+	// an AWS config that uses them.
+	ConfigureAWS(ctx context.Context, opts ConfigureOptions) (aws.Config, error)
+	// LoadOptions returns the options to load the AWS config once the host has been configured.
+	LoadOptions(node *api.NodeConfig) []func(*config.LoadOptions) error
+	// AWSConfigPath returns the AWS config file the kubelet credential plugins need to get
+	// credentials through the provider. It's empty when the default credential chain works.
+	AWSConfigPath(node *api.NodeConfig) string
+	// RenderConfig returns the files ConfigureAWS writes, without writing them.
+	RenderConfig(node *api.NodeConfig) ([]util.RenderedFile, error)
+	// Daemons returns the daemons that need to run before the node daemons are configured.
+	Daemons(manager daemon.DaemonManager, node *api.NodeConfig) []daemon.Daemon
+	// Validations returns the checks that the provider can get credentials on this host.
+	Validations(config aws.Config, node *api.NodeConfig) []validation.Validation[*api.NodeConfig]
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   []Provider
+)
+
+// Register makes a provider available to nodeadm. It panics if a provider with
+// the same name is already registered.
+func Register(p Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	for _, registered := range providers {
+		if registered.Name() == p.Name() {
+			panic(fmt.Sprintf("credential provider %s is already registered", p.Name()))
+		}
+	}
+	providers = append(providers, p)
+}
+
+// Providers returns every registered provider in the order they were registered.
+func Providers() []Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return slices.Clone(providers)
+}
+
+// Get returns the provider registered with name.
+func Get(name CredentialProvider) (Provider, error) {
+	for _, p := range Providers() {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid credential process provided. Valid options are %s", validNames())
+}
+
+// ForNodeConfig returns the provider node is configured to use.
+func ForNodeConfig(node *api.NodeConfig) (Provider, error) {
+	for _, p := range Providers() {
+		if p.IsConfigured(node) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no credential process provided in nodeConfig")
+}
+
+// ForInstalledArtifacts returns the provider installed according to artifacts.
+func ForInstalledArtifacts(artifacts *tracker.InstalledArtifacts) (Provider, error) {
+	for _, p := range Providers() {
+		if p.IsInstalled(artifacts) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no credential process found in installed artifacts")
+}
+
+func validNames() string {
+	var names []string
+	for _, p := range Providers() {
+		names = append(names, string(p.Name()))
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
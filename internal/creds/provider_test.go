@@ -0,0 +1,108 @@
+package creds_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider creds.CredentialProvider
+		wantErr  string
+	}{
+		{
+			name:     "ssm",
+			provider: creds.SsmCredentialProvider,
+		},
+		{
+			name:     "iam-ra",
+			provider: creds.IamRolesAnywhereCredentialProvider,
+		},
+		{
+			name:     "credential-process",
+			provider: creds.CredentialProcessCredentialProvider,
+		},
+		{
+			name:     "unknown",
+			provider: "kerberos",
+			wantErr:  "invalid credential process provided. Valid options are ssm, iam-ra and credential-process",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, err := creds.Get(tc.provider)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(p.Name()).To(Equal(tc.provider))
+		})
+	}
+}
+
+func TestForNodeConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		hybrid  *api.HybridOptions
+		want    creds.CredentialProvider
+		wantErr string
+	}{
+		{
+			name:   "ssm",
+			hybrid: &api.HybridOptions{SSM: &api.SSM{}},
+			want:   creds.SsmCredentialProvider,
+		},
+		{
+			name:   "iam-ra",
+			hybrid: &api.HybridOptions{IAMRolesAnywhere: &api.IAMRolesAnywhere{}},
+			want:   creds.IamRolesAnywhereCredentialProvider,
+		},
+		{
+			name:   "credential-process",
+			hybrid: &api.HybridOptions{CredentialProcess: &api.CredentialProcess{}},
+			want:   creds.CredentialProcessCredentialProvider,
+		},
+		{
+			name:    "none",
+			hybrid:  &api.HybridOptions{},
+			wantErr: "no credential process provided in nodeConfig",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			node := &api.NodeConfig{Spec: api.NodeConfigSpec{Hybrid: tc.hybrid}}
+			p, err := creds.ForNodeConfig(node)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(tc.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(p.Name()).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestForInstalledArtifacts(t *testing.T) {
+	g := NewWithT(t)
+
+	p, err := creds.ForInstalledArtifacts(&tracker.InstalledArtifacts{CredentialProcess: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Name()).To(Equal(creds.CredentialProcessCredentialProvider))
+
+	_, err = creds.ForInstalledArtifacts(&tracker.InstalledArtifacts{})
+	g.Expect(err).To(MatchError("no credential process found in installed artifacts"))
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(func() { creds.Register(creds.SSMProvider{}) }).To(PanicWith("credential provider ssm is already registered"))
+}
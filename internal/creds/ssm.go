@@ -0,0 +1,160 @@
+package creds
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awsSsm "github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/tracker"
+	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// SSMProvider gets credentials from the SSM agent after registering the node as a
+// hybrid managed instance.
+type SSMProvider struct{}
+
+var _ Provider = SSMProvider{}
+
+func (SSMProvider) Name() CredentialProvider {
+	return SsmCredentialProvider
+}
+
+func (SSMProvider) IsConfigured(node *api.NodeConfig) bool {
+	return node.IsSSM()
+}
+
+func (SSMProvider) IsInstalled(artifacts *tracker.InstalledArtifacts) bool {
+	return artifacts.Ssm
+}
+
+func (SSMProvider) ValidateOS(_, _ string) error {
+	return nil
+}
+
+func (SSMProvider) Install(ctx context.Context, opts InstallOptions) error {
+	opts.Logger.Info("Installing SSM agent installer...")
+	return ssm.Install(ctx, ssm.InstallOptions{
+		Tracker: opts.Tracker,
+		Source:  ssm.NewSSMInstaller(opts.Logger, opts.Region),
+		Logger:  opts.Logger,
+		Region:  opts.Region,
+	})
+}
+
+func (SSMProvider) Upgrade(ctx context.Context, opts InstallOptions) error {
+	opts.Logger.Info("Upgrading SSM agent installer...")
+	return ssm.Upgrade(ctx, ssm.InstallOptions{
+		Source: ssm.NewSSMInstaller(opts.Logger, opts.Region),
+		Logger: opts.Logger,
+		Region: opts.Region,
+	})
+}
+
+// BackupPaths returns nil, the SSM agent is upgraded by its own installer.
+func (SSMProvider) BackupPaths() []string {
+	return nil
+}
+
+func (SSMProvider) Uninstall(ctx context.Context, opts UninstallOptions) error {
+	opts.Logger.Info("Stopping SSM daemon...")
+	if err := opts.DaemonManager.StopDaemon(ssm.SsmDaemonName); err != nil {
+		return err
+	}
+
+	ssmRegistration := ssm.NewSSMRegistration()
+	region := ssmRegistration.GetRegion()
+	var loadOpts []func(*config.LoadOptions) error
+	if region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(region))
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return err
+	}
+
+	if err := ssm.Uninstall(ctx, ssm.UninstallOptions{
+		Logger:          opts.Logger,
+		SSMRegistration: ssmRegistration,
+		PkgSource:       opts.PackageManager,
+		SSMClient:       awsSsm.NewFromConfig(awsConfig),
+	}); err != nil {
+		return fmt.Errorf("uninstalling SSM: %w", err)
+	}
+	return nil
+}
+
+func (SSMProvider) PopulateDefaults(_ *api.NodeConfig) {}
+
+func (SSMProvider) ValidateConfig(node *api.NodeConfig) error {
+	if node.Spec.Hybrid.SSM.ActivationCode == "" {
+		return fmt.Errorf("ActivationCode is missing in hybrid ssm configuration")
+	}
+	if node.Spec.Hybrid.SSM.ActivationID == "" {
+		return fmt.Errorf("ActivationID is missing in hybrid ssm configuration")
+	}
+	return nil
+}
+
+// ConfigureAWS registers the node with SSM, starts the agent and waits for it to write the credentials.
+func (p SSMProvider) ConfigureAWS(ctx context.Context, opts ConfigureOptions) (aws.Config, error) {
+	ssmDaemon := ssm.NewSsmDaemon(opts.DaemonManager, opts.NodeConfig, opts.Logger)
+	if err := ssmDaemon.Configure(ctx); err != nil {
+		return aws.Config{}, err
+	}
+	if err := ssmDaemon.EnsureRunning(ctx); err != nil {
+		return aws.Config{}, err
+	}
+	if err := ssmDaemon.PostLaunch(ctx); err != nil {
+		return aws.Config{}, err
+	}
+
+	if host.IsDryRun(ctx) {
+		// The SSM agent only writes credentials once the machine is registered,
+		// which doesn't happen in a dry run, so read whatever is already available in
+		// the host. Reading doesn't change the host, and there is no config to render.
+		return ReadConfig(ctx, opts.NodeConfig)
+	}
+
+	configCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	opts.Logger.Info("Waiting for AWS config to be available")
+	awsConfig, err := ssm.WaitForAWSConfig(configCtx, opts.NodeConfig, 2*time.Second)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("reading aws config for SSM: %w", err)
+	}
+	return awsConfig, nil
+}
+
+func (SSMProvider) LoadOptions(node *api.NodeConfig) []func(*config.LoadOptions) error {
+	return []func(*config.LoadOptions) error{config.WithRegion(node.Spec.Cluster.Region)}
+}
+
+// AWSConfigPath returns an empty path, the SSM agent writes the credentials to the default shared credentials file.
+func (SSMProvider) AWSConfigPath(_ *api.NodeConfig) string {
+	return ""
+}
+
+func (SSMProvider) RenderConfig(_ *api.NodeConfig) ([]util.RenderedFile, error) {
+	return nil, nil
+}
+
+func (SSMProvider) Daemons(_ daemon.DaemonManager, _ *api.NodeConfig) []daemon.Daemon {
+	return nil
+}
+
+func (SSMProvider) Validations(config aws.Config, _ *api.NodeConfig) []validation.Validation[*api.NodeConfig] {
+	return []validation.Validation[*api.NodeConfig]{
+		validation.New("ssm-api-network", ssm.NewAccessValidator(config).Run),
+	}
+}
@@ -4,7 +4,7 @@ import "context"
 
 type Daemon interface {
 	// Configure configures the daemon.
-	Configure() error
+	Configure(ctx context.Context) error
 
 	// EnsureRunning ensures that the daemon is running.
 	// If the daemon is not running, it will be started.
@@ -13,7 +13,7 @@ type Daemon interface {
 
 	// PostLaunch runs any additional step that needs to occur after the service
 	// daemon as been started
-	PostLaunch() error
+	PostLaunch(ctx context.Context) error
 
 	// Stop stops the daemon
 	// If the daemon is already stopped, this will be a no-op
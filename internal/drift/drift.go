@@ -0,0 +1,112 @@
+package drift
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// Renderer is implemented by the components that write configuration files to
+// the host, so the files can be produced without side effects.
+type Renderer interface {
+	RenderConfig() ([]util.RenderedFile, error)
+}
+
+// FileDrift is the difference between a rendered file and the file on disk.
+type FileDrift struct {
+	Path string
+	// Missing is true when the file doesn't exist on disk.
+	Missing bool
+	// Diff is a unified diff from the file on disk to the rendered file.
+	Diff string
+	// OnDiskPerms and RenderedPerms are only different when the permissions drifted.
+	OnDiskPerms   fs.FileMode
+	RenderedPerms fs.FileMode
+}
+
+// Compare returns the drift of every rendered file that doesn't match the file on disk.
+func Compare(files []util.RenderedFile) ([]FileDrift, error) {
+	var drifts []FileDrift
+	for _, file := range files {
+		drift, err := compareFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
+		}
+	}
+	return drifts, nil
+}
+
+func compareFile(file util.RenderedFile) (*FileDrift, error) {
+	onDisk, err := os.ReadFile(file.Path)
+	if os.IsNotExist(err) {
+		diff, err := unifiedDiff(file.Path, nil, file.Content)
+		if err != nil {
+			return nil, err
+		}
+		return &FileDrift{Path: file.Path, Missing: true, Diff: diff, RenderedPerms: file.Perms}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", file.Path, err)
+	}
+
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := unifiedDiff(file.Path, onDisk, file.Content)
+	if err != nil {
+		return nil, err
+	}
+	if diff == "" && info.Mode().Perm() == file.Perms.Perm() {
+		return nil, nil
+	}
+	return &FileDrift{
+		Path:          file.Path,
+		Diff:          diff,
+		OnDiskPerms:   info.Mode().Perm(),
+		RenderedPerms: file.Perms.Perm(),
+	}, nil
+}
+
+func unifiedDiff(path string, onDisk, rendered []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(onDisk)),
+		B:        difflib.SplitLines(string(rendered)),
+		FromFile: path,
+		ToFile:   path + " (rendered)",
+		Context:  3,
+	})
+}
+
+// Print writes the drift of every file to w as a unified diff.
+func Print(w io.Writer, drifts []FileDrift) error {
+	for _, drift := range drifts {
+		if drift.Missing {
+			if _, err := fmt.Fprintf(w, "%s does not exist\n", drift.Path); err != nil {
+				return err
+			}
+		} else if drift.OnDiskPerms != drift.RenderedPerms {
+			if _, err := fmt.Fprintf(w, "%s has mode %s, expected %s\n", drift.Path, drift.OnDiskPerms, drift.RenderedPerms); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, drift.Diff); err != nil {
+			return err
+		}
+		if drift.Diff != "" && !strings.HasSuffix(drift.Diff, "\n") {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package drift_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/drift"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+func TestCompare(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	upToDate := filepath.Join(dir, "up-to-date.conf")
+	changed := filepath.Join(dir, "changed.conf")
+	wrongMode := filepath.Join(dir, "wrong-mode.conf")
+	missing := filepath.Join(dir, "missing.conf")
+	g.Expect(os.WriteFile(upToDate, []byte("a\nb\n"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(changed, []byte("a\nold\nc\n"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(wrongMode, []byte("a\n"), 0o600)).To(Succeed())
+
+	drifts, err := drift.Compare([]util.RenderedFile{
+		{Path: upToDate, Content: []byte("a\nb\n"), Perms: 0o644},
+		{Path: changed, Content: []byte("a\nnew\nc\n"), Perms: 0o644},
+		{Path: wrongMode, Content: []byte("a\n"), Perms: 0o644},
+		{Path: missing, Content: []byte("a\n"), Perms: 0o644},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(drifts).To(HaveLen(3))
+
+	g.Expect(drifts[0].Path).To(Equal(changed))
+	g.Expect(drifts[0].Diff).To(ContainSubstring("-old\n+new\n"))
+
+	g.Expect(drifts[1].Path).To(Equal(wrongMode))
+	g.Expect(drifts[1].Diff).To(BeEmpty())
+	g.Expect(drifts[1].OnDiskPerms).To(BeEquivalentTo(0o600))
+
+	g.Expect(drifts[2].Path).To(Equal(missing))
+	g.Expect(drifts[2].Missing).To(BeTrue())
+	g.Expect(drifts[2].Diff).To(ContainSubstring("+a\n"))
+
+	var buf bytes.Buffer
+	g.Expect(drift.Print(&buf, drifts)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring("--- " + changed))
+	g.Expect(buf.String()).To(ContainSubstring(wrongMode + " has mode -rw-------, expected -rw-r--r--"))
+	g.Expect(buf.String()).To(ContainSubstring(missing + " does not exist"))
+}
@@ -1,9 +1,12 @@
 package firewall
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"regexp"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 const (
@@ -29,6 +32,10 @@ func NewFirewalld() Manager {
 	}
 }
 
+func (fd *firewalld) Name() string {
+	return FirewalldBackend
+}
+
 // IsEnabled returns true if firewalld is enabled and running on the node
 func (fd *firewalld) IsEnabled() (bool, error) {
 	// Check if firewalld is installed
@@ -51,44 +58,56 @@ func (fd *firewalld) IsEnabled() (bool, error) {
 	return false, nil
 }
 
-// AllowTcpPort adds a rule to the firewall to open input port
-func (fd *firewalld) AllowTcpPort(port string) error {
-	portAddCmd := exec.Command(fd.binPath, "--permanent", fmt.Sprintf("--add-port=%s/tcp", port))
-	out, err := portAddCmd.CombinedOutput()
+// AllowRule adds a rule to the firewall to allow inbound traffic
+func (fd *firewalld) AllowRule(ctx context.Context, r Rule) error {
+	portAddCmd := exec.Command(fd.binPath, "--permanent", "--add-port="+r.String())
+	out, err := host.FromContext(ctx).Run(portAddCmd)
 	if err != nil {
-		return fmt.Errorf("failed to allow port %s in firewall: %s, error: %v", port, out, err)
+		return fmt.Errorf("failed to allow %s in firewall: %s, error: %v", r, out, err)
 	}
 	return nil
 }
 
-// AllowTcpPortRange adds a rule to the firewall to open the range of input port
-func (fd *firewalld) AllowTcpPortRange(startPort, endPort string) error {
-	portAddCmd := exec.Command(fd.binPath, "--permanent", fmt.Sprintf("--add-port=%s-%s/tcp", startPort, endPort))
-	out, err := portAddCmd.CombinedOutput()
+// RemoveRule removes a rule added with AllowRule. The change is applied when the rules are flushed.
+func (fd *firewalld) RemoveRule(ctx context.Context, r Rule) error {
+	if fd.binPath == "" {
+		return fmt.Errorf("%s not installed: %w", firewalldBinary, ErrNotFound)
+	}
+	portRemoveCmd := exec.Command(fd.binPath, "--permanent", "--remove-port="+r.String())
+	out, err := host.FromContext(ctx).Run(portRemoveCmd)
+	if isNotRunning(err) {
+		return fmt.Errorf("firewalld not running: %w", ErrNotFound)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to allow ports in firewall: %s, error: %v", out, err)
+		return fmt.Errorf("failed to remove %s from firewall: %s, error: %v", r, out, err)
 	}
 	return nil
 }
 
 // FlushRules flushes the rules and reloads the firewall to enforce the rules
-func (fd *firewalld) FlushRules() error {
+func (fd *firewalld) FlushRules(ctx context.Context) error {
+	if fd.binPath == "" {
+		return fmt.Errorf("%s not installed: %w", firewalldBinary, ErrNotFound)
+	}
 	reloadCmd := exec.Command(fd.binPath, "--reload")
-	out, err := reloadCmd.CombinedOutput()
+	out, err := host.FromContext(ctx).Run(reloadCmd)
+	if isNotRunning(err) {
+		return fmt.Errorf("firewalld not running: %w", ErrNotFound)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to reload firewall: %s, error: %v", out, err)
 	}
 
 	persistCmd := exec.Command(fd.binPath, "--runtime-to-permanent")
-	out, err = persistCmd.CombinedOutput()
+	out, err = host.FromContext(ctx).Run(persistCmd)
 	if err != nil {
 		return fmt.Errorf("failed to persist firewall rules: %s, error: %v", out, err)
 	}
 	return nil
 }
 
-func (fd *firewalld) IsPortOpen(port, protocol string) (bool, error) {
-	queryCmd := exec.Command(fd.binPath, fmt.Sprintf("--query-port=%s/%s", port, protocol))
+func (fd *firewalld) IsAllowed(r Rule) (bool, error) {
+	queryCmd := exec.Command(fd.binPath, "--query-port="+r.String())
 	out, err := queryCmd.CombinedOutput()
 	if err != nil {
 		// firewall-cmd returns an error if the port is not open
@@ -99,3 +118,9 @@ func (fd *firewalld) IsPortOpen(port, protocol string) (bool, error) {
 	}
 	return false, fmt.Errorf("unsupported firewall port status")
 }
+
+// isNotRunning returns true if firewall-cmd failed because firewalld is not running.
+func isNotRunning(err error) bool {
+	exitError, ok := err.(*exec.ExitError)
+	return ok && exitError.ExitCode() == notRunningExitCode
+}
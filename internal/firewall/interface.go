@@ -1,19 +1,56 @@
 package firewall
 
-// Manager is an interface for providing firewall functionalities
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	UfwBackend       = "ufw"
+	FirewalldBackend = "firewalld"
+	NftablesBackend  = "nftables"
+	IptablesBackend  = "iptables"
+)
+
+// ErrNotFound is returned when removing rules from a backend that is not installed or
+// running anymore, or whose chain is gone, so the rules are gone too.
+var ErrNotFound = errors.New("firewall not found")
+
+// Manager is an interface for providing firewall functionalities.
+// Rules are changed with the Host in the context.
 type Manager interface {
+	// Name returns the name of the firewall backend
+	Name() string
+
 	// IsEnabled returns if firewall is enabled
 	IsEnabled() (bool, error)
 
-	// AllowTcpPort adds a rule to open a port on the host
-	AllowTcpPort(string) error
+	// AllowRule adds a rule to allow inbound traffic on the host
+	AllowRule(context.Context, Rule) error
 
-	// AllowTcpPortRange adds a rule to open a range of port on the host
-	AllowTcpPortRange(string, string) error
+	// RemoveRule removes a rule previously added with AllowRule
+	RemoveRule(context.Context, Rule) error
 
 	// FlushRules writes newly added rules to disk and reloads the firewall
-	FlushRules() error
+	FlushRules(context.Context) error
+
+	// IsAllowed returns true if firewall allows the inbound traffic matched by the rule
+	IsAllowed(Rule) (bool, error)
+}
 
-	// IsPortOpen return true if firewall allows traffic on input port
-	IsPortOpen(string, string) (bool, error)
+// New returns the Manager for the named backend.
+func New(backend string) (Manager, error) {
+	switch backend {
+	case UfwBackend:
+		return NewUncomplicatedFirewall(), nil
+	case FirewalldBackend:
+		return NewFirewalld(), nil
+	case NftablesBackend:
+		return NewNftables(), nil
+	case IptablesBackend:
+		return NewIptables(), nil
+	default:
+		return nil, fmt.Errorf("unsupported firewall backend %q", backend)
+	}
 }
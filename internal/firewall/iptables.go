@@ -0,0 +1,203 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+const (
+	iptablesBinary     = "iptables"
+	ip6tablesBinary    = "ip6tables"
+	iptablesInputChain = "INPUT"
+)
+
+var (
+	iptablesFilterRegex  = regexp.MustCompile(`^(-P INPUT (DROP|REJECT)|-A INPUT .*-j (DROP|REJECT)\b)`)
+	iptablesNodeadmRegex = regexp.MustCompile(`^-A INPUT -p (tcp|udp) -m (?:tcp|udp) --dport (\d+)(?::(\d+))? -m comment --comment "?` + comment + `"? -j ACCEPT$`)
+
+	iptablesRulesService = rulesService{backend: IptablesBackend}
+)
+
+// iptables inserts rules at the top of the INPUT chain of the filter table, with both
+// iptables and ip6tables so IPv6 nodes are reachable too. Rules added by nodeadm are
+// marked with a comment, so only those are removed. They are restored at boot by a
+// nodeadm unit, as iptables only applies them in memory.
+type iptables struct {
+	binPaths []string
+	// added are the rules allowed by this manager, keyed by binary path.
+	added   map[string][]Rule
+	removed []Rule
+}
+
+func NewIptables() Manager {
+	var paths []string
+	for _, binary := range []string{iptablesBinary, ip6tablesBinary} {
+		if path, err := exec.LookPath(binary); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return &iptables{
+		binPaths: paths,
+		added:    map[string][]Rule{},
+	}
+}
+
+func (ipt *iptables) Name() string {
+	return IptablesBackend
+}
+
+// IsEnabled returns true if the INPUT chain of iptables or ip6tables drops or rejects traffic
+func (ipt *iptables) IsEnabled() (bool, error) {
+	filtering, err := ipt.filtering()
+	if err != nil {
+		return false, err
+	}
+	return len(filtering) > 0, nil
+}
+
+// AllowRule inserts a rule at the top of the INPUT chains that filter traffic and
+// don't accept it yet
+func (ipt *iptables) AllowRule(ctx context.Context, r Rule) error {
+	filtering, err := ipt.filtering()
+	if err != nil {
+		return err
+	}
+	for binPath, rules := range filtering {
+		if iptablesAccepts(rules, r) {
+			continue
+		}
+		args := append([]string{"-I", iptablesInputChain}, iptablesRule(r)...)
+		out, err := host.FromContext(ctx).Run(exec.Command(binPath, args...))
+		if err != nil {
+			return fmt.Errorf("failed to allow %s in %s: %s, error: %v", r, path.Base(binPath), out, err)
+		}
+		ipt.added[binPath] = append(ipt.added[binPath], r)
+	}
+	return nil
+}
+
+// RemoveRule deletes the rule added with AllowRule, if it still exists
+func (ipt *iptables) RemoveRule(ctx context.Context, r Rule) error {
+	ipt.removed = append(ipt.removed, r)
+	if len(ipt.binPaths) == 0 {
+		return fmt.Errorf("%s not installed: %w", iptablesBinary, ErrNotFound)
+	}
+	for _, binPath := range ipt.binPaths {
+		checkArgs := append([]string{"-C", iptablesInputChain}, iptablesRule(r)...)
+		if err := exec.Command(binPath, checkArgs...).Run(); err != nil {
+			// iptables -C exits with an error when the rule doesn't exist
+			continue
+		}
+		args := append([]string{"-D", iptablesInputChain}, iptablesRule(r)...)
+		out, err := host.FromContext(ctx).Run(exec.Command(binPath, args...))
+		if err != nil {
+			return fmt.Errorf("failed to remove %s from %s: %s, error: %v", r, path.Base(binPath), out, err)
+		}
+	}
+	return nil
+}
+
+// FlushRules saves the rules added by nodeadm in a script run at boot by a nodeadm
+// unit, which is removed once there are none left. They are not saved with
+// iptables-save, as the saved rules would include the ones of kube-proxy and the CNI.
+func (ipt *iptables) FlushRules(ctx context.Context) error {
+	var commands []string
+	for _, binPath := range ipt.binPaths {
+		rules, err := listInput(binPath)
+		if err != nil {
+			return err
+		}
+		for _, r := range persistedRules(iptablesNodeadmRules(rules), ipt.added[binPath], ipt.removed) {
+			spec := strings.Join(iptablesRule(r), " ")
+			commands = append(commands, fmt.Sprintf("%s -C %s %s 2>/dev/null || %s -I %s %s",
+				binPath, iptablesInputChain, spec, binPath, iptablesInputChain, spec))
+		}
+	}
+	return iptablesRulesService.save(ctx, commands)
+}
+
+// IsAllowed returns true if every INPUT chain that filters traffic accepts the traffic
+// matched by the rule
+func (ipt *iptables) IsAllowed(r Rule) (bool, error) {
+	filtering, err := ipt.filtering()
+	if err != nil || len(filtering) == 0 {
+		return false, err
+	}
+	for _, rules := range filtering {
+		if !iptablesAccepts(rules, r) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// filtering returns the INPUT chain rules of the binaries whose INPUT chain drops or
+// rejects traffic, keyed by binary path.
+func (ipt *iptables) filtering() (map[string]string, error) {
+	filtering := map[string]string{}
+	for _, binPath := range ipt.binPaths {
+		rules, err := listInput(binPath)
+		if err != nil {
+			return nil, err
+		}
+		if iptablesFilters(rules) {
+			filtering[binPath] = rules
+		}
+	}
+	return filtering, nil
+}
+
+func listInput(binPath string) (string, error) {
+	out, err := exec.Command(binPath, "-S", iptablesInputChain).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to list %s %s chain: %s, error: %v", path.Base(binPath), iptablesInputChain, out, err)
+	}
+	return string(out), nil
+}
+
+// iptablesFilters returns true if the output of iptables -S drops or rejects traffic.
+func iptablesFilters(rules string) bool {
+	for _, line := range strings.Split(rules, "\n") {
+		if iptablesFilterRegex.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// iptablesRule returns the rule specification accepting the traffic of r.
+func iptablesRule(r Rule) []string {
+	return []string{
+		"-p", string(r.Protocol), "-m", string(r.Protocol), "--dport", r.ports(":"),
+		"-m", "comment", "--comment", comment, "-j", "ACCEPT",
+	}
+}
+
+// iptablesNodeadmRules returns the rules added by nodeadm in the output of iptables -S.
+func iptablesNodeadmRules(rules string) []Rule {
+	var nodeadmRules []Rule
+	for _, line := range strings.Split(rules, "\n") {
+		if matches := iptablesNodeadmRegex.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			nodeadmRules = append(nodeadmRules, Rule{Protocol: Protocol(matches[1]), Port: matches[2], EndPort: matches[3]})
+		}
+	}
+	return nodeadmRules
+}
+
+// iptablesAccepts returns true if a rule in the output of iptables -S accepts the traffic of r.
+func iptablesAccepts(rules string, r Rule) bool {
+	ruleRegex := regexp.MustCompile(fmt.Sprintf(`^-A %s -p %s -m %s --dport %s( .*)? -j ACCEPT$`,
+		iptablesInputChain, r.Protocol, r.Protocol, regexp.QuoteMeta(r.ports(":"))))
+	for _, line := range strings.Split(rules, "\n") {
+		if ruleRegex.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package firewall
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const iptablesInput = `-P INPUT DROP
+-A INPUT -p tcp -m tcp --dport 10250 -m comment --comment nodeadm -j ACCEPT
+-A INPUT -p tcp -m tcp --dport 30000:32767 -j ACCEPT
+-A INPUT -p udp -m udp --dport 4789 -j ACCEPT
+-A INPUT -p tcp -m tcp --dport 10256 -j DROP
+-A INPUT -m state --state RELATED,ESTABLISHED -j ACCEPT
+`
+
+func TestIptablesAccepts(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(iptablesAccepts(iptablesInput, TCPPort("10250"))).To(BeTrue())
+	g.Expect(iptablesAccepts(iptablesInput, TCPPortRange("30000", "32767"))).To(BeTrue())
+	g.Expect(iptablesAccepts(iptablesInput, UDPPort("4789"))).To(BeTrue())
+	g.Expect(iptablesAccepts(iptablesInput, TCPPort("4789"))).To(BeFalse())
+	g.Expect(iptablesAccepts(iptablesInput, TCPPort("10256"))).To(BeFalse())
+	g.Expect(iptablesAccepts(iptablesInput, TCPPort("1025"))).To(BeFalse())
+}
+
+func TestIptablesFilterRegex(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(iptablesFilterRegex.MatchString("-P INPUT DROP")).To(BeTrue())
+	g.Expect(iptablesFilterRegex.MatchString("-A INPUT -j REJECT --reject-with icmp-host-prohibited")).To(BeTrue())
+	g.Expect(iptablesFilterRegex.MatchString("-P INPUT ACCEPT")).To(BeFalse())
+	g.Expect(iptablesFilterRegex.MatchString("-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT")).To(BeFalse())
+}
+
+func TestIptablesFilters(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(iptablesFilters(iptablesInput)).To(BeTrue())
+	g.Expect(iptablesFilters("-P INPUT ACCEPT\n-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT\n")).To(BeFalse())
+}
+
+func TestIptablesNodeadmRules(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(iptablesNodeadmRules(iptablesInput)).To(Equal([]Rule{TCPPort("10250")}))
+}
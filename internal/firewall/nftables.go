@@ -0,0 +1,267 @@
+package firewall
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+const nftBinary = "nft"
+
+var (
+	nftTableRegex         = regexp.MustCompile(`^table (\w+) (\S+) {`)
+	nftChainRegex         = regexp.MustCompile(`^chain (\S+) {`)
+	nftInputHookRegex     = regexp.MustCompile(`^type filter hook input\b`)
+	nftDropPolicyRegex    = regexp.MustCompile(`\bpolicy drop;`)
+	nftFilterRuleRegex    = regexp.MustCompile(`(^|\s)(drop|reject)(\s|$)`)
+	nftIptablesOwnedRegex = regexp.MustCompile(`^# Warning: table (\w+) (\S+) is managed by iptables-nft`)
+	nftRuleHandleRegex    = regexp.MustCompile(`# handle (\d+)$`)
+	nftNodeadmRegex       = regexp.MustCompile(`^(tcp|udp) dport (\d+)(?:-(\d+))? (?:counter packets \d+ bytes \d+ )?accept comment "` + comment + `"`)
+
+	nftRulesService = rulesService{backend: NftablesBackend}
+)
+
+// nftables adds rules to the first filter chain hooked to input that drops or rejects
+// traffic. Rules added by nodeadm are marked with a comment, so only those are removed.
+// Tables created by iptables-nft are left to the iptables manager. The rules are
+// restored at boot by a nodeadm unit, as the nftables configuration of the host is
+// left untouched.
+type nftables struct {
+	binPath string
+	// added and removed are the rules allowed and removed by this manager.
+	added   []Rule
+	removed []Rule
+}
+
+// nftChain is a chain in the nftables ruleset.
+type nftChain struct {
+	family string
+	table  string
+	name   string
+}
+
+func NewNftables() Manager {
+	path, _ := exec.LookPath(nftBinary)
+	return &nftables{
+		binPath: path,
+	}
+}
+
+func (nft *nftables) Name() string {
+	return NftablesBackend
+}
+
+// IsEnabled returns true if nftables has a filter chain on the input hook that drops
+// or rejects traffic, either with its policy or with a rule
+func (nft *nftables) IsEnabled() (bool, error) {
+	if nft.binPath == "" {
+		return false, nil
+	}
+	_, found, err := nft.inputChain()
+	return found, err
+}
+
+// AllowRule inserts a rule at the top of the input chain to accept inbound traffic
+func (nft *nftables) AllowRule(ctx context.Context, r Rule) error {
+	chain, err := nft.requireInputChain()
+	if err != nil {
+		return err
+	}
+	args := append([]string{"insert", "rule", chain.family, chain.table, chain.name}, nftRule(r)...)
+	args = append(args, "comment", fmt.Sprintf("%q", comment))
+	out, err := host.FromContext(ctx).Run(exec.Command(nft.binPath, args...))
+	if err != nil {
+		return fmt.Errorf("failed to allow %s in nftables: %s, error: %v", r, out, err)
+	}
+	nft.added = append(nft.added, r)
+	return nil
+}
+
+// RemoveRule deletes the rules matching r that were added by nodeadm
+func (nft *nftables) RemoveRule(ctx context.Context, r Rule) error {
+	nft.removed = append(nft.removed, r)
+	if nft.binPath == "" {
+		return fmt.Errorf("%s not installed: %w", nftBinary, ErrNotFound)
+	}
+	chain, found, err := nft.inputChain()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no nftables filter chain on the input hook dropping traffic: %w", ErrNotFound)
+	}
+	out, err := exec.Command(nft.binPath, "-a", "list", "chain", chain.family, chain.table, chain.name).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to list nftables chain %s: %s, error: %v", chain.name, out, err)
+	}
+	for _, handle := range nftRuleHandles(string(out), r) {
+		deleteCmd := exec.Command(nft.binPath, "delete", "rule", chain.family, chain.table, chain.name, "handle", handle)
+		if out, err := host.FromContext(ctx).Run(deleteCmd); err != nil {
+			return fmt.Errorf("failed to remove %s from nftables: %s, error: %v", r, out, err)
+		}
+	}
+	return nil
+}
+
+// FlushRules saves the rules added by nodeadm in a script run at boot by a nodeadm
+// unit, which is removed once there are none left. They are not written to the
+// nftables configuration, which also holds the rules of other tools and is managed by
+// the host.
+func (nft *nftables) FlushRules(ctx context.Context) error {
+	var commands []string
+	if nft.binPath == "" {
+		return nftRulesService.save(ctx, nil)
+	}
+	chain, found, err := nft.inputChain()
+	if err != nil {
+		return err
+	}
+	if found {
+		out, err := exec.Command(nft.binPath, "list", "chain", chain.family, chain.table, chain.name).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to list nftables chain %s: %s, error: %v", chain.name, out, err)
+		}
+		target := strings.Join([]string{chain.family, chain.table, chain.name}, " ")
+		for _, r := range persistedRules(nftNodeadmRules(string(out)), nft.added, nft.removed) {
+			rule := strings.Join(nftRule(r), " ")
+			commands = append(commands, fmt.Sprintf(`%s list chain %s 2>/dev/null | grep -qF '%s comment "%s"' || %s insert rule %s %s comment '"%s"'`,
+				nft.binPath, target, rule, comment, nft.binPath, target, rule, comment))
+		}
+	}
+	return nftRulesService.save(ctx, commands)
+}
+
+// IsAllowed returns true if the input chain accepts the traffic matched by the rule
+func (nft *nftables) IsAllowed(r Rule) (bool, error) {
+	chain, found, err := nft.inputChain()
+	if err != nil || !found {
+		return false, err
+	}
+	out, err := exec.Command(nft.binPath, "list", "chain", chain.family, chain.table, chain.name).CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("failed to list nftables chain %s: %s, error: %v", chain.name, out, err)
+	}
+	return nftAccepts(string(out), r), nil
+}
+
+func (nft *nftables) inputChain() (nftChain, bool, error) {
+	out, err := exec.Command(nft.binPath, "list", "ruleset").CombinedOutput()
+	if err != nil {
+		return nftChain{}, false, fmt.Errorf("failed to list nftables ruleset: %s, error: %v", out, err)
+	}
+	chain, found := findInputChain(string(out))
+	return chain, found, nil
+}
+
+func (nft *nftables) requireInputChain() (nftChain, error) {
+	chain, found, err := nft.inputChain()
+	if err != nil {
+		return nftChain{}, err
+	}
+	if !found {
+		return nftChain{}, fmt.Errorf("no nftables filter chain on the input hook dropping traffic")
+	}
+	return chain, nil
+}
+
+// findInputChain returns the first filter chain hooked to input that drops or rejects
+// traffic in the output of nft list ruleset. The chains of tables managed by
+// iptables-nft are skipped.
+func findInputChain(ruleset string) (nftChain, bool) {
+	var chain nftChain
+	var inChain, inputHook, filters bool
+	// nft prints the warning before the table it refers to.
+	iptablesOwned := map[nftChain]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(ruleset))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case nftTableRegex.MatchString(line):
+			matches := nftTableRegex.FindStringSubmatch(line)
+			chain = nftChain{family: matches[1], table: matches[2]}
+			inChain = false
+		case nftIptablesOwnedRegex.MatchString(line):
+			matches := nftIptablesOwnedRegex.FindStringSubmatch(line)
+			iptablesOwned[nftChain{family: matches[1], table: matches[2]}] = true
+		case nftChainRegex.MatchString(line):
+			chain.name = nftChainRegex.FindStringSubmatch(line)[1]
+			inChain, inputHook, filters = true, false, false
+		case !inChain:
+			// Sets and maps don't filter traffic by themselves.
+		case line == "}":
+			owned := iptablesOwned[nftChain{family: chain.family, table: chain.table}] || isIptablesNftChain(chain)
+			if inputHook && filters && !owned {
+				return chain, true
+			}
+			inChain = false
+		case nftInputHookRegex.MatchString(line):
+			inputHook = true
+			filters = nftDropPolicyRegex.MatchString(line)
+		case nftFilterRuleRegex.MatchString(line):
+			filters = true
+		}
+	}
+	return nftChain{}, false
+}
+
+// isIptablesNftChain returns true for the INPUT chain iptables-nft creates, even when
+// nft doesn't print the warning about the table being managed by iptables-nft.
+func isIptablesNftChain(chain nftChain) bool {
+	return (chain.family == "ip" || chain.family == "ip6") && chain.table == "filter" && chain.name == "INPUT"
+}
+
+// nftRule returns the statement matching and accepting the traffic of r.
+func nftRule(r Rule) []string {
+	return []string{string(r.Protocol), "dport", r.ports("-"), "accept"}
+}
+
+// nftRuleRegex matches the rules accepting the traffic of r, as printed by nft list chain.
+func nftRuleRegex(r Rule) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`^%s dport %s (counter packets \d+ bytes \d+ )?accept\b`,
+		r.Protocol, regexp.QuoteMeta(r.ports("-"))))
+}
+
+// nftAccepts returns true if a rule in the chain listing accepts the traffic of r.
+func nftAccepts(listing string, r Rule) bool {
+	ruleRegex := nftRuleRegex(r)
+	for _, line := range strings.Split(listing, "\n") {
+		if ruleRegex.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// nftNodeadmRules returns the rules added by nodeadm in the output of nft list chain.
+func nftNodeadmRules(listing string) []Rule {
+	var rules []Rule
+	for _, line := range strings.Split(listing, "\n") {
+		if matches := nftNodeadmRegex.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			rules = append(rules, Rule{Protocol: Protocol(matches[1]), Port: matches[2], EndPort: matches[3]})
+		}
+	}
+	return rules
+}
+
+// nftRuleHandles returns the handles of the rules accepting the traffic of r that were
+// added by nodeadm, from the output of nft -a list chain.
+func nftRuleHandles(listing string, r Rule) []string {
+	ruleRegex := nftRuleRegex(r)
+	commentMarker := fmt.Sprintf("comment %q", comment)
+	var handles []string
+	for _, line := range strings.Split(listing, "\n") {
+		line = strings.TrimSpace(line)
+		if !ruleRegex.MatchString(line) || !strings.Contains(line, commentMarker) {
+			continue
+		}
+		if matches := nftRuleHandleRegex.FindStringSubmatch(line); matches != nil {
+			handles = append(handles, matches[1])
+		}
+	}
+	return handles
+}
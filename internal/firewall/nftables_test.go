@@ -0,0 +1,123 @@
+package firewall
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const nftRuleset = `table ip nat {
+	chain POSTROUTING {
+		type nat hook postrouting priority srcnat; policy accept;
+	}
+}
+table inet filter {
+	chain forward {
+		type filter hook forward priority filter; policy drop;
+	}
+	chain input {
+		type filter hook input priority filter; policy drop;
+	}
+}
+`
+
+const nftInputChain = `table inet filter {
+	chain input { # handle 1
+		type filter hook input priority filter; policy drop;
+		udp dport 8472 accept comment "nodeadm" # handle 7
+		tcp dport 30000-32767 accept comment "nodeadm" # handle 6
+		tcp dport 10250 counter packets 12 bytes 720 accept # handle 5
+		tcp dport 10256 accept comment "nodeadm" # handle 4
+		ct state established,related accept # handle 2
+		tcp dport 22 accept # handle 3
+	}
+}
+`
+
+func TestFindInputChain(t *testing.T) {
+	g := NewWithT(t)
+	chain, found := findInputChain(nftRuleset)
+	g.Expect(found).To(BeTrue())
+	g.Expect(chain).To(Equal(nftChain{family: "inet", table: "filter", name: "input"}))
+
+	_, found = findInputChain("table ip nat {\n\tchain POSTROUTING {\n\t\ttype nat hook postrouting priority srcnat; policy accept;\n\t}\n}\n")
+	g.Expect(found).To(BeFalse())
+}
+
+func TestFindInputChainAcceptPolicy(t *testing.T) {
+	g := NewWithT(t)
+	_, found := findInputChain(`table inet filter {
+	set allowed {
+		type ipv4_addr
+		elements = { 10.0.0.1 }
+	}
+	chain input {
+		type filter hook input priority filter; policy accept;
+		tcp dport 22 accept
+	}
+}
+`)
+	g.Expect(found).To(BeFalse())
+
+	chain, found := findInputChain(`table inet filter {
+	chain input {
+		type filter hook input priority filter; policy accept;
+		ct state established,related accept
+		tcp dport 22 accept
+		counter packets 0 bytes 0 reject with icmpx type admin-prohibited
+	}
+}
+`)
+	g.Expect(found).To(BeTrue())
+	g.Expect(chain).To(Equal(nftChain{family: "inet", table: "filter", name: "input"}))
+}
+
+func TestFindInputChainSkipsIptablesNft(t *testing.T) {
+	g := NewWithT(t)
+	_, found := findInputChain(`# Warning: table ip filter is managed by iptables-nft, do not touch!
+table ip filter {
+	chain INPUT {
+		type filter hook input priority filter; policy drop;
+		meta l4proto tcp tcp dport 22 counter packets 0 bytes 0 accept
+	}
+}
+table ip6 filter {
+	chain INPUT {
+		type filter hook input priority filter; policy drop;
+	}
+}
+# Warning: table ip security is managed by iptables-nft, do not touch!
+table ip security {
+	chain input {
+		type filter hook input priority 150; policy drop;
+	}
+}
+`)
+	g.Expect(found).To(BeFalse())
+}
+
+func TestNftAccepts(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(nftAccepts(nftInputChain, TCPPort("10250"))).To(BeTrue())
+	g.Expect(nftAccepts(nftInputChain, TCPPortRange("30000", "32767"))).To(BeTrue())
+	g.Expect(nftAccepts(nftInputChain, UDPPort("8472"))).To(BeTrue())
+	g.Expect(nftAccepts(nftInputChain, TCPPort("8472"))).To(BeFalse())
+	g.Expect(nftAccepts(nftInputChain, TCPPort("1025"))).To(BeFalse())
+}
+
+func TestNftRuleHandles(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(nftRuleHandles(nftInputChain, TCPPort("10256"))).To(Equal([]string{"4"}))
+	g.Expect(nftRuleHandles(nftInputChain, TCPPortRange("30000", "32767"))).To(Equal([]string{"6"}))
+	// Rules not added by nodeadm are left alone.
+	g.Expect(nftRuleHandles(nftInputChain, TCPPort("10250"))).To(BeEmpty())
+}
+
+func TestNftNodeadmRules(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(nftNodeadmRules(nftInputChain)).To(Equal([]Rule{
+		UDPPort("8472"),
+		TCPPortRange("30000", "32767"),
+		TCPPort("10256"),
+	}))
+}
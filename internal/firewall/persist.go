@@ -0,0 +1,111 @@
+package firewall
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"os/exec"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/util/file"
+)
+
+const (
+	rulesScriptDir = "/etc/eks/firewall"
+	rulesUnitDir   = "/etc/systemd/system"
+)
+
+var (
+	//go:embed rules_service.tpl
+	rawRulesServiceTemplate string
+
+	rulesServiceTemplate = template.Must(template.New("").Parse(rawRulesServiceTemplate))
+)
+
+// rulesService persists the rules nodeadm added to a backend that applies rules in
+// memory only. A oneshot systemd unit runs a script adding them again at boot, after
+// the host restored its own rules.
+type rulesService struct {
+	backend string
+}
+
+func (s rulesService) unitName() string {
+	return fmt.Sprintf("nodeadm-%s-rules.service", s.backend)
+}
+
+func (s rulesService) unitPath() string {
+	return path.Join(rulesUnitDir, s.unitName())
+}
+
+func (s rulesService) scriptPath() string {
+	return path.Join(rulesScriptDir, s.backend+"-rules.sh")
+}
+
+// save writes the script running commands and enables the unit running it at boot.
+// Without commands, the unit and the script are removed.
+func (s rulesService) save(ctx context.Context, commands []string) error {
+	if len(commands) == 0 {
+		return s.remove(ctx)
+	}
+	script := "#!/bin/sh\n# Generated by nodeadm, restores the firewall rules it added.\n" + strings.Join(commands, "\n") + "\n"
+	var unit bytes.Buffer
+	if err := rulesServiceTemplate.Execute(&unit, map[string]string{
+		"Backend":    s.backend,
+		"ScriptPath": s.scriptPath(),
+	}); err != nil {
+		return fmt.Errorf("executing %s template: %w", s.unitName(), err)
+	}
+
+	h := host.FromContext(ctx)
+	if err := h.WriteFile(s.scriptPath(), strings.NewReader(script), 0o755); err != nil {
+		return fmt.Errorf("writing %s: %w", s.scriptPath(), err)
+	}
+	if err := h.WriteFile(s.unitPath(), &unit, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", s.unitPath(), err)
+	}
+	if out, err := h.Run(exec.Command("systemctl", "daemon-reload")); err != nil {
+		return fmt.Errorf("reloading systemd: %s, error: %v", out, err)
+	}
+	if out, err := h.Run(exec.Command("systemctl", "enable", s.unitName())); err != nil {
+		return fmt.Errorf("enabling %s: %s, error: %v", s.unitName(), out, err)
+	}
+	return nil
+}
+
+func (s rulesService) remove(ctx context.Context) error {
+	if !file.Exists(s.unitPath()) {
+		return nil
+	}
+	h := host.FromContext(ctx)
+	if out, err := h.Run(exec.Command("systemctl", "disable", s.unitName())); err != nil {
+		return fmt.Errorf("disabling %s: %s, error: %v", s.unitName(), out, err)
+	}
+	if err := h.RemoveAll(s.unitPath()); err != nil {
+		return err
+	}
+	if err := h.RemoveAll(s.scriptPath()); err != nil {
+		return err
+	}
+	if out, err := h.Run(exec.Command("systemctl", "daemon-reload")); err != nil {
+		return fmt.Errorf("reloading systemd: %s, error: %v", out, err)
+	}
+	return nil
+}
+
+// persistedRules returns the rules to restore at boot: the ones nodeadm added that are
+// in place, plus the ones added and minus the ones removed by this manager. In a dry
+// run, the changes of the manager are not applied and only the latter reflect them.
+func persistedRules(current, added, removed []Rule) []Rule {
+	var rules []Rule
+	for _, r := range slices.Concat(current, added) {
+		if !slices.Contains(rules, r) && !slices.Contains(removed, r) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,40 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+func TestPersistedRules(t *testing.T) {
+	g := NewWithT(t)
+	current := []Rule{TCPPort("10250"), TCPPort("10256")}
+	added := []Rule{TCPPort("10250"), TCPPortRange("30000", "32767")}
+	removed := []Rule{TCPPort("10256")}
+	g.Expect(persistedRules(current, added, removed)).To(Equal([]Rule{
+		TCPPort("10250"),
+		TCPPortRange("30000", "32767"),
+	}))
+}
+
+func TestRulesServiceSave(t *testing.T) {
+	g := NewWithT(t)
+	dryRun := host.NewDryRun()
+	ctx := host.NewContext(context.Background(), dryRun)
+	service := rulesService{backend: IptablesBackend}
+
+	g.Expect(service.save(ctx, []string{"iptables -I INPUT -j ACCEPT"})).To(Succeed())
+	var targets []string
+	for _, action := range dryRun.Actions() {
+		targets = append(targets, action.Target)
+	}
+	g.Expect(targets).To(Equal([]string{
+		"/etc/eks/firewall/iptables-rules.sh",
+		"/etc/systemd/system/nodeadm-iptables-rules.service",
+		"systemctl daemon-reload",
+		"systemctl enable nodeadm-iptables-rules.service",
+	}))
+}
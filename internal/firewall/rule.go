@@ -0,0 +1,50 @@
+package firewall
+
+import "fmt"
+
+// Protocol is the transport protocol of the traffic matched by a Rule.
+type Protocol string
+
+const (
+	ProtocolTCP Protocol = "tcp"
+	ProtocolUDP Protocol = "udp"
+)
+
+// comment marks the rules added by nodeadm in the backends that support it.
+const comment = "nodeadm"
+
+// Rule allows inbound traffic to a port, or an inclusive range of ports, over a protocol.
+type Rule struct {
+	Protocol Protocol
+	Port     string
+	// EndPort is the last port of a range starting at Port. It's empty for a single port.
+	EndPort string `json:",omitempty"`
+}
+
+// TCPPort returns a rule allowing TCP traffic to port.
+func TCPPort(port string) Rule {
+	return Rule{Protocol: ProtocolTCP, Port: port}
+}
+
+// TCPPortRange returns a rule allowing TCP traffic to the ports from start to end.
+func TCPPortRange(start, end string) Rule {
+	return Rule{Protocol: ProtocolTCP, Port: start, EndPort: end}
+}
+
+// UDPPort returns a rule allowing UDP traffic to port.
+func UDPPort(port string) Rule {
+	return Rule{Protocol: ProtocolUDP, Port: port}
+}
+
+// String returns the rule in the port/protocol notation, like 10250/tcp or 30000-32767/tcp.
+func (r Rule) String() string {
+	return fmt.Sprintf("%s/%s", r.ports("-"), r.Protocol)
+}
+
+// ports returns the port, or the range of ports joined by separator.
+func (r Rule) ports(separator string) string {
+	if r.EndPort == "" {
+		return r.Port
+	}
+	return r.Port + separator + r.EndPort
+}
@@ -0,0 +1,26 @@
+package firewall
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRuleString(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(TCPPort("10250").String()).To(Equal("10250/tcp"))
+	g.Expect(UDPPort("8472").String()).To(Equal("8472/udp"))
+	g.Expect(TCPPortRange("30000", "32767").String()).To(Equal("30000-32767/tcp"))
+	g.Expect(ufwRule(TCPPortRange("30000", "32767"))).To(Equal("30000:32767/tcp"))
+}
+
+func TestNew(t *testing.T) {
+	g := NewWithT(t)
+	for _, backend := range []string{UfwBackend, FirewalldBackend, NftablesBackend, IptablesBackend} {
+		manager, err := New(backend)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(manager.Name()).To(Equal(backend))
+	}
+	_, err := New("pf")
+	g.Expect(err).To(MatchError(`unsupported firewall backend "pf"`))
+}
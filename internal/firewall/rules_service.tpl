@@ -0,0 +1,12 @@
+[Unit]
+Description=Restores the {{ .Backend }} rules added by nodeadm, which {{ .Backend }} doesn't save across reboots.
+After=iptables.service ip6tables.service netfilter-persistent.service nftables.service
+Before=kubelet.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/bin/sh {{ .ScriptPath }}
+
+[Install]
+WantedBy=multi-user.target
@@ -2,10 +2,13 @@ package firewall
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os/exec"
 	"regexp"
 	"strings"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 const (
@@ -16,7 +19,7 @@ const (
 
 var (
 	ufwActiveRegex     = regexp.MustCompile(`.*Status: active*`)
-	ufwStatusRuleRegex = regexp.MustCompile(`(\d+)\s*/(\w+)\s+(ALLOW|DENY)\s+Anywhere`)
+	ufwStatusRuleRegex = regexp.MustCompile(`(\d+(?::\d+)?)\s*/(\w+)\s+(ALLOW|DENY)\s+Anywhere`)
 )
 
 type UncomplicatedFireWall struct {
@@ -37,6 +40,10 @@ func NewUncomplicatedFirewall() Manager {
 	}
 }
 
+func (ufw *UncomplicatedFireWall) Name() string {
+	return UfwBackend
+}
+
 // IsEnabled returns true if ufw is enabled and running on the node
 func (ufw *UncomplicatedFireWall) IsEnabled() (bool, error) {
 	// Check if ufw is installed
@@ -54,43 +61,51 @@ func (ufw *UncomplicatedFireWall) IsEnabled() (bool, error) {
 	return false, nil
 }
 
-// AllowTcpPort adds a rule to the firewall to open input port
-func (ufw *UncomplicatedFireWall) AllowTcpPort(port string) error {
-	portAddCmd := exec.Command(ufw.binPath, "allow", fmt.Sprintf("%s/tcp", port))
-	out, err := portAddCmd.CombinedOutput()
+// AllowRule adds a rule to the firewall to allow inbound traffic
+func (ufw *UncomplicatedFireWall) AllowRule(ctx context.Context, r Rule) error {
+	allowCmd := exec.Command(ufw.binPath, "allow", ufwRule(r), "comment", comment)
+	out, err := host.FromContext(ctx).Run(allowCmd)
 	if err != nil {
-		return fmt.Errorf("failed to allow port %s in firewall: %s, error: %v", port, out, err)
+		return fmt.Errorf("failed to allow %s in firewall: %s, error: %v", r, out, err)
 	}
 	return nil
 }
 
-// AllowTcpPortRange adds a rule to the firewall to open the range of input port
-func (ufw *UncomplicatedFireWall) AllowTcpPortRange(startPort, endPort string) error {
-	portAddCmd := exec.Command(ufw.binPath, "allow", fmt.Sprintf("%s:%s/tcp", startPort, endPort))
-	out, err := portAddCmd.CombinedOutput()
+// RemoveRule deletes a rule added with AllowRule
+func (ufw *UncomplicatedFireWall) RemoveRule(ctx context.Context, r Rule) error {
+	if ufw.binPath == "" {
+		return fmt.Errorf("%s not installed: %w", ufwBinary, ErrNotFound)
+	}
+	deleteCmd := exec.Command(ufw.binPath, "delete", "allow", ufwRule(r))
+	out, err := host.FromContext(ctx).Run(deleteCmd)
 	if err != nil {
-		return fmt.Errorf("failed to allow ports in firewall: %s, error: %v", out, err)
+		return fmt.Errorf("failed to remove %s from firewall: %s, error: %v", r, out, err)
 	}
 	return nil
 }
 
+// ufwRule returns r in the port/protocol notation of ufw, where ranges are separated by a colon.
+func ufwRule(r Rule) string {
+	return fmt.Sprintf("%s/%s", r.ports(":"), r.Protocol)
+}
+
 // FlushRules flushes the rules and reloads the firewall to enforce the rules
-func (ufw *UncomplicatedFireWall) FlushRules() error {
+func (ufw *UncomplicatedFireWall) FlushRules(ctx context.Context) error {
 	// UFW activates the rules the moment its added, there is no need to flush them out to disk explicitly
 	return nil
 }
 
-// IsPortOpen returns is port/protocol is open on the firewall
+// IsAllowed returns if the port/protocol of the rule is open on the firewall
 // UFW doesn't have a way to query a port, so this function refreshes the active rules
 // maintained by firewall and checks if port/protocol is allowed.
-func (ufw *UncomplicatedFireWall) IsPortOpen(port, protocol string) (bool, error) {
+func (ufw *UncomplicatedFireWall) IsAllowed(r Rule) (bool, error) {
 	if len(ufw.rules) == 0 {
 		if err := ufw.refreshActiveRules(); err != nil {
 			return false, err
 		}
 	}
 	for _, rule := range ufw.rules {
-		if rule.port == port && rule.protocol == protocol && rule.action == actionAllow {
+		if rule.port == r.ports(":") && rule.protocol == string(r.Protocol) && rule.action == actionAllow {
 			return true, nil
 		}
 	}
@@ -46,7 +46,7 @@ func (i *Initer) Run(ctx context.Context) error {
 	for _, aspect := range aspects {
 		nameField := zap.String("name", aspect.Name())
 		i.Logger.Info("Setting up system aspect..", nameField)
-		if err := aspect.Setup(); err != nil {
+		if err := aspect.Setup(ctx); err != nil {
 			return err
 		}
 		i.Logger.Info("Finished setting up system aspect", nameField)
@@ -77,7 +77,7 @@ func initDaemons(ctx context.Context, nodeProvider nodeprovider.NodeProvider, sk
 			nameField := zap.String("name", daemon.Name())
 
 			logger.Info("Configuring daemon...", nameField)
-			if err := daemon.Configure(); err != nil {
+			if err := daemon.Configure(ctx); err != nil {
 				return err
 			}
 			logger.Info("Configured daemon", nameField)
@@ -95,7 +95,7 @@ func initDaemons(ctx context.Context, nodeProvider nodeprovider.NodeProvider, sk
 			logger.Info("Daemon is running", nameField)
 
 			logger.Info("Running post-launch tasks..", nameField)
-			if err := daemon.PostLaunch(); err != nil {
+			if err := daemon.PostLaunch(ctx); err != nil {
 				return err
 			}
 			logger.Info("Finished post-launch tasks", nameField)
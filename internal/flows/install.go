@@ -11,19 +11,19 @@ import (
 	"github.com/aws/eks-hybrid/internal/containerd"
 	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/iamauthenticator"
-	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/imagecredentialprovider"
 	"github.com/aws/eks-hybrid/internal/iptables"
 	"github.com/aws/eks-hybrid/internal/kubectl"
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
-	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
 type Installer struct {
-	AwsSource          aws.Source
-	ContainerdSource   containerd.SourceName
+	AwsSource        aws.Source
+	ContainerdSource containerd.SourceName
+	// ContainerdVersion is the containerd version to install. Empty installs the latest supported version.
+	ContainerdVersion  string
 	PackageManager     *packagemanager.DistroPackageManager
 	CredentialProvider creds.CredentialProvider
 	SsmRegion          string
@@ -58,46 +58,60 @@ func (i *Installer) Run(ctx context.Context) error {
 	}
 
 	i.Logger.Info("Finishing up install...")
-	return i.Tracker.Save()
+	return i.Tracker.Save(ctx)
 }
 
 func (i *Installer) installDistroPackages(ctx context.Context) error {
 	i.Logger.Info("Installing containerd...")
-	if err := containerd.Install(ctx, i.Tracker, i.PackageManager, i.ContainerdSource); err != nil {
+	if i.ContainerdSource == containerd.ContainerdSourceEks {
+		if err := containerd.InstallRelease(ctx, i.Tracker, i.AwsSource, i.Logger); err != nil {
+			return err
+		}
+	} else if err := containerd.Install(ctx, i.Tracker, i.PackageManager, i.ContainerdSource, i.ContainerdVersion); err != nil {
 		return err
 	}
 
 	i.Logger.Info("Installing iptables...")
-	return iptables.Install(ctx, i.Tracker, i.PackageManager)
+	if err := iptables.Install(ctx, i.Tracker, i.PackageManager); err != nil {
+		return err
+	}
+
+	packages := managedPackages(i.Tracker.Artifacts, i.PackageManager)
+	i.Logger.Info("Holding installed packages...", zap.Strings("packages", packages))
+	plugin, err := i.PackageManager.Hold(ctx, packages...)
+	i.Tracker.RecordHoldPlugin(plugin)
+	if err != nil {
+		return err
+	}
+	i.Tracker.RecordHeldPackages(packages)
+	return nil
+}
+
+// managedPackages returns the distro packages installed by nodeadm.
+func managedPackages(artifacts *tracker.InstalledArtifacts, pm *packagemanager.DistroPackageManager) []string {
+	var packages []string
+	// containerd from the eks source is not a distro package.
+	source := containerd.SourceName(artifacts.Containerd)
+	if source == containerd.ContainerdSourceDistro || source == containerd.ContainerdSourceDocker {
+		packages = append(packages, pm.ContainerdPackages()...)
+	}
+	if artifacts.Iptables {
+		packages = append(packages, pm.IptablesPackages()...)
+	}
+	return packages
 }
 
 func (i *Installer) installCredentialProcess(ctx context.Context) error {
-	switch i.CredentialProvider {
-	case creds.IamRolesAnywhereCredentialProvider:
-		i.Logger.Info("Installing AWS signing helper...")
-		if err := iamrolesanywhere.Install(ctx, iamrolesanywhere.InstallOptions{
-			Tracker: i.Tracker,
-			Source:  i.AwsSource,
-			Logger:  i.Logger,
-		}); err != nil {
-			return err
-		}
-	case creds.SsmCredentialProvider:
-		ssmInstaller := ssm.NewSSMInstaller(i.Logger, i.SsmRegion)
-
-		i.Logger.Info("Installing SSM agent installer...")
-		if err := ssm.Install(ctx, ssm.InstallOptions{
-			Tracker: i.Tracker,
-			Source:  ssmInstaller,
-			Logger:  i.Logger,
-			Region:  i.SsmRegion,
-		}); err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("unable to detect hybrid auth method")
+	provider, err := creds.Get(i.CredentialProvider)
+	if err != nil {
+		return fmt.Errorf("unable to detect hybrid auth method: %w", err)
 	}
-	return nil
+	return provider.Install(ctx, creds.InstallOptions{
+		Tracker: i.Tracker,
+		Source:  i.AwsSource,
+		Region:  i.SsmRegion,
+		Logger:  i.Logger,
+	})
 }
 
 func (i *Installer) installEksArtifacts(ctx context.Context) error {
@@ -0,0 +1,65 @@
+package flows
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/packagemanager"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+// Rollback restores the binaries and configuration backed up before an upgrade and restarts
+// the daemons that use them. Distro packages, like containerd, are not rolled back.
+func Rollback(ctx context.Context, backup *artifact.Backup, daemonManager daemon.DaemonManager, logger *zap.Logger) error {
+	logger.Info("Restoring backup...", zap.String("dir", backup.Dir), zap.Time("createdAt", backup.CreatedAt))
+	if err := backup.Restore(ctx); err != nil {
+		return errors.Wrap(err, "restoring backup")
+	}
+
+	if err := daemonManager.DaemonReload(); err != nil {
+		return err
+	}
+
+	// Only restart the daemons that were already running, nodeadm might not manage them on this node.
+	for _, name := range []string{containerd.ContainerdDaemonName, iamrolesanywhere.DaemonName} {
+		status, err := daemonManager.GetDaemonStatus(name)
+		if err != nil {
+			return errors.Wrapf(err, "getting %s status", name)
+		}
+		if status != daemon.DaemonStatusRunning {
+			continue
+		}
+		logger.Info("Restarting daemon...", zap.String("name", name))
+		if err := daemonManager.RestartDaemon(ctx, name); err != nil {
+			return errors.Wrapf(err, "restarting %s", name)
+		}
+	}
+
+	logger.Info("Restarting daemon...", zap.String("name", kubelet.KubeletDaemonName))
+	if err := daemonManager.RestartDaemon(ctx, kubelet.KubeletDaemonName); err != nil {
+		return errors.Wrapf(err, "restarting %s", kubelet.KubeletDaemonName)
+	}
+
+	logger.Info("Rollback completed")
+	return nil
+}
+
+// HoldBackupPackages holds again the packages that were held when the backup in tr
+// was taken and restores them as the held packages in tr.
+func HoldBackupPackages(ctx context.Context, tr *tracker.Tracker, pm *packagemanager.DistroPackageManager) error {
+	plugin, err := pm.Hold(ctx, tr.BackupHeldPackages...)
+	tr.RecordHoldPlugin(plugin)
+	if err != nil {
+		return err
+	}
+	tr.HeldPackages = slices.Clone(tr.BackupHeldPackages)
+	return nil
+}
@@ -2,34 +2,41 @@ package flows
 
 import (
 	"context"
-	"fmt"
-	"os"
+	"slices"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	awsSsm "github.com/aws/aws-sdk-go-v2/service/ssm"
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/iamauthenticator"
-	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/imagecredentialprovider"
 	"github.com/aws/eks-hybrid/internal/iptables"
 	"github.com/aws/eks-hybrid/internal/kubectl"
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
-	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/system"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
 const eksConfigDir = "/etc/eks"
 
 type (
-	CNIUninstall func() error
+	CNIUninstall func(context.Context) error
 )
 
 type Uninstaller struct {
-	Artifacts      *tracker.InstalledArtifacts
+	Artifacts *tracker.InstalledArtifacts
+	// HeldPackages are the distro packages nodeadm put on hold, released before uninstalling them.
+	HeldPackages []string
+	// HoldPlugins are the plugin packages nodeadm installed to hold packages, removed
+	// once the holds are released.
+	HoldPlugins []string
+	// AWSConfigPaths are the AWS config files written by init for the node credentials.
+	AWSConfigPaths []string
+	// Aspects are the system aspects whose changes are reverted.
+	Aspects        []system.SystemAspect
 	DaemonManager  daemon.DaemonManager
 	PackageManager *packagemanager.DistroPackageManager
 	Logger         *zap.Logger
@@ -37,21 +44,38 @@ type Uninstaller struct {
 }
 
 func (u *Uninstaller) Run(ctx context.Context) error {
+	if len(u.HeldPackages) > 0 {
+		u.Logger.Info("Releasing held packages...", zap.Strings("packages", u.HeldPackages))
+		if err := u.PackageManager.Release(ctx, u.HeldPackages...); err != nil {
+			return err
+		}
+	}
+	for _, plugin := range u.HoldPlugins {
+		u.Logger.Info("Uninstalling hold plugin...", zap.String("package", plugin))
+		if err := u.PackageManager.RemoveHoldPlugin(ctx, plugin); err != nil {
+			return err
+		}
+	}
+
 	if err := u.uninstallDaemons(ctx); err != nil {
 		return err
 	}
 
+	if err := u.teardownAspects(ctx); err != nil {
+		return err
+	}
+
 	if err := u.uninstallBinaries(ctx); err != nil {
 		return err
 	}
 
-	if err := u.cleanup(); err != nil {
+	if err := u.cleanup(ctx); err != nil {
 		return err
 	}
 
 	u.Logger.Info("Finished uninstallation tasks...")
 
-	return tracker.Clear()
+	return tracker.Clear(ctx)
 }
 
 func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
@@ -60,44 +84,18 @@ func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
 		if err := u.DaemonManager.StopDaemon(kubelet.KubeletDaemonName); err != nil {
 			return err
 		}
-		if err := kubelet.Uninstall(kubelet.UninstallOptions{}); err != nil {
+		if err := kubelet.Uninstall(ctx, kubelet.UninstallOptions{}); err != nil {
 			return err
 		}
 	}
-	if u.Artifacts.Ssm {
-		u.Logger.Info("Stopping SSM daemon...")
-		if err := u.DaemonManager.StopDaemon(ssm.SsmDaemonName); err != nil {
-			return err
-		}
-
-		ssmRegistration := ssm.NewSSMRegistration()
-		region := ssmRegistration.GetRegion()
-		opts := []func(*config.LoadOptions) error{}
-		if region != "" {
-			opts = append(opts, config.WithRegion(region))
-		}
-
-		awsConfig, err := config.LoadDefaultConfig(ctx, opts...)
-		if err != nil {
-			return err
-		}
-
-		if err := ssm.Uninstall(ctx, ssm.UninstallOptions{
-			Logger:          u.Logger,
-			SSMRegistration: ssmRegistration,
-			PkgSource:       u.PackageManager,
-			SSMClient:       awsSsm.NewFromConfig(awsConfig),
+	if provider, err := creds.ForInstalledArtifacts(u.Artifacts); err == nil {
+		if err := provider.Uninstall(ctx, creds.UninstallOptions{
+			DaemonManager:  u.DaemonManager,
+			PackageManager: u.PackageManager,
+			Logger:         u.Logger,
+			AWSConfigPaths: u.AWSConfigPaths,
 		}); err != nil {
-			return fmt.Errorf("uninstalling SSM: %w", err)
-		}
-	}
-	if u.Artifacts.IamRolesAnywhere {
-		u.Logger.Info("Removing aws_signing_helper_update daemon...")
-		if status, err := u.DaemonManager.GetDaemonStatus(iamrolesanywhere.DaemonName); err == nil || status != daemon.DaemonStatusUnknown {
-			if err = u.DaemonManager.StopDaemon(iamrolesanywhere.DaemonName); err != nil {
-				u.Logger.Info("Stopping aws_signing_helper_update daemon...")
-				return err
-			}
+			return err
 		}
 	}
 	if u.Artifacts.Containerd != string(containerd.ContainerdSourceNone) {
@@ -105,7 +103,24 @@ func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
 		if err := u.DaemonManager.StopDaemon(containerd.ContainerdDaemonName); err != nil {
 			return err
 		}
-		if err := containerd.Uninstall(ctx, u.PackageManager); err != nil {
+		if u.Artifacts.Containerd == string(containerd.ContainerdSourceEks) {
+			if err := containerd.UninstallRelease(ctx, u.DaemonManager); err != nil {
+				return err
+			}
+		} else if err := containerd.Uninstall(ctx, u.PackageManager); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// teardownAspects reverts the changes of the system aspects, in the reverse order
+// they were set up.
+func (u *Uninstaller) teardownAspects(ctx context.Context) error {
+	for _, aspect := range slices.Backward(u.Aspects) {
+		nameField := zap.String("name", aspect.Name())
+		u.Logger.Info("Tearing down system aspect..", nameField)
+		if err := aspect.Teardown(ctx); err != nil {
 			return err
 		}
 	}
@@ -115,31 +130,25 @@ func (u *Uninstaller) uninstallDaemons(ctx context.Context) error {
 func (u *Uninstaller) uninstallBinaries(ctx context.Context) error {
 	if u.Artifacts.Kubectl {
 		u.Logger.Info("Uninstalling kubectl...")
-		if err := kubectl.Uninstall(); err != nil {
+		if err := kubectl.Uninstall(ctx); err != nil {
 			return err
 		}
 	}
 	if u.Artifacts.CniPlugins {
 		u.Logger.Info("Uninstalling cni-plugins...")
-		if err := u.CNIUninstall(); err != nil {
+		if err := u.CNIUninstall(ctx); err != nil {
 			return err
 		}
 	}
 	if u.Artifacts.IamAuthenticator {
 		u.Logger.Info("Uninstalling IAM authenticator...")
-		if err := iamauthenticator.Uninstall(); err != nil {
-			return err
-		}
-	}
-	if u.Artifacts.IamRolesAnywhere {
-		u.Logger.Info("Uninstalling AWS signing helper...")
-		if err := iamrolesanywhere.Uninstall(); err != nil {
+		if err := iamauthenticator.Uninstall(ctx); err != nil {
 			return err
 		}
 	}
 	if u.Artifacts.ImageCredentialProvider {
 		u.Logger.Info("Uninstalling image credential provider...")
-		if err := imagecredentialprovider.Uninstall(); err != nil {
+		if err := imagecredentialprovider.Uninstall(ctx); err != nil {
 			return err
 		}
 	}
@@ -153,12 +162,17 @@ func (u *Uninstaller) uninstallBinaries(ctx context.Context) error {
 }
 
 // cleanup removes directories or files that are not individually owned by single component
-func (u *Uninstaller) cleanup() error {
-	if err := u.PackageManager.Cleanup(); err != nil {
+func (u *Uninstaller) cleanup(ctx context.Context) error {
+	if err := u.PackageManager.Cleanup(ctx); err != nil {
+		return err
+	}
+
+	// The kernel modules config is written on init even when containerd isn't installed by nodeadm.
+	if err := containerd.UninstallKernelModulesConfig(ctx); err != nil {
 		return err
 	}
 
-	if err := os.RemoveAll(eksConfigDir); err != nil {
+	if err := host.FromContext(ctx).RemoveAll(eksConfigDir); err != nil {
 		return err
 	}
 
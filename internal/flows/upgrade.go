@@ -2,40 +2,93 @@ package flows
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/utils/strings/slices"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/cni"
 	"github.com/aws/eks-hybrid/internal/containerd"
 	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/daemon"
 	"github.com/aws/eks-hybrid/internal/iamauthenticator"
-	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/imagecredentialprovider"
 	"github.com/aws/eks-hybrid/internal/iptables"
 	"github.com/aws/eks-hybrid/internal/kubectl"
 	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/node"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
 	"github.com/aws/eks-hybrid/internal/packagemanager"
-	"github.com/aws/eks-hybrid/internal/ssm"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
+// HealthCheckPhase is the phase that waits for kubelet to be running and the node
+// to be Ready after the upgraded daemons are started.
+const HealthCheckPhase = "health-check"
+
+const (
+	healthCheckTimeout  = 5 * time.Minute
+	healthCheckInterval = 10 * time.Second
+	rollbackTimeout     = 5 * time.Minute
+)
+
 type Upgrader struct {
 	NodeProvider       nodeprovider.NodeProvider
 	AwsSource          aws.Source
 	PackageManager     *packagemanager.DistroPackageManager
 	CredentialProvider creds.CredentialProvider
-	Artifacts          *tracker.InstalledArtifacts
-	DaemonManager      daemon.DaemonManager
-	SkipPhases         []string
-	Logger             *zap.Logger
+	// ContainerdVersion is the containerd version to upgrade to. Empty upgrades to the latest supported version.
+	ContainerdVersion string
+	Tracker           *tracker.Tracker
+	DaemonManager     daemon.DaemonManager
+	SkipPhases        []string
+	Logger            *zap.Logger
 }
 
+// Run backs up the installed artifacts and their configuration, upgrades them and waits
+// for the node to be healthy. If any of those steps fail, the backup is restored.
 func (u *Upgrader) Run(ctx context.Context) error {
+	u.Logger.Info("Backing up installed artifacts and configuration...", zap.String("dir", tracker.BackupDir))
+	backup, err := artifact.NewBackup(ctx, tracker.BackupDir, u.backupPaths())
+	if err != nil {
+		return errors.Wrap(err, "backing up installed artifacts")
+	}
+	u.Tracker.SetBackup(backup)
+	if err := u.Tracker.Save(ctx); err != nil {
+		return errors.Wrap(err, "saving backup to tracker")
+	}
+
+	if err := u.upgrade(ctx); err != nil {
+		u.Logger.Error("Upgrade failed, rolling back to the previous version", zap.Error(err))
+		// The upgrade might have failed because ctx expired, so the rollback gets its own deadline.
+		rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+		defer cancel()
+		if holdErr := HoldBackupPackages(rollbackCtx, u.Tracker, u.PackageManager); holdErr != nil {
+			u.Logger.Error("Failed to hold packages again", zap.Error(holdErr))
+		}
+		u.Tracker.RestoreBackupRecords()
+		if saveErr := u.Tracker.Save(rollbackCtx); saveErr != nil {
+			u.Logger.Error("Failed to restore tracker records", zap.Error(saveErr))
+		}
+		if rollbackErr := Rollback(rollbackCtx, backup, u.DaemonManager, u.Logger); rollbackErr != nil {
+			return stdErrors.Join(err, errors.Wrap(rollbackErr, "rolling back upgrade"))
+		}
+		return errors.Wrap(err, "upgrade failed and was rolled back")
+	}
+
+	if err := u.Tracker.Save(ctx); err != nil {
+		return errors.Wrap(err, "saving upgraded artifacts to tracker")
+	}
+
+	return u.NodeProvider.Cleanup()
+}
+
+func (u *Upgrader) upgrade(ctx context.Context) error {
 	if err := u.upgradeDistroPackages(ctx); err != nil {
 		return err
 	}
@@ -58,7 +111,48 @@ func (u *Upgrader) Run(ctx context.Context) error {
 		return err
 	}
 
-	return u.NodeProvider.Cleanup()
+	if slices.Contains(u.SkipPhases, runPhase) || slices.Contains(u.SkipPhases, HealthCheckPhase) {
+		return nil
+	}
+	return u.checkHealth(ctx)
+}
+
+// checkHealth waits for kubelet to be running and for the node to report Ready.
+func (u *Upgrader) checkHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	u.Logger.Info("Waiting for kubelet to be running...")
+	if err := daemon.WaitForStatus(ctx, u.Logger, u.DaemonManager, kubelet.KubeletDaemonName, daemon.DaemonStatusRunning, healthCheckInterval); err != nil {
+		return errors.Wrap(err, "kubelet is not running after upgrade")
+	}
+
+	u.Logger.Info("Waiting for node to be Ready...")
+	if err := node.WaitForReady(ctx, healthCheckInterval); err != nil {
+		return errors.Wrap(err, "node is not Ready after upgrade")
+	}
+	return nil
+}
+
+// backupPaths returns the binaries and configuration files that can be modified by the upgrade.
+// Distro packages are upgraded by the package manager and are not included, unlike containerd
+// installed from the release manifest.
+func (u *Upgrader) backupPaths() []string {
+	paths := []string{
+		kubelet.BinPath,
+		kubectl.BinPath,
+		imagecredentialprovider.BinPath,
+		iamauthenticator.IAMAuthenticatorBinPath,
+		cni.BinPath,
+	}
+	if provider, err := creds.Get(u.CredentialProvider); err == nil {
+		paths = append(paths, provider.BackupPaths()...)
+	}
+	if u.Tracker.Artifacts.Containerd == string(containerd.ContainerdSourceEks) {
+		paths = append(paths, containerd.ReleasePaths()...)
+	}
+	paths = append(paths, kubelet.ConfigPaths()...)
+	return append(paths, containerd.ConfigPaths()...)
 }
 
 func (u *Upgrader) upgradeDistroPackages(ctx context.Context) error {
@@ -66,68 +160,79 @@ func (u *Upgrader) upgradeDistroPackages(ctx context.Context) error {
 	if err := u.PackageManager.RefreshMetadataCache(ctx); err != nil {
 		return err
 	}
-	if u.Artifacts.Containerd != string(containerd.ContainerdSourceNone) {
+
+	u.Logger.Info("Releasing held packages...", zap.Strings("packages", u.Tracker.HeldPackages))
+	if err := u.PackageManager.Release(ctx, u.Tracker.HeldPackages...); err != nil {
+		return err
+	}
+	u.Tracker.HeldPackages = nil
+
+	switch containerd.SourceName(u.Tracker.Artifacts.Containerd) {
+	case containerd.ContainerdSourceNone:
+	case containerd.ContainerdSourceEks:
 		u.Logger.Info("Upgrading containerd...")
-		if err := containerd.Upgrade(ctx, u.PackageManager); err != nil {
+		if err := containerd.UpgradeRelease(ctx, u.Tracker, u.AwsSource, u.Logger); err != nil {
+			return err
+		}
+	default:
+		u.Logger.Info("Upgrading containerd...")
+		if err := containerd.Upgrade(ctx, u.PackageManager, u.ContainerdVersion); err != nil {
 			return err
 		}
 	}
 
-	if u.Artifacts.Iptables {
+	if u.Tracker.Artifacts.Iptables {
 		u.Logger.Info("Upgrading iptables...")
 		if err := iptables.Upgrade(ctx, u.PackageManager); err != nil {
 			return err
 		}
 	}
+
+	packages := managedPackages(u.Tracker.Artifacts, u.PackageManager)
+	u.Logger.Info("Holding upgraded packages...", zap.Strings("packages", packages))
+	plugin, err := u.PackageManager.Hold(ctx, packages...)
+	u.Tracker.RecordHoldPlugin(plugin)
+	if err != nil {
+		return err
+	}
+	u.Tracker.RecordHeldPackages(packages)
 	return nil
 }
 
 func (u *Upgrader) upgradeCredentialProvider(ctx context.Context) error {
-	switch u.CredentialProvider {
-	case creds.IamRolesAnywhereCredentialProvider:
-		u.Logger.Info("Upgrading AWS signing helper...")
-		if err := iamrolesanywhere.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
-			return err
-		}
-	case creds.SsmCredentialProvider:
-		nodeConfig := u.NodeProvider.GetNodeConfig()
-		ssmInstaller := ssm.NewSSMInstaller(u.Logger, nodeConfig.Spec.Cluster.Region)
-
-		u.Logger.Info("Upgrading SSM agent installer...")
-		if err := ssm.Upgrade(ctx, ssm.InstallOptions{
-			Source: ssmInstaller,
-			Logger: u.Logger,
-			Region: nodeConfig.Spec.Cluster.Region,
-		}); err != nil {
-			return err
-		}
-	default:
+	provider, err := creds.Get(u.CredentialProvider)
+	if err != nil {
 		return fmt.Errorf("installed credential provider %s is not supported for upgrade", u.CredentialProvider)
 	}
-	return nil
+	return provider.Upgrade(ctx, creds.InstallOptions{
+		Tracker: u.Tracker,
+		Source:  u.AwsSource,
+		Region:  u.NodeProvider.GetNodeConfig().Spec.Cluster.Region,
+		Logger:  u.Logger,
+	})
 }
 
 func (u *Upgrader) upgradeEksArtifacts(ctx context.Context) error {
 	u.Logger.Info("Upgrading kubelet...")
-	if err := kubelet.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	if err := kubelet.Upgrade(ctx, u.AwsSource, u.Tracker, u.Logger); err != nil {
 		return errors.Wrap(err, "failed to upgrade kubelet")
 	}
 
 	u.Logger.Info("Upgrading kubectl...")
-	if err := kubectl.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	if err := kubectl.Upgrade(ctx, u.AwsSource, u.Tracker, u.Logger); err != nil {
 		return err
 	}
 
 	u.Logger.Info("Upgrading image credential provider...")
-	if err := imagecredentialprovider.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	if err := imagecredentialprovider.Upgrade(ctx, u.AwsSource, u.Tracker, u.Logger); err != nil {
 		return err
 	}
 
 	u.Logger.Info("Upgrading IAM authenticator...")
-	if err := iamauthenticator.Upgrade(ctx, u.AwsSource, u.Logger); err != nil {
+	if err := iamauthenticator.Upgrade(ctx, u.AwsSource, u.Tracker, u.Logger); err != nil {
 		return err
 	}
 
 	u.Logger.Info("Upgrading cni-plugins...")
-	return cni.Upgrade(ctx, u.AwsSource, u.Logger)
+	return cni.Upgrade(ctx, u.AwsSource, u.Tracker, u.Logger)
 }
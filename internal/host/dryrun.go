@@ -0,0 +1,180 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/secrets"
+)
+
+// ActionKind is the type of change recorded by a DryRun.
+type ActionKind string
+
+const (
+	ActionWriteFile ActionKind = "write"
+	ActionMkdir     ActionKind = "mkdir"
+	ActionSymlink   ActionKind = "symlink"
+	ActionRemove    ActionKind = "remove"
+	ActionRun       ActionKind = "run"
+	ActionAPI       ActionKind = "api"
+	ActionDaemon    ActionKind = "daemon"
+)
+
+// Action is a change that would have been made to the host.
+type Action struct {
+	Kind   ActionKind
+	Target string
+	Detail string
+}
+
+// DryRun records the changes instead of applying them. Files are read fully so
+// downloads are still checksummed, but nothing is written to disk.
+type DryRun struct {
+	mu      sync.Mutex
+	actions []Action
+}
+
+var _ Host = &DryRun{}
+
+func NewDryRun() *DryRun {
+	return &DryRun{}
+}
+
+func (d *DryRun) record(kind ActionKind, target, detail string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Commands can take secrets as arguments, like the SSM activation code.
+	d.actions = append(d.actions, Action{Kind: kind, Target: secrets.Redact(target), Detail: secrets.Redact(detail)})
+}
+
+// Actions returns the changes recorded so far in the order they were made.
+func (d *DryRun) Actions() []Action {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Action(nil), d.actions...)
+}
+
+func (d *DryRun) WriteFile(dst string, src io.Reader, perms fs.FileMode) error {
+	n, err := io.Copy(io.Discard, src)
+	if err != nil {
+		return err
+	}
+	d.record(ActionWriteFile, dst, fmt.Sprintf("%s, %d bytes", perms.Perm(), n))
+	return nil
+}
+
+func (d *DryRun) MkdirAll(path string, perms fs.FileMode) error {
+	d.record(ActionMkdir, path, perms.Perm().String())
+	return nil
+}
+
+func (d *DryRun) Symlink(oldname, newname string) error {
+	d.record(ActionSymlink, newname, "-> "+oldname)
+	return nil
+}
+
+func (d *DryRun) RemoveAll(path string) error {
+	d.record(ActionRemove, path, "")
+	return nil
+}
+
+func (d *DryRun) Run(cmd *exec.Cmd) ([]byte, error) {
+	d.record(ActionRun, strings.Join(cmd.Args, " "), "")
+	return nil, nil
+}
+
+func (d *DryRun) CallAPI(operation string, _ func() error) error {
+	d.record(ActionAPI, operation, "")
+	return nil
+}
+
+// DaemonManager wraps manager so operations that change the state of a daemon are
+// recorded instead of applied. The status of daemons that haven't been touched is
+// read from manager.
+func (d *DryRun) DaemonManager(manager daemon.DaemonManager) daemon.DaemonManager {
+	return &dryRunDaemonManager{
+		DaemonManager: manager,
+		dryRun:        d,
+		statuses:      map[string]daemon.DaemonStatus{},
+	}
+}
+
+// Print writes every recorded change to w.
+func (d *DryRun) Print(w io.Writer) error {
+	actions := d.Actions()
+	if len(actions) == 0 {
+		_, err := fmt.Fprintln(w, "Dry run: no changes would be made to the host")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Dry run: the following changes would be made to the host")
+	for _, action := range actions {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", action.Kind, action.Target, action.Detail)
+	}
+	return tw.Flush()
+}
+
+type dryRunDaemonManager struct {
+	daemon.DaemonManager
+	dryRun   *DryRun
+	mu       sync.Mutex
+	statuses map[string]daemon.DaemonStatus
+}
+
+func (m *dryRunDaemonManager) setStatus(name string, status daemon.DaemonStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[name] = status
+}
+
+func (m *dryRunDaemonManager) StartDaemon(name string) error {
+	m.dryRun.record(ActionDaemon, name, "start")
+	m.setStatus(name, daemon.DaemonStatusRunning)
+	return nil
+}
+
+func (m *dryRunDaemonManager) StopDaemon(name string) error {
+	m.dryRun.record(ActionDaemon, name, "stop")
+	m.setStatus(name, daemon.DaemonStatusStopped)
+	return nil
+}
+
+func (m *dryRunDaemonManager) RestartDaemon(_ context.Context, name string, _ ...daemon.OperationOption) error {
+	m.dryRun.record(ActionDaemon, name, "restart")
+	m.setStatus(name, daemon.DaemonStatusRunning)
+	return nil
+}
+
+// GetDaemonStatus returns the status a daemon would have after the recorded operations.
+func (m *dryRunDaemonManager) GetDaemonStatus(name string) (daemon.DaemonStatus, error) {
+	m.mu.Lock()
+	status, ok := m.statuses[name]
+	m.mu.Unlock()
+	if ok {
+		return status, nil
+	}
+	return m.DaemonManager.GetDaemonStatus(name)
+}
+
+func (m *dryRunDaemonManager) EnableDaemon(name string) error {
+	m.dryRun.record(ActionDaemon, name, "enable")
+	return nil
+}
+
+func (m *dryRunDaemonManager) DisableDaemon(name string) error {
+	m.dryRun.record(ActionDaemon, name, "disable")
+	return nil
+}
+
+func (m *dryRunDaemonManager) DaemonReload() error {
+	m.dryRun.record(ActionDaemon, "systemd", "daemon-reload")
+	return nil
+}
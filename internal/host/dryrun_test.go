@@ -0,0 +1,96 @@
+package host_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
+)
+
+type fakeDaemonManager struct {
+	daemon.DaemonManager
+}
+
+func (fakeDaemonManager) GetDaemonStatus(name string) (daemon.DaemonStatus, error) {
+	return daemon.DaemonStatusStopped, nil
+}
+
+func TestDryRunRecordsChanges(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	dryRun := host.NewDryRun()
+	ctx := host.NewContext(context.Background(), dryRun)
+	g.Expect(host.IsDryRun(ctx)).To(BeTrue())
+
+	h := host.FromContext(ctx)
+	src := strings.NewReader("content")
+	g.Expect(h.WriteFile(filepath.Join(dir, "file"), src, 0o644)).To(Succeed())
+	g.Expect(src.Len()).To(BeZero(), "source should be fully read")
+	g.Expect(h.MkdirAll(filepath.Join(dir, "dir"), 0o755)).To(Succeed())
+	g.Expect(h.Symlink("/usr/bin/kubelet", filepath.Join(dir, "link"))).To(Succeed())
+	g.Expect(h.RemoveAll(filepath.Join(dir, "old"))).To(Succeed())
+	_, err := h.Run(exec.Command("systemctl", "daemon-reload"))
+	g.Expect(err).NotTo(HaveOccurred())
+	called := false
+	g.Expect(h.CallAPI("ssm:DeregisterManagedInstance", func() error {
+		called = true
+		return errors.New("should not be called")
+	})).To(Succeed())
+	g.Expect(called).To(BeFalse())
+
+	g.Expect(dir).To(BeADirectory())
+	g.Expect(filepath.Join(dir, "file")).NotTo(BeAnExistingFile())
+
+	g.Expect(dryRun.Actions()).To(Equal([]host.Action{
+		{Kind: host.ActionWriteFile, Target: filepath.Join(dir, "file"), Detail: "-rw-r--r--, 7 bytes"},
+		{Kind: host.ActionMkdir, Target: filepath.Join(dir, "dir"), Detail: "-rwxr-xr-x"},
+		{Kind: host.ActionSymlink, Target: filepath.Join(dir, "link"), Detail: "-> /usr/bin/kubelet"},
+		{Kind: host.ActionRemove, Target: filepath.Join(dir, "old")},
+		{Kind: host.ActionRun, Target: "systemctl daemon-reload"},
+		{Kind: host.ActionAPI, Target: "ssm:DeregisterManagedInstance"},
+	}))
+
+	var buf bytes.Buffer
+	g.Expect(dryRun.Print(&buf)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring("the following changes would be made"))
+	g.Expect(buf.String()).To(ContainSubstring("systemctl daemon-reload"))
+}
+
+func TestDryRunDaemonManager(t *testing.T) {
+	g := NewWithT(t)
+	dryRun := host.NewDryRun()
+	manager := dryRun.DaemonManager(fakeDaemonManager{})
+
+	status, err := manager.GetDaemonStatus("kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(daemon.DaemonStatusStopped))
+
+	g.Expect(manager.DaemonReload()).To(Succeed())
+	g.Expect(manager.EnableDaemon("kubelet")).To(Succeed())
+	g.Expect(manager.RestartDaemon(context.Background(), "kubelet")).To(Succeed())
+
+	status, err = manager.GetDaemonStatus("kubelet")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(daemon.DaemonStatusRunning))
+
+	g.Expect(dryRun.Actions()).To(Equal([]host.Action{
+		{Kind: host.ActionDaemon, Target: "systemd", Detail: "daemon-reload"},
+		{Kind: host.ActionDaemon, Target: "kubelet", Detail: "enable"},
+		{Kind: host.ActionDaemon, Target: "kubelet", Detail: "restart"},
+	}))
+}
+
+func TestFromContextDefaultsToOS(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	g.Expect(host.IsDryRun(ctx)).To(BeFalse())
+	g.Expect(host.FromContext(ctx)).To(Equal(host.OS{}))
+}
@@ -0,0 +1,100 @@
+package host
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+)
+
+// DefaultDirPerms are the permissions assigned to the parent directories created when writing a file.
+const DefaultDirPerms = fs.ModeDir | 0o755
+
+// Host applies the changes nodeadm makes to the machine. Flows get it from the
+// context so the same steps can run against a DryRun, which records the changes
+// instead of applying them.
+type Host interface {
+	// WriteFile writes the content of src to path with perms permissions,
+	// replacing any existing file and creating the parent directories.
+	WriteFile(path string, src io.Reader, perms fs.FileMode) error
+	// MkdirAll creates path and any missing parents with perms permissions.
+	MkdirAll(path string, perms fs.FileMode) error
+	// Symlink creates newname as a symbolic link to oldname.
+	Symlink(oldname, newname string) error
+	// RemoveAll removes path and any children it contains.
+	RemoveAll(path string) error
+	// Run runs cmd and returns its combined output.
+	Run(cmd *exec.Cmd) ([]byte, error)
+	// CallAPI performs call, a request to the AWS API operation that changes state.
+	CallAPI(operation string, call func() error) error
+}
+
+type contextKey struct{}
+
+var key = contextKey{}
+
+// FromContext returns the Host from the context.
+// If no Host is found, the OS is returned.
+func FromContext(ctx context.Context) Host {
+	h, ok := ctx.Value(key).(Host)
+	if !ok {
+		return OS{}
+	}
+	return h
+}
+
+// NewContext returns a new Context, derived from ctx, which carries the provided Host.
+func NewContext(ctx context.Context, h Host) context.Context {
+	return context.WithValue(ctx, key, h)
+}
+
+// IsDryRun returns true when the Host in the context doesn't apply changes.
+func IsDryRun(ctx context.Context) bool {
+	_, ok := FromContext(ctx).(*DryRun)
+	return ok
+}
+
+// OS applies changes to the local machine.
+type OS struct{}
+
+var _ Host = OS{}
+
+func (OS) WriteFile(dst string, src io.Reader, perms fs.FileMode) error {
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(dst), DefaultDirPerms); err != nil {
+		return err
+	}
+
+	fh, err := os.OpenFile(dst, os.O_CREATE|os.O_RDWR|os.O_TRUNC, perms)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	_, err = io.Copy(fh, src)
+	return err
+}
+
+func (OS) MkdirAll(path string, perms fs.FileMode) error {
+	return os.MkdirAll(path, perms)
+}
+
+func (OS) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+func (OS) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+func (OS) Run(cmd *exec.Cmd) ([]byte, error) {
+	return cmd.CombinedOutput()
+}
+
+func (OS) CallAPI(_ string, call func() error) error {
+	return call()
+}
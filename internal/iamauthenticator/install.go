@@ -2,13 +2,13 @@ package iamauthenticator
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -74,7 +74,7 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	}
 	defer authenticator.Close()
 
-	if err := artifact.InstallFile(filepath.Join(opts.InstallRoot, IAMAuthenticatorBinPath), authenticator, artifactFilePerms); err != nil {
+	if err := host.FromContext(ctx).WriteFile(filepath.Join(opts.InstallRoot, IAMAuthenticatorBinPath), authenticator, artifactFilePerms); err != nil {
 		return errors.Wrap(err, "installing aws-iam-authenticator")
 	}
 
@@ -82,19 +82,26 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 		return errors.Errorf("aws-iam-authenticator checksum mismatch: %v", artifact.NewChecksumError(authenticator))
 	}
 
+	opts.Tracker.Record(artifact.IamAuthenticator, IAMAuthenticatorBinPath, authenticator)
+
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(IAMAuthenticatorBinPath)
+func Uninstall(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	return h.RemoveAll(IAMAuthenticatorBinPath)
 }
 
-func Upgrade(ctx context.Context, src IAMAuthenticatorSource, log *zap.Logger) error {
+func Upgrade(ctx context.Context, src IAMAuthenticatorSource, tr *tracker.Tracker, log *zap.Logger) error {
 	authenticator, err := src.GetIAMAuthenticator(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting aws-iam-authenticator source")
 	}
 	defer authenticator.Close()
 
-	return artifact.Upgrade(artifactName, IAMAuthenticatorBinPath, authenticator, artifactFilePerms, log)
+	if err := artifact.Upgrade(ctx, artifactName, IAMAuthenticatorBinPath, authenticator, artifactFilePerms, log); err != nil {
+		return err
+	}
+	tr.Record(artifact.IamAuthenticator, IAMAuthenticatorBinPath, authenticator)
+	return nil
 }
@@ -2,12 +2,16 @@ package iamrolesanywhere
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
 	"os"
 	"path"
 	"text/template"
+
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const (
@@ -59,7 +63,18 @@ type AWSConfig struct {
 }
 
 // WriteAWSConfig writes an AWS configuration file with contents appropriate for node config
-func WriteAWSConfig(cfg AWSConfig) error {
+// using the Host in the context.
+func WriteAWSConfig(ctx context.Context, cfg AWSConfig) error {
+	file, err := RenderAWSConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return writeConfigFile(ctx, file)
+}
+
+// RenderAWSConfig returns the AWS configuration file written by WriteAWSConfig without writing it.
+func RenderAWSConfig(cfg AWSConfig) (util.RenderedFile, error) {
 	if cfg.ConfigPath == "" {
 		cfg.ConfigPath = DefaultAWSConfigPath
 	}
@@ -69,10 +84,15 @@ func WriteAWSConfig(cfg AWSConfig) error {
 	}
 
 	if err := validateAWSConfig(cfg); err != nil {
-		return err
+		return util.RenderedFile{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := awsConfigTpl.Execute(&buf, cfg); err != nil {
+		return util.RenderedFile{}, err
 	}
 
-	return writeConfigFile(cfg)
+	return util.RenderedFile{Path: cfg.ConfigPath, Content: buf.Bytes(), Perms: 0o644}, nil
 }
 
 func validateAWSConfig(cfg AWSConfig) error {
@@ -113,17 +133,13 @@ func validateAWSConfig(cfg AWSConfig) error {
 	return errors.Join(errs...)
 }
 
-func writeConfigFile(cfg AWSConfig) error {
-	var buf bytes.Buffer
-	if err := awsConfigTpl.Execute(&buf, cfg); err != nil {
-		return err
-	}
-
-	if err := os.MkdirAll(path.Dir(cfg.ConfigPath), os.ModeDir); err != nil {
+func writeConfigFile(ctx context.Context, file util.RenderedFile) error {
+	h := host.FromContext(ctx)
+	if err := h.MkdirAll(path.Dir(file.Path), os.ModeDir); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(cfg.ConfigPath, buf.Bytes(), 0o644); err != nil {
+	if err := h.WriteFile(file.Path, bytes.NewReader(file.Content), file.Perms); err != nil {
 		return fmt.Errorf("writing AWS config file: %w", err)
 	}
 
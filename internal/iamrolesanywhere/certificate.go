@@ -0,0 +1,65 @@
+package iamrolesanywhere
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/aws/eks-hybrid/internal/secrets"
+)
+
+const (
+	// DefaultCertificatePath is the node certificate used when none is configured.
+	DefaultCertificatePath = "/etc/iam/pki/server.pem"
+	// DefaultPrivateKeyPath is the node private key used when none is configured.
+	DefaultPrivateKeyPath = "/etc/iam/pki/server.key"
+	// ResolvedPrivateKeyPath is where the private key is written when privateKeyPath is
+	// a secret reference, as the signing helper can only read it from a file.
+	ResolvedPrivateKeyPath = "/etc/eks/iam-roles-anywhere/server.key"
+)
+
+// PrivateKeyFile returns the file the signing helper reads the private key from for
+// the configured privateKeyPath.
+func PrivateKeyFile(privateKeyPath string) string {
+	if secrets.IsReference(privateKeyPath) {
+		return ResolvedPrivateKeyPath
+	}
+	return privateKeyPath
+}
+
+var certificateFlagRegex = regexp.MustCompile(`--certificate\s+(\S+)`)
+
+// ReadCertificate reads the first PEM encoded certificate in path.
+func ReadCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading IAM Roles Anywhere certificate")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM certificate found in %s", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing certificate %s", path)
+	}
+	return cert, nil
+}
+
+// CertificatePathFromConfig returns the certificate used by the credential process
+// configured in the AWS config file at configPath.
+func CertificatePathFromConfig(configPath string) (string, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", errors.Wrap(err, "reading AWS config file")
+	}
+	match := certificateFlagRegex.FindSubmatch(data)
+	if match == nil {
+		return "", fmt.Errorf("no certificate configured in credential_process in %s", configPath)
+	}
+	return string(match[1]), nil
+}
@@ -37,13 +37,13 @@ func NewSigningHelperDaemon(daemonManager daemon.DaemonManager, node *api.NodeCo
 	}
 }
 
-func (s *SigningHelperDaemon) Configure() error {
-	service, err := GenerateUpdateSystemdService(s.node)
+func (s *SigningHelperDaemon) Configure(ctx context.Context) error {
+	service, err := s.RenderConfig()
 	if err != nil {
 		return err
 	}
 
-	if err := util.WriteFileWithDir(SigningHelperServiceFilePath, service, 0o644); err != nil {
+	if err := util.WriteRenderedFiles(ctx, service); err != nil {
 		return fmt.Errorf("writing aws_signing_helper_update service file %s: %v", EksHybridAwsCredentialsPath, err)
 	}
 
@@ -53,6 +53,15 @@ func (s *SigningHelperDaemon) Configure() error {
 	return nil
 }
 
+// RenderConfig returns the systemd unit written by Configure without writing it.
+func (s *SigningHelperDaemon) RenderConfig() ([]util.RenderedFile, error) {
+	service, err := GenerateUpdateSystemdService(s.node)
+	if err != nil {
+		return nil, err
+	}
+	return []util.RenderedFile{{Path: SigningHelperServiceFilePath, Content: service, Perms: 0o644}}, nil
+}
+
 // EnsureRunning enables and starts the aws_signing_helper unit.
 func (s *SigningHelperDaemon) EnsureRunning(ctx context.Context) error {
 	err := s.daemonManager.EnableDaemon(s.Name())
@@ -64,7 +73,7 @@ func (s *SigningHelperDaemon) EnsureRunning(ctx context.Context) error {
 
 // PostLaunch runs any additional step that needs to occur after the service
 // daemon as been started.
-func (s *SigningHelperDaemon) PostLaunch() error {
+func (s *SigningHelperDaemon) PostLaunch(_ context.Context) error {
 	return nil
 }
 
@@ -90,7 +99,7 @@ func GenerateUpdateSystemdService(node *api.NodeConfig) ([]byte, error) {
 		"Region":                    node.Spec.Cluster.Region,
 		"NodeName":                  node.Spec.Hybrid.IAMRolesAnywhere.NodeName,
 		"CertificatePath":           node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath,
-		"PrivateKeyPath":            node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath,
+		"PrivateKeyPath":            PrivateKeyFile(node.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath),
 	}); err != nil {
 		return nil, fmt.Errorf("executing aws_signing_helper_update service template: %w", err)
 	}
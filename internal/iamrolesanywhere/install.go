@@ -2,7 +2,6 @@ package iamrolesanywhere
 
 import (
 	"context"
-	"os"
 	"path"
 	"path/filepath"
 
@@ -10,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -73,7 +73,7 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	}
 	defer signingHelper.Close()
 
-	if err := artifact.InstallFile(filepath.Join(opts.InstallRoot, SigningHelperBinPath), signingHelper, artifactFilePerms); err != nil {
+	if err := host.FromContext(ctx).WriteFile(filepath.Join(opts.InstallRoot, SigningHelperBinPath), signingHelper, artifactFilePerms); err != nil {
 		return errors.Wrap(err, "installing aws_signing_helper")
 	}
 
@@ -81,25 +81,35 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 		return errors.Errorf("aws_signing_helper checksum mismatch: %v", artifact.NewChecksumError(signingHelper))
 	}
 
+	opts.Tracker.Record(artifact.IamRolesAnywhere, SigningHelperBinPath, signingHelper)
+
 	return nil
 }
 
-func Uninstall() error {
-	if err := os.RemoveAll(SigningHelperServiceFilePath); err != nil {
+func Uninstall(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	if err := h.RemoveAll(SigningHelperServiceFilePath); err != nil {
+		return err
+	}
+	if err := h.RemoveAll(path.Dir(EksHybridAwsCredentialsPath)); err != nil {
 		return err
 	}
-	if err := os.RemoveAll(path.Dir(EksHybridAwsCredentialsPath)); err != nil {
+	if err := h.RemoveAll(path.Dir(ResolvedPrivateKeyPath)); err != nil {
 		return err
 	}
-	return os.RemoveAll(SigningHelperBinPath)
+	return h.RemoveAll(SigningHelperBinPath)
 }
 
-func Upgrade(ctx context.Context, signingHelperSrc SigningHelperSource, log *zap.Logger) error {
+func Upgrade(ctx context.Context, signingHelperSrc SigningHelperSource, tr *tracker.Tracker, log *zap.Logger) error {
 	signingHelper, err := signingHelperSrc.GetSigningHelper(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting aws_signing_helper source")
 	}
 	defer signingHelper.Close()
 
-	return artifact.Upgrade(artifactName, SigningHelperBinPath, signingHelper, artifactFilePerms, log)
+	if err := artifact.Upgrade(ctx, artifactName, SigningHelperBinPath, signingHelper, artifactFilePerms, log); err != nil {
+		return err
+	}
+	tr.Record(artifact.IamRolesAnywhere, SigningHelperBinPath, signingHelper)
+	return nil
 }
@@ -0,0 +1,179 @@
+package iamrolesanywhere
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/rolesanywhere"
+	"github.com/aws/aws-sdk-go-v2/service/rolesanywhere/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	certificatePerms = 0o644
+	privateKeyPerms  = 0o600
+)
+
+// TrustAnchorClient gets IAM Roles Anywhere trust anchors.
+type TrustAnchorClient interface {
+	GetTrustAnchor(ctx context.Context, params *rolesanywhere.GetTrustAnchorInput, optFns ...func(*rolesanywhere.Options)) (*rolesanywhere.GetTrustAnchorOutput, error)
+}
+
+// TrustAnchorCertPool returns the CA certificates of a trust anchor backed by a certificate bundle.
+// Trust anchors backed by ACM Private CA don't expose their chain, so their CA bundle needs to be
+// read from a file with ReadCertPool.
+func TrustAnchorCertPool(ctx context.Context, client TrustAnchorClient, trustAnchorARN string) (*x509.CertPool, error) {
+	parsed, err := arn.Parse(trustAnchorARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing trust anchor ARN %s", trustAnchorARN)
+	}
+	id, ok := strings.CutPrefix(parsed.Resource, "trust-anchor/")
+	if !ok {
+		return nil, fmt.Errorf("%s is not a trust anchor ARN", trustAnchorARN)
+	}
+
+	out, err := client.GetTrustAnchor(ctx, &rolesanywhere.GetTrustAnchorInput{TrustAnchorId: aws.String(id)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting trust anchor %s", trustAnchorARN)
+	}
+	if out.TrustAnchor == nil || out.TrustAnchor.Source == nil {
+		return nil, fmt.Errorf("trust anchor %s has no source", trustAnchorARN)
+	}
+
+	data, ok := out.TrustAnchor.Source.SourceData.(*types.SourceDataMemberX509CertificateData)
+	if !ok {
+		return nil, fmt.Errorf("trust anchor %s with source %s doesn't include its CA certificates", trustAnchorARN, out.TrustAnchor.Source.SourceType)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(data.Value)) {
+		return nil, fmt.Errorf("no PEM certificates found in trust anchor %s", trustAnchorARN)
+	}
+	return pool, nil
+}
+
+// ReadCertPool reads the PEM encoded CA certificates in path.
+func ReadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading CA bundle")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// ValidateCertificate checks that privateKey matches the first certificate in certificate and that
+// the certificate is currently valid and chains up to roots. Any other certificate in certificate
+// is used as intermediate. It returns the parsed leaf certificate.
+func ValidateCertificate(certificate, privateKey []byte, roots *x509.CertPool) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair(certificate, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading certificate and private key")
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, der := range pair.Certificate[1:] {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing intermediate certificate")
+		}
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, errors.Wrap(err, "verifying certificate against the trust anchor")
+	}
+
+	return leaf, nil
+}
+
+// ReplaceCertificate replaces the certificate and private key files. Each file is swapped
+// atomically and, if the second swap fails, the first file is restored so the pair stays consistent.
+func ReplaceCertificate(certificatePath, privateKeyPath string, certificate, privateKey []byte) error {
+	oldPrivateKey, err := readIfExists(privateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceFile(privateKeyPath, privateKey, privateKeyPerms); err != nil {
+		return errors.Wrap(err, "replacing private key")
+	}
+
+	if err := replaceFile(certificatePath, certificate, certificatePerms); err != nil {
+		err = errors.Wrap(err, "replacing certificate")
+		if oldPrivateKey == nil {
+			return err
+		}
+		if restoreErr := replaceFile(privateKeyPath, oldPrivateKey, privateKeyPerms); restoreErr != nil {
+			return fmt.Errorf("%w; restoring private key: %v", err, restoreErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func readIfExists(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// replaceFile writes data to a temporary file next to path and renames it over path, so
+// readers see either the old or the new content. It keeps the permissions of the existing file.
+func replaceFile(path string, data []byte, perms fs.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perms = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perms); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,211 @@
+package iamrolesanywhere_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rolesanywhere"
+	"github.com/aws/aws-sdk-go-v2/service/rolesanywhere/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+)
+
+const trustAnchorARN = "arn:aws:rolesanywhere:us-west-2:123456789012:trust-anchor/0123-abcd"
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(g *WithT) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	g.Expect(err).NotTo(HaveOccurred())
+	return testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue returns a PEM encoded certificate signed by ca and its PEM encoded private key.
+func (ca testCA) issue(g *WithT, notBefore, notAfter time.Time) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "my-node"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	g.Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestValidateCertificate(t *testing.T) {
+	g := NewWithT(t)
+	ca := newTestCA(g)
+	otherCA := newTestCA(g)
+	validCert, validKey := ca.issue(g, time.Now().Add(-time.Hour), time.Now().AddDate(0, 6, 0))
+	_, otherKey := ca.issue(g, time.Now().Add(-time.Hour), time.Now().AddDate(0, 6, 0))
+	expiredCert, expiredKey := ca.issue(g, time.Now().AddDate(0, -2, 0), time.Now().AddDate(0, -1, 0))
+	untrustedCert, untrustedKey := otherCA.issue(g, time.Now().Add(-time.Hour), time.Now().AddDate(0, 6, 0))
+
+	testCases := []struct {
+		name    string
+		cert    []byte
+		key     []byte
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cert: validCert,
+			key:  validKey,
+		},
+		{
+			name:    "key doesn't match",
+			cert:    validCert,
+			key:     otherKey,
+			wantErr: "loading certificate and private key",
+		},
+		{
+			name:    "expired",
+			cert:    expiredCert,
+			key:     expiredKey,
+			wantErr: "verifying certificate against the trust anchor",
+		},
+		{
+			name:    "signed by another CA",
+			cert:    untrustedCert,
+			key:     untrustedKey,
+			wantErr: "verifying certificate against the trust anchor",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			leaf, err := iamrolesanywhere.ValidateCertificate(tc.cert, tc.key, ca.pool())
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(leaf.Subject.CommonName).To(Equal("my-node"))
+		})
+	}
+}
+
+type fakeTrustAnchorClient struct {
+	id  string
+	out *rolesanywhere.GetTrustAnchorOutput
+	err error
+}
+
+func (f *fakeTrustAnchorClient) GetTrustAnchor(_ context.Context, in *rolesanywhere.GetTrustAnchorInput, _ ...func(*rolesanywhere.Options)) (*rolesanywhere.GetTrustAnchorOutput, error) {
+	f.id = aws.ToString(in.TrustAnchorId)
+	return f.out, f.err
+}
+
+func TestTrustAnchorCertPool(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ca := newTestCA(g)
+	cert, key := ca.issue(g, time.Now().Add(-time.Hour), time.Now().AddDate(0, 6, 0))
+
+	client := &fakeTrustAnchorClient{
+		out: &rolesanywhere.GetTrustAnchorOutput{
+			TrustAnchor: &types.TrustAnchorDetail{
+				Source: &types.Source{
+					SourceType: types.TrustAnchorTypeCertificateBundle,
+					SourceData: &types.SourceDataMemberX509CertificateData{Value: string(ca.pem)},
+				},
+			},
+		},
+	}
+	pool, err := iamrolesanywhere.TrustAnchorCertPool(ctx, client, trustAnchorARN)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.id).To(Equal("0123-abcd"))
+	_, err = iamrolesanywhere.ValidateCertificate(cert, key, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	client.out.TrustAnchor.Source = &types.Source{
+		SourceType: types.TrustAnchorTypeAwsAcmPca,
+		SourceData: &types.SourceDataMemberAcmPcaArn{Value: "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/ca"},
+	}
+	_, err = iamrolesanywhere.TrustAnchorCertPool(ctx, client, trustAnchorARN)
+	g.Expect(err).To(MatchError(ContainSubstring("with source AWS_ACM_PCA doesn't include its CA certificates")))
+
+	client.err = errors.New("access denied")
+	_, err = iamrolesanywhere.TrustAnchorCertPool(ctx, client, trustAnchorARN)
+	g.Expect(err).To(MatchError(ContainSubstring("access denied")))
+
+	_, err = iamrolesanywhere.TrustAnchorCertPool(ctx, client, "arn:aws:rolesanywhere:us-west-2:123456789012:profile/0123")
+	g.Expect(err).To(MatchError(ContainSubstring("is not a trust anchor ARN")))
+}
+
+func TestReplaceCertificate(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "server.pem")
+	keyPath := filepath.Join(dir, "server.key")
+	g.Expect(os.WriteFile(certPath, []byte("old cert"), 0o640)).To(Succeed())
+	g.Expect(os.WriteFile(keyPath, []byte("old key"), 0o600)).To(Succeed())
+
+	g.Expect(iamrolesanywhere.ReplaceCertificate(certPath, keyPath, []byte("new cert"), []byte("new key"))).To(Succeed())
+
+	g.Expect(os.ReadFile(certPath)).To(BeEquivalentTo("new cert"))
+	g.Expect(os.ReadFile(keyPath)).To(BeEquivalentTo("new key"))
+	info, err := os.Stat(certPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o640)))
+	entries, err := os.ReadDir(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(2))
+}
+
+func TestReplaceCertificateRestoresKeyOnFailure(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "server.key")
+	g.Expect(os.WriteFile(keyPath, []byte("old key"), 0o600)).To(Succeed())
+	// The certificate can't be written because its parent is a file.
+	certPath := filepath.Join(keyPath, "server.pem")
+
+	err := iamrolesanywhere.ReplaceCertificate(certPath, keyPath, []byte("new cert"), []byte("new key"))
+	g.Expect(err).To(MatchError(ContainSubstring("replacing certificate")))
+	g.Expect(os.ReadFile(keyPath)).To(BeEquivalentTo("old key"))
+}
@@ -3,6 +3,7 @@ package iamrolesanywhere
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rolesanywhere"
@@ -57,3 +58,56 @@ func (a AccessValidator) Run(ctx context.Context, informer validation.Informer,
 
 	return nil
 }
+
+// DefaultCertificateExpiryWarningDays is how many days before the certificate expires
+// CertificateExpiryValidator starts warning.
+const DefaultCertificateExpiryWarningDays = 30
+
+// CertificateExpiryValidator validates the IAM Roles Anywhere certificate is not expired
+// and warns when it's about to.
+type CertificateExpiryValidator struct {
+	warningDays int
+	now         func() time.Time
+}
+
+// NewCertificateExpiryValidator returns a new CertificateExpiryValidator that warns
+// warningDays before the certificate expires.
+func NewCertificateExpiryValidator(warningDays int) CertificateExpiryValidator {
+	return CertificateExpiryValidator{
+		warningDays: warningDays,
+		now:         time.Now,
+	}
+}
+
+func (v CertificateExpiryValidator) Run(ctx context.Context, informer validation.Informer, node *api.NodeConfig) error {
+	var err error
+	informer.Starting(ctx, "iam-roles-anywhere-certificate-expiry", "Validating IAM Roles Anywhere certificate expiration")
+	defer func() {
+		informer.Done(ctx, "iam-roles-anywhere-certificate-expiry", err)
+	}()
+
+	certPath := node.Spec.Hybrid.IAMRolesAnywhere.CertificatePath
+	if certPath == "" {
+		certPath = DefaultCertificatePath
+	}
+
+	cert, err := ReadCertificate(certPath)
+	if err != nil {
+		err = validation.WithRemediation(err, "Ensure the IAM Roles Anywhere certificate exists and is a valid PEM encoded certificate")
+		return err
+	}
+
+	now := v.now()
+	remediation := "Issue a new certificate from your trust anchor CA and install it with 'nodeadm credentials rotate'"
+	if now.After(cert.NotAfter) {
+		err = validation.WithRemediation(fmt.Errorf("certificate %s expired on %s", certPath, cert.NotAfter.UTC().Format(time.RFC3339)), remediation)
+		return err
+	}
+
+	if left := cert.NotAfter.Sub(now); left < time.Duration(v.warningDays)*24*time.Hour {
+		err = validation.NewWarning(fmt.Errorf("certificate %s expires in %d days, on %s", certPath, int(left.Hours()/24), cert.NotAfter.UTC().Format(time.RFC3339)), remediation)
+		return err
+	}
+
+	return nil
+}
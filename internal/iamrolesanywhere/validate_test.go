@@ -3,7 +3,10 @@ package iamrolesanywhere_test
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	. "github.com/onsi/gomega"
@@ -79,3 +82,67 @@ func TestAccessValidatorRunFail(t *testing.T) {
 	g.Expect(informer.DoneWith).To(MatchError(ContainSubstring("checking connection to IAM Roles Anywhere endpoint")))
 	g.Expect(validation.Remediation(informer.DoneWith)).To(ContainSubstring("Ensure your network configuration allows access to the AWS IAM Roles Anywhere API endpoint"))
 }
+
+func TestCertificateExpiryValidator(t *testing.T) {
+	g := NewWithT(t)
+	ca := newTestCA(g)
+
+	testCases := []struct {
+		name        string
+		notAfter    time.Time
+		missing     bool
+		wantErr     string
+		wantWarning bool
+	}{
+		{
+			name:     "valid for longer than the warning window",
+			notAfter: time.Now().AddDate(0, 6, 0),
+		},
+		{
+			name:        "expires within the warning window",
+			notAfter:    time.Now().AddDate(0, 0, 10),
+			wantErr:     "expires in 9 days",
+			wantWarning: true,
+		},
+		{
+			name:     "expired",
+			notAfter: time.Now().Add(-time.Hour),
+			wantErr:  "expired on",
+		},
+		{
+			name:    "missing certificate",
+			missing: true,
+			wantErr: "reading IAM Roles Anywhere certificate",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			certPath := filepath.Join(t.TempDir(), "server.pem")
+			if !tc.missing {
+				cert, _ := ca.issue(g, time.Now().AddDate(0, -1, 0), tc.notAfter)
+				g.Expect(os.WriteFile(certPath, cert, 0o644)).To(Succeed())
+			}
+			node := &api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Hybrid: &api.HybridOptions{
+						IAMRolesAnywhere: &api.IAMRolesAnywhere{CertificatePath: certPath},
+					},
+				},
+			}
+
+			informer := test.NewFakeInformer()
+			err := iamrolesanywhere.NewCertificateExpiryValidator(iamrolesanywhere.DefaultCertificateExpiryWarningDays).Run(ctx, informer, node)
+			g.Expect(informer.Started).To(BeTrue())
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(informer.DoneWith).To(BeNil())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			g.Expect(informer.DoneWith).To(Equal(err))
+			g.Expect(validation.IsWarning(err)).To(Equal(tc.wantWarning))
+		})
+	}
+}
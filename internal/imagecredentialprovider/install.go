@@ -2,7 +2,6 @@ package imagecredentialprovider
 
 import (
 	"context"
-	"os"
 	"path"
 	"path/filepath"
 
@@ -10,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -75,7 +75,7 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	}
 	defer imageCredentialProvider.Close()
 
-	if err := artifact.InstallFile(filepath.Join(opts.InstallRoot, BinPath), imageCredentialProvider, artifactFilePerms); err != nil {
+	if err := host.FromContext(ctx).WriteFile(filepath.Join(opts.InstallRoot, BinPath), imageCredentialProvider, artifactFilePerms); err != nil {
 		return errors.Wrap(err, "installing image-credential-provider")
 	}
 
@@ -83,19 +83,26 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 		return errors.Errorf("image-credential-provider checksum mismatch: %v", artifact.NewChecksumError(imageCredentialProvider))
 	}
 
+	opts.Tracker.Record(artifact.ImageCredentialProvider, BinPath, imageCredentialProvider)
+
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(path.Dir(BinPath))
+func Uninstall(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	return h.RemoveAll(path.Dir(BinPath))
 }
 
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+func Upgrade(ctx context.Context, src Source, tr *tracker.Tracker, log *zap.Logger) error {
 	imageCredentialProvider, err := src.GetImageCredentialProvider(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting image-credential-provider source")
 	}
 	defer imageCredentialProvider.Close()
 
-	return artifact.Upgrade(artifactName, BinPath, imageCredentialProvider, artifactFilePerms, log)
+	if err := artifact.Upgrade(ctx, artifactName, BinPath, imageCredentialProvider, artifactFilePerms, log); err != nil {
+		return err
+	}
+	tr.Record(artifact.ImageCredentialProvider, BinPath, imageCredentialProvider)
+	return nil
 }
@@ -2,13 +2,13 @@ package kubectl
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -74,7 +74,7 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	}
 	defer kubectl.Close()
 
-	if err := artifact.InstallFile(filepath.Join(opts.InstallRoot, BinPath), kubectl, artifactFilePerms); err != nil {
+	if err := host.FromContext(ctx).WriteFile(filepath.Join(opts.InstallRoot, BinPath), kubectl, artifactFilePerms); err != nil {
 		return errors.Wrap(err, "installing kubectl")
 	}
 
@@ -82,19 +82,26 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 		return errors.Errorf("kubectl checksum mismatch: %v", artifact.NewChecksumError(kubectl))
 	}
 
+	opts.Tracker.Record(artifact.Kubectl, BinPath, kubectl)
+
 	return nil
 }
 
-func Uninstall() error {
-	return os.RemoveAll(BinPath)
+func Uninstall(ctx context.Context) error {
+	h := host.FromContext(ctx)
+	return h.RemoveAll(BinPath)
 }
 
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+func Upgrade(ctx context.Context, src Source, tr *tracker.Tracker, log *zap.Logger) error {
 	kubectl, err := src.GetKubectl(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting kubectl source")
 	}
 	defer kubectl.Close()
 
-	return artifact.Upgrade(artifactName, BinPath, kubectl, artifactFilePerms, log)
+	if err := artifact.Upgrade(ctx, artifactName, BinPath, kubectl, artifactFilePerms, log); err != nil {
+		return err
+	}
+	tr.Record(artifact.Kubectl, BinPath, kubectl)
+	return nil
 }
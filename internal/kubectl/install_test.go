@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/kubectl"
 	"github.com/aws/eks-hybrid/internal/test"
@@ -30,6 +31,7 @@ func TestInstall(t *testing.T) {
 		},
 		Verify: func(g *GomegaWithT, tempDir string, tr *tracker.Tracker) {
 			g.Expect(tr.Artifacts.Kubectl).To(BeTrue())
+			g.Expect(tr.Records).To(HaveKeyWithValue(artifact.Kubectl, HaveField("InstallPath", kubectl.BinPath)))
 		},
 		VerifyFilePaths: []string{kubectl.BinPath},
 	})
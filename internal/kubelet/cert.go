@@ -6,8 +6,8 @@ import (
 
 const caCertificatePath = "/etc/kubernetes/pki/ca.crt"
 
-// Write the cluster certifcate authority to the filesystem where
+// Render the cluster certifcate authority to the filesystem path where
 // both kubelet and kubeconfig can read it
-func writeClusterCaCert(caCert []byte) error {
-	return util.WriteFileWithDir(caCertificatePath, caCert, kubeletConfigPerm)
+func renderClusterCaCert(caCert []byte) util.RenderedFile {
+	return util.RenderedFile{Path: caCertificatePath, Content: caCert, Perms: kubeletConfigPerm}
 }
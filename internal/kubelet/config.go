@@ -39,7 +39,8 @@ const (
 	kubeletConfigDir  = "config.json.d"
 	kubeletConfigPerm = 0o644
 
-	hybridNodeLabel            = "eks.amazonaws.com/compute-type=hybrid"
+	hybridNodeLabelKey         = "eks.amazonaws.com/compute-type"
+	hybridNodeLabel            = hybridNodeLabelKey + "=hybrid"
 	credentialProviderLabelKey = "eks.amazonaws.com/hybrid-credential-provider"
 
 	hybridProviderIdPrefix = "eks-hybrid"
@@ -47,17 +48,17 @@ const (
 
 var nodeNameProviderIdRegexPattern = regexp.MustCompile(`^eks-hybrid:///[^/]+/[^/]+/(.+)$`)
 
-func (k *kubelet) writeKubeletConfig() error {
+func (k *kubelet) renderKubeletConfig() ([]util.RenderedFile, error) {
 	kubeletVersion, err := GetKubeletVersion()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// tracking: https://github.com/kubernetes/enhancements/issues/3983
 	// for enabling drop-in configuration
 	if semver.Compare(kubeletVersion, "v1.29.0") < 0 {
-		return k.writeKubeletConfigToFile()
+		return k.renderKubeletConfigToFile()
 	} else {
-		return k.writeKubeletConfigToDir()
+		return k.renderKubeletConfigToDir()
 	}
 }
 
@@ -66,32 +67,34 @@ func (k *kubelet) writeKubeletConfig() error {
 // KubeletConfiguration types:
 // https://pkg.go.dev/k8s.io/kubelet/config/v1beta1#KubeletConfiguration
 type kubeletConfig struct {
-	Address                  string                           `json:"address"`
-	Authentication           k8skubelet.KubeletAuthentication `json:"authentication"`
-	Authorization            k8skubelet.KubeletAuthorization  `json:"authorization"`
-	CgroupDriver             string                           `json:"cgroupDriver"`
-	CgroupRoot               string                           `json:"cgroupRoot"`
-	ClusterDNS               []string                         `json:"clusterDNS"`
-	ClusterDomain            string                           `json:"clusterDomain"`
-	ContainerRuntimeEndpoint string                           `json:"containerRuntimeEndpoint"`
-	EvictionHard             map[string]string                `json:"evictionHard,omitempty"`
-	FeatureGates             map[string]bool                  `json:"featureGates"`
-	HairpinMode              string                           `json:"hairpinMode"`
-	KubeAPIBurst             *int                             `json:"kubeAPIBurst,omitempty"`
-	KubeAPIQPS               *int                             `json:"kubeAPIQPS,omitempty"`
-	KubeReserved             map[string]string                `json:"kubeReserved,omitempty"`
-	KubeReservedCgroup       *string                          `json:"kubeReservedCgroup,omitempty"`
-	Logging                  loggingConfiguration             `json:"logging"`
-	MaxPods                  int32                            `json:"maxPods,omitempty"`
-	ProtectKernelDefaults    bool                             `json:"protectKernelDefaults"`
-	ProviderID               *string                          `json:"providerID,omitempty"`
-	ReadOnlyPort             int                              `json:"readOnlyPort"`
-	RegisterWithTaints       []v1.Taint                       `json:"registerWithTaints,omitempty"`
-	SerializeImagePulls      bool                             `json:"serializeImagePulls"`
-	ServerTLSBootstrap       bool                             `json:"serverTLSBootstrap"`
-	SystemReservedCgroup     *string                          `json:"systemReservedCgroup,omitempty"`
-	TLSCipherSuites          []string                         `json:"tlsCipherSuites"`
-	ResolvConf               string                           `json:"resolvConf,omitempty"`
+	Address                  string                              `json:"address"`
+	Authentication           k8skubelet.KubeletAuthentication    `json:"authentication"`
+	Authorization            k8skubelet.KubeletAuthorization     `json:"authorization"`
+	CgroupDriver             string                              `json:"cgroupDriver"`
+	CgroupRoot               string                              `json:"cgroupRoot"`
+	ClusterDNS               []string                            `json:"clusterDNS"`
+	ClusterDomain            string                              `json:"clusterDomain"`
+	ContainerRuntimeEndpoint string                              `json:"containerRuntimeEndpoint"`
+	EvictionHard             map[string]string                   `json:"evictionHard,omitempty"`
+	FailSwapOn               *bool                               `json:"failSwapOn,omitempty"`
+	FeatureGates             map[string]bool                     `json:"featureGates"`
+	HairpinMode              string                              `json:"hairpinMode"`
+	KubeAPIBurst             *int                                `json:"kubeAPIBurst,omitempty"`
+	KubeAPIQPS               *int                                `json:"kubeAPIQPS,omitempty"`
+	KubeReserved             map[string]string                   `json:"kubeReserved,omitempty"`
+	KubeReservedCgroup       *string                             `json:"kubeReservedCgroup,omitempty"`
+	Logging                  loggingConfiguration                `json:"logging"`
+	MaxPods                  int32                               `json:"maxPods,omitempty"`
+	MemorySwap               *k8skubelet.MemorySwapConfiguration `json:"memorySwap,omitempty"`
+	ProtectKernelDefaults    bool                                `json:"protectKernelDefaults"`
+	ProviderID               *string                             `json:"providerID,omitempty"`
+	ReadOnlyPort             int                                 `json:"readOnlyPort"`
+	RegisterWithTaints       []v1.Taint                          `json:"registerWithTaints,omitempty"`
+	SerializeImagePulls      bool                                `json:"serializeImagePulls"`
+	ServerTLSBootstrap       bool                                `json:"serverTLSBootstrap"`
+	SystemReservedCgroup     *string                             `json:"systemReservedCgroup,omitempty"`
+	TLSCipherSuites          []string                            `json:"tlsCipherSuites"`
+	ResolvConf               string                              `json:"resolvConf,omitempty"`
 	metav1.TypeMeta          `json:",inline"`
 }
 
@@ -276,6 +279,20 @@ func (ksc *kubeletConfig) withHybridCloudProvider(cfg *api.NodeConfig, flags map
 	flags["hostname-override"] = cfg.Status.Hybrid.NodeName
 }
 
+// withSwap lets kubelet run with swap enabled when the swap policy is limited, allowing
+// pods to use swap proportionally to their memory requests.
+func (ksc *kubeletConfig) withSwap(cfg *api.NodeConfig, kubeletVersion string) {
+	if cfg.Spec.Instance.Swap != api.SwapPolicyLimited {
+		return
+	}
+	ksc.FailSwapOn = ptr.Bool(false)
+	ksc.MemorySwap = &k8skubelet.MemorySwapConfiguration{SwapBehavior: limitedSwapBehavior}
+	// NodeSwap is beta, but disabled by default, before 1.30
+	if semver.Compare(kubeletVersion, "v1.30.0") < 0 {
+		ksc.FeatureGates[nodeSwapFeatureGate] = true
+	}
+}
+
 func (ksc *kubeletConfig) withHybridNodeLabels(cfg *api.NodeConfig, flags map[string]string) {
 	var labels []string
 	labels = append(labels, hybridNodeLabel)
@@ -387,6 +404,7 @@ func (k *kubelet) GenerateKubeletConfig() (*kubeletConfig, error) {
 	if k.nodeConfig.IsHybridNode() {
 		kubeletConfig.withHybridCloudProvider(k.nodeConfig, k.flags)
 		kubeletConfig.withHybridNodeLabels(k.nodeConfig, k.flags)
+		kubeletConfig.withSwap(k.nodeConfig, kubeletVersion)
 		if err := kubeletConfig.withHybridReservedResources(); err != nil {
 			return nil, err
 		}
@@ -409,58 +427,53 @@ func (k *kubelet) GenerateKubeletConfig() (*kubeletConfig, error) {
 	return &kubeletConfig, nil
 }
 
-// WriteConfig writes the kubelet config to a file.
-// This should only be used for kubelet versions < 1.28.
-func (k *kubelet) writeKubeletConfigToFile() error {
+// renderKubeletConfigToFile renders the kubelet config merged with the user's provided config
+// into a single file. This should only be used for kubelet versions < 1.28.
+func (k *kubelet) renderKubeletConfigToFile() ([]util.RenderedFile, error) {
 	kubeletConfig, err := k.GenerateKubeletConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var kubeletConfigBytes []byte
 	if len(k.nodeConfig.Spec.Kubelet.Config) > 0 {
 		mergedMap, err := util.DocumentMerge(kubeletConfig, k.nodeConfig.Spec.Kubelet.Config, mergo.WithOverride)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if kubeletConfigBytes, err = json.MarshalIndent(mergedMap, "", strings.Repeat(" ", 4)); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		var err error
 		if kubeletConfigBytes, err = json.MarshalIndent(kubeletConfig, "", strings.Repeat(" ", 4)); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	configPath := path.Join(kubeletConfigRoot, kubeletConfigFile)
 	k.flags["config"] = configPath
 
-	zap.L().Info("Writing kubelet config to file..", zap.String("path", configPath))
-	return util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm)
+	return []util.RenderedFile{{Path: configPath, Content: kubeletConfigBytes, Perms: kubeletConfigPerm}}, nil
 }
 
-// WriteKubeletConfigToDir writes nodeadm's generated kubelet config to the
-// standard config file and writes the user's provided config to a directory for
+// renderKubeletConfigToDir renders nodeadm's generated kubelet config to the
+// standard config file and the user's provided config to a directory for
 // drop-in support. This is only supported on kubelet versions >= 1.28. see:
 // https://kubernetes.io/docs/tasks/administer-cluster/kubelet-config-file/#kubelet-conf-d
-func (k *kubelet) writeKubeletConfigToDir() error {
+func (k *kubelet) renderKubeletConfigToDir() ([]util.RenderedFile, error) {
 	kubeletConfig, err := k.GenerateKubeletConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	kubeletConfigBytes, err := json.MarshalIndent(kubeletConfig, "", strings.Repeat(" ", 4))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	configPath := path.Join(kubeletConfigRoot, kubeletConfigFile)
 	k.flags["config"] = configPath
-
-	zap.L().Info("Writing kubelet config to file..", zap.String("path", configPath))
-	if err := util.WriteFileWithDir(configPath, kubeletConfigBytes, kubeletConfigPerm); err != nil {
-		return err
-	}
+	files := []util.RenderedFile{{Path: configPath, Content: kubeletConfigBytes, Perms: kubeletConfigPerm}}
 
 	if len(k.nodeConfig.Spec.Kubelet.Config) > 0 {
 		dirPath := path.Join(kubeletConfigRoot, kubeletConfigDir)
@@ -475,20 +488,17 @@ func (k *kubelet) writeKubeletConfigToDir() error {
 		// config as a valid KubeletConfiguration
 		userKubeletConfigMap, err := util.DocumentMerge(defaultKubeletSubConfig().TypeMeta, k.nodeConfig.Spec.Kubelet.Config)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		zap.L().Info("Writing user kubelet config to drop-in file..", zap.String("path", filePath))
 		userKubeletConfigBytes, err := json.MarshalIndent(userKubeletConfigMap, "", strings.Repeat(" ", 4))
 		if err != nil {
-			return err
-		}
-		if err := util.WriteFileWithDir(filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
-			return err
+			return nil, err
 		}
+		files = append(files, util.RenderedFile{Path: filePath, Content: userKubeletConfigBytes, Perms: kubeletConfigPerm})
 	}
 
-	return nil
+	return files, nil
 }
 
 func getProviderId(availabilityZone, instanceId string) string {
@@ -179,3 +179,24 @@ func TestResolvConf(t *testing.T) {
 	kubeletConfig.withResolvConf(resolvConfPath)
 	assert.Equal(t, kubeletConfig.ResolvConf, resolvConfPath)
 }
+
+func TestRenderKubeletEnvironmentIsSorted(t *testing.T) {
+	k := kubelet{
+		flags: map[string]string{
+			"node-ip":    "10.0.0.1",
+			"config":     "/etc/kubernetes/kubelet/config.json",
+			"kubeconfig": "/var/lib/kubelet/kubeconfig",
+		},
+		environment: map[string]string{
+			"KUBELET_CONFIG_DROPIN_DIR_ALPHA": "on",
+			"AWS_CONFIG_FILE":                 "/etc/aws/hybrid/config",
+		},
+		nodeConfig: &api.NodeConfig{},
+	}
+
+	file := k.renderKubeletEnvironment()
+	assert.Equal(t, kubeletEnvironmentFilePath, file.Path)
+	assert.Equal(t, `AWS_CONFIG_FILE="/etc/aws/hybrid/config"
+KUBELET_CONFIG_DROPIN_DIR_ALPHA="on"
+NODEADM_KUBELET_ARGS="--config=/etc/kubernetes/kubelet/config.json --kubeconfig=/var/lib/kubelet/kubeconfig --node-ip=10.0.0.1"`, string(file.Content))
+}
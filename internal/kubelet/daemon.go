@@ -4,9 +4,11 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/util"
 )
 
 const KubeletDaemonName = "kubelet"
@@ -33,23 +35,43 @@ func NewKubeletDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, a
 	}
 }
 
-func (k *kubelet) Configure() error {
-	if err := k.writeKubeletConfig(); err != nil {
+func (k *kubelet) Configure(ctx context.Context) error {
+	files, err := k.RenderConfig()
+	if err != nil {
 		return err
 	}
-	if err := k.writeKubeconfig(); err != nil {
-		return err
+	for _, file := range files {
+		zap.L().Info("Writing kubelet config to file..", zap.String("path", file.Path))
 	}
-	if err := k.writeImageCredentialProviderConfig(); err != nil {
-		return err
+	return util.WriteRenderedFiles(ctx, files)
+}
+
+// RenderConfig returns the files written by Configure without writing them.
+func (k *kubelet) RenderConfig() ([]util.RenderedFile, error) {
+	// flags and environment are accumulated while rendering, start from scratch
+	// so rendering multiple times produces the same result.
+	k.flags = make(map[string]string)
+	k.environment = make(map[string]string)
+
+	files, err := k.renderKubeletConfig()
+	if err != nil {
+		return nil, err
 	}
-	if err := writeClusterCaCert(k.nodeConfig.Spec.Cluster.CertificateAuthority); err != nil {
-		return err
+	kubeconfig, err := k.renderKubeconfig()
+	if err != nil {
+		return nil, err
 	}
-	if err := k.writeKubeletEnvironment(); err != nil {
-		return err
+	imageCredentialProviderConfig, err := k.renderImageCredentialProviderConfig()
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	files = append(files,
+		kubeconfig,
+		imageCredentialProviderConfig,
+		renderClusterCaCert(k.nodeConfig.Spec.Cluster.CertificateAuthority),
+		k.renderKubeletEnvironment(),
+	)
+	return files, nil
 }
 
 func (k *kubelet) EnsureRunning(ctx context.Context) error {
@@ -63,7 +85,7 @@ func (k *kubelet) EnsureRunning(ctx context.Context) error {
 	return k.daemonManager.RestartDaemon(ctx, KubeletDaemonName)
 }
 
-func (k *kubelet) PostLaunch() error {
+func (k *kubelet) PostLaunch(_ context.Context) error {
 	return nil
 }
 
@@ -0,0 +1,64 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"dario.cat/mergo"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// EffectiveConfig is the configuration kubelet runs with once the node is initialized.
+type EffectiveConfig struct {
+	// Flags are the command line flags, including the user-provided ones.
+	Flags []string `json:"flags"`
+	// Config is the KubeletConfiguration generated by nodeadm merged with the
+	// user-provided config, as kubelet loads it.
+	Config map[string]interface{} `json:"config"`
+}
+
+// GetEffectiveConfig returns the kubelet configuration init renders for cfg without
+// writing any file. The config needs to be enriched first.
+func GetEffectiveConfig(cfg *api.NodeConfig) (*EffectiveConfig, error) {
+	k := &kubelet{
+		nodeConfig:  cfg,
+		environment: make(map[string]string),
+		flags:       make(map[string]string),
+	}
+	files, err := k.RenderConfig()
+	if err != nil {
+		return nil, err
+	}
+	config, err := mergeRenderedKubeletConfig(files, cfg.Spec.Kubelet.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &EffectiveConfig{
+		Flags:  k.kubeletFlags(),
+		Config: config,
+	}, nil
+}
+
+// mergeRenderedKubeletConfig merges the user config into the kubelet config file found
+// in files. Depending on the kubelet version, the user config is either already merged
+// in the file or rendered as a drop-in, merging it again produces the same result.
+func mergeRenderedKubeletConfig(files []util.RenderedFile, userConfig api.InlineDocument) (map[string]interface{}, error) {
+	configPath := path.Join(kubeletConfigRoot, kubeletConfigFile)
+	for _, file := range files {
+		if file.Path != configPath {
+			continue
+		}
+		var config map[string]interface{}
+		if err := json.Unmarshal(file.Content, &config); err != nil {
+			return nil, fmt.Errorf("reading rendered kubelet config: %w", err)
+		}
+		if len(userConfig) == 0 {
+			return config, nil
+		}
+		return util.DocumentMerge(config, userConfig, mergo.WithOverride)
+	}
+	return nil, fmt.Errorf("kubelet config %s wasn't rendered", configPath)
+}
@@ -0,0 +1,41 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+func TestMergeRenderedKubeletConfig(t *testing.T) {
+	files := []util.RenderedFile{
+		{Path: "/etc/kubernetes/kubelet/config.json.d/00-nodeadm.conf", Content: []byte(`{"maxPods": 250}`)},
+		{Path: "/etc/kubernetes/kubelet/config.json", Content: []byte(`{"maxPods": 110, "clusterDomain": "cluster.local"}`)},
+	}
+
+	config, err := mergeRenderedKubeletConfig(files, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"maxPods": float64(110), "clusterDomain": "cluster.local"}, config)
+
+	config, err = mergeRenderedKubeletConfig(files, api.InlineDocument{"maxPods": runtime.RawExtension{Raw: []byte("250")}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"maxPods": float64(250), "clusterDomain": "cluster.local"}, config)
+
+	_, err = mergeRenderedKubeletConfig(files[:1], nil)
+	assert.EqualError(t, err, "kubelet config /etc/kubernetes/kubelet/config.json wasn't rendered")
+}
+
+func TestKubeletFlags(t *testing.T) {
+	k := &kubelet{
+		nodeConfig: &api.NodeConfig{Spec: api.NodeConfigSpec{Kubelet: api.KubeletOptions{Flags: []string{"--node-labels=rack=rack1"}}}},
+		flags:      map[string]string{"node-labels": "eks.amazonaws.com/compute-type=hybrid", "cloud-provider": ""},
+	}
+	assert.Equal(t, []string{
+		"--cloud-provider=",
+		"--node-labels=eks.amazonaws.com/compute-type=hybrid",
+		"--node-labels=rack=rack1",
+	}, k.kubeletFlags())
+}
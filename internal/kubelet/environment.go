@@ -2,6 +2,8 @@ package kubelet
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/aws/eks-hybrid/internal/util"
@@ -12,26 +14,30 @@ const (
 	kubeletArgsEnvironmentName = "NODEADM_KUBELET_ARGS"
 )
 
-// Write environment variables needed for kubelet runtime. This should be the
+// Render environment variables needed for kubelet runtime. This should be the
 // last method called on the kubelet object so that environment side effects of
-// other methods are properly recorded
-func (k *kubelet) writeKubeletEnvironment() error {
+// other methods are properly recorded. Flags and variables are sorted so the
+// rendered file is stable across runs.
+func (k *kubelet) renderKubeletEnvironment() util.RenderedFile {
 	// transform kubelet flags into a single string and write them to the
-	// kubelet environment variable
-	var kubeletFlags []string
-	for flag, value := range k.flags {
-		kubeletFlags = append(kubeletFlags, fmt.Sprintf("--%s=%s", flag, value))
-	}
-	// append user-provided flags at the end to give them precedence
-	kubeletFlags = append(kubeletFlags, k.nodeConfig.Spec.Kubelet.Flags...)
-	// expose these flags via an environment variable scoped to nodeadm
-	k.environment[kubeletArgsEnvironmentName] = strings.Join(kubeletFlags, " ")
+	// kubelet environment variable scoped to nodeadm
+	k.environment[kubeletArgsEnvironmentName] = strings.Join(k.kubeletFlags(), " ")
 	// write additional environment variables
 	var kubeletEnvironment []string
-	for eKey, eValue := range k.environment {
-		kubeletEnvironment = append(kubeletEnvironment, fmt.Sprintf(`%s="%s"`, eKey, eValue))
+	for _, eKey := range slices.Sorted(maps.Keys(k.environment)) {
+		kubeletEnvironment = append(kubeletEnvironment, fmt.Sprintf(`%s="%s"`, eKey, k.environment[eKey]))
+	}
+	return util.RenderedFile{Path: kubeletEnvironmentFilePath, Content: []byte(strings.Join(kubeletEnvironment, "\n")), Perms: kubeletConfigPerm}
+}
+
+// kubeletFlags returns the flags kubelet runs with, sorted, followed by the
+// user-provided flags to give them precedence.
+func (k *kubelet) kubeletFlags() []string {
+	var kubeletFlags []string
+	for _, flag := range slices.Sorted(maps.Keys(k.flags)) {
+		kubeletFlags = append(kubeletFlags, fmt.Sprintf("--%s=%s", flag, k.flags[flag]))
 	}
-	return util.WriteFileWithDir(kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
+	return append(kubeletFlags, k.nodeConfig.Spec.Kubelet.Flags...)
 }
 
 // Add values to the environment variables map in a terse manner
@@ -13,6 +13,7 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -34,7 +35,7 @@ var (
 	imageCredentialProviderConfigPath                      = path.Join(imageCredentialProviderRoot, imageCredentialProviderConfig)
 )
 
-func (k *kubelet) writeImageCredentialProviderConfig() error {
+func (k *kubelet) renderImageCredentialProviderConfig() (util.RenderedFile, error) {
 	// fallback default for image credential provider binary if not overridden
 	ecrCredentialProviderBinPath := path.Join(imageCredentialProviderRoot, "ecr-credential-provider")
 	if binPath, set := os.LookupEnv(ecrCredentialProviderBinPathEnvironmentName); set {
@@ -42,18 +43,18 @@ func (k *kubelet) writeImageCredentialProviderConfig() error {
 		ecrCredentialProviderBinPath = binPath
 	}
 	if err := ensureCredentialProviderBinaryExists(ecrCredentialProviderBinPath); err != nil {
-		return err
+		return util.RenderedFile{}, err
 	}
 
 	config, err := generateImageCredentialProviderConfig(k.nodeConfig, ecrCredentialProviderBinPath)
 	if err != nil {
-		return err
+		return util.RenderedFile{}, err
 	}
 
 	k.flags["image-credential-provider-bin-dir"] = path.Dir(ecrCredentialProviderBinPath)
 	k.flags["image-credential-provider-config"] = imageCredentialProviderConfigPath
 
-	return util.WriteFileWithDir(imageCredentialProviderConfigPath, config, imageCredentialProviderPerm)
+	return util.RenderedFile{Path: imageCredentialProviderConfigPath, Content: config, Perms: imageCredentialProviderPerm}, nil
 }
 
 type imageCredentialProviderTemplateVars struct {
@@ -81,11 +82,11 @@ func generateImageCredentialProviderConfig(cfg *api.NodeConfig, ecrCredentialPro
 
 	var buf bytes.Buffer
 	var imageCredentialProviderTemplate *template.Template
-	if cfg.IsIAMRolesAnywhere() {
-		templateVars.AwsConfigPath = cfg.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath
+	if provider, err := creds.ForNodeConfig(cfg); err == nil && provider.AWSConfigPath(cfg) != "" {
+		templateVars.AwsConfigPath = provider.AWSConfigPath(cfg)
 		imageCredentialProviderTemplate = template.Must(template.New("image-credential-provider").Parse(hybridRolesAnywhereImageCredentialProviderTemplateData))
 	} else {
-		// The regular non-iam roles anywhere credential provider would still work for SSM based installs
+		// The regular credential provider works for SSM based installs, which use the default credential chain
 		imageCredentialProviderTemplate = template.Must(template.New("image-credential-provider").Parse(imageCredentialProviderTemplateData))
 	}
 	if err := imageCredentialProviderTemplate.Execute(&buf, templateVars); err != nil {
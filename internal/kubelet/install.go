@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
@@ -51,7 +52,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 		return errors.Wrap(err, "installing kubelet")
 	}
 
-	if err := installSystemdUnit(filepath.Join(opts.InstallRoot, UnitPath)); err != nil {
+	if err := installSystemdUnit(ctx, filepath.Join(opts.InstallRoot, UnitPath)); err != nil {
 		return errors.Wrap(err, "installing systemd unit")
 	}
 
@@ -82,7 +83,7 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 	}
 	defer kubelet.Close()
 
-	if err := artifact.InstallFile(filepath.Join(opts.InstallRoot, BinPath), kubelet, artifactFilePerms); err != nil {
+	if err := host.FromContext(ctx).WriteFile(filepath.Join(opts.InstallRoot, BinPath), kubelet, artifactFilePerms); err != nil {
 		return errors.Wrap(err, "installing kubelet")
 	}
 
@@ -90,12 +91,14 @@ func downloadFileTo(ctx context.Context, opts InstallOptions) error {
 		return errors.Errorf("kubelet checksum mismatch: %v", artifact.NewChecksumError(kubelet))
 	}
 
+	opts.Tracker.Record(artifact.Kubelet, BinPath, kubelet)
+
 	return nil
 }
 
-func installSystemdUnit(unitPath string) error {
+func installSystemdUnit(ctx context.Context, unitPath string) error {
 	buf := bytes.NewBuffer(kubeletUnitFile)
-	if err := artifact.InstallFile(unitPath, buf, 0o644); err != nil {
+	if err := host.FromContext(ctx).WriteFile(unitPath, buf, 0o644); err != nil {
 		return errors.Errorf("failed to install kubelet systemd unit: %v", err)
 	}
 	return nil
@@ -107,7 +110,7 @@ type UninstallOptions struct {
 	InstallRoot string
 }
 
-func Uninstall(opts UninstallOptions) error {
+func Uninstall(ctx context.Context, opts UninstallOptions) error {
 	pathsToRemove := []string{
 		filepath.Join(opts.InstallRoot, BinPath),
 		filepath.Join(opts.InstallRoot, UnitPath),
@@ -127,8 +130,9 @@ func Uninstall(opts UninstallOptions) error {
 		pathsToRemove = append(pathsToRemove, actualCertPath)
 	}
 
+	h := host.FromContext(ctx)
 	for _, path := range pathsToRemove {
-		if err := os.RemoveAll(path); err != nil {
+		if err := h.RemoveAll(path); err != nil {
 			allErrors = append(allErrors, err)
 		}
 	}
@@ -138,12 +142,27 @@ func Uninstall(opts UninstallOptions) error {
 	return nil
 }
 
-func Upgrade(ctx context.Context, src Source, log *zap.Logger) error {
+func Upgrade(ctx context.Context, src Source, tr *tracker.Tracker, log *zap.Logger) error {
 	kubelet, err := src.GetKubelet(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting kubelet source")
 	}
 	defer kubelet.Close()
 
-	return artifact.Upgrade(artifactName, BinPath, kubelet, artifactFilePerms, log)
+	if err := artifact.Upgrade(ctx, artifactName, BinPath, kubelet, artifactFilePerms, log); err != nil {
+		return err
+	}
+	tr.Record(artifact.Kubelet, BinPath, kubelet)
+	return nil
+}
+
+// ConfigPaths returns the files and directories written when kubelet is installed and configured.
+func ConfigPaths() []string {
+	return []string{
+		UnitPath,
+		kubeconfigPath,
+		kubeletConfigRoot,
+		kubeletEnvironmentFilePath,
+		imageCredentialProviderConfigPath,
+	}
 }
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/aws"
 	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/test"
@@ -75,7 +76,7 @@ func TestUninstall(t *testing.T) {
 				g.Expect(os.Symlink(filepath.Join(tmpDir, actualCertFile), filepath.Join(tmpDir, currentCertFile))).NotTo(HaveOccurred())
 			}
 
-			err := kubelet.Uninstall(kubelet.UninstallOptions{
+			err := kubelet.Uninstall(context.Background(), kubelet.UninstallOptions{
 				InstallRoot: tmpDir,
 			})
 
@@ -126,6 +127,7 @@ func TestInstall(t *testing.T) {
 		},
 		Verify: func(g *GomegaWithT, tempDir string, tr *tracker.Tracker) {
 			g.Expect(tr.Artifacts.Kubelet).To(BeTrue())
+			g.Expect(tr.Records).To(HaveKeyWithValue(artifact.Kubelet, HaveField("InstallPath", kubelet.BinPath)))
 		},
 		VerifyFilePaths: []string{kubelet.BinPath, kubelet.UnitPath},
 	})
@@ -11,6 +11,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/iamauthenticator"
 	"github.com/aws/eks-hybrid/internal/util"
 )
@@ -31,20 +32,20 @@ var (
 	kubeconfigBootstrapPath      = path.Join(kubeconfigRoot, kubeconfigBootstrapFile)
 )
 
-func (k *kubelet) writeKubeconfig() error {
+func (k *kubelet) renderKubeconfig() (util.RenderedFile, error) {
 	kubeconfig, err := generateKubeconfig(k.nodeConfig)
 	if err != nil {
-		return err
+		return util.RenderedFile{}, err
 	}
 	if k.nodeConfig.IsOutpostNode() {
 		// kubelet bootstrap kubeconfig uses aws-iam-authenticator with cluster id to authenticate to cluster
 		//   - if "aws eks describe-cluster" is bypassed, for local outpost, the value of CLUSTER_NAME parameter will be cluster id.
 		//   - otherwise, the cluster id will use the id returned by "aws eks describe-cluster".
 		k.flags["bootstrap-kubeconfig"] = kubeconfigBootstrapPath
-		return util.WriteFileWithDir(kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
+		return util.RenderedFile{Path: kubeconfigBootstrapPath, Content: kubeconfig, Perms: kubeconfigPerm}, nil
 	} else {
 		k.flags["kubeconfig"] = kubeconfigPath
-		return util.WriteFileWithDir(kubeconfigPath, kubeconfig, kubeconfigPerm)
+		return util.RenderedFile{Path: kubeconfigPath, Content: kubeconfig, Perms: kubeconfigPerm}, nil
 	}
 }
 
@@ -73,23 +74,13 @@ func (kct *kubeconfigTemplateVars) withOutpostVars(cfg *api.NodeConfig) {
 }
 
 func (kct *kubeconfigTemplateVars) withHybridTemplateVars(cfg *api.NodeConfig) {
-	if cfg.IsIAMRolesAnywhere() {
-		kct.withIamRolesAnywhereHybridVars(cfg)
-	} else if cfg.IsSSM() {
-		kct.withSsmHybridVars(cfg)
+	kct.Region = cfg.Spec.Cluster.Region
+	if provider, err := creds.ForNodeConfig(cfg); err == nil {
+		kct.AwsConfigPath = provider.AWSConfigPath(cfg)
 	}
 	kct.AwsIamAuthenticatorPath = iamauthenticator.IAMAuthenticatorBinPath
 }
 
-func (kct *kubeconfigTemplateVars) withIamRolesAnywhereHybridVars(cfg *api.NodeConfig) {
-	kct.Region = cfg.Spec.Cluster.Region
-	kct.AwsConfigPath = cfg.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath
-}
-
-func (kct *kubeconfigTemplateVars) withSsmHybridVars(cfg *api.NodeConfig) {
-	kct.Region = cfg.Spec.Cluster.Region
-}
-
 func generateKubeconfig(cfg *api.NodeConfig) ([]byte, error) {
 	config := newKubeconfigTemplateVars(cfg)
 	if cfg.IsOutpostNode() {
@@ -0,0 +1,47 @@
+package kubelet
+
+import (
+	"fmt"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/system"
+)
+
+const (
+	nodeSwapFeatureGate = "NodeSwap"
+	limitedSwapBehavior = "LimitedSwap"
+
+	// minSwapKubeletVersion is the first version where NodeSwap is beta and supports LimitedSwap.
+	minSwapKubeletVersion = "v1.28.0"
+)
+
+// ValidateSwap checks the installed kubelet and the host support the swap policy.
+func ValidateSwap(cfg *api.NodeConfig) error {
+	if cfg.Spec.Instance.Swap != api.SwapPolicyLimited {
+		return nil
+	}
+	kubeletVersion, err := GetKubeletVersion()
+	if err != nil {
+		return err
+	}
+	cgroupV2, err := system.IsCgroupV2()
+	if err != nil {
+		return err
+	}
+	return validateSwap(cfg.Spec.Instance.Swap, kubeletVersion, cgroupV2)
+}
+
+func validateSwap(policy api.SwapPolicy, kubeletVersion string, cgroupV2 bool) error {
+	if policy != api.SwapPolicyLimited {
+		return nil
+	}
+	if semver.Compare(kubeletVersion, minSwapKubeletVersion) < 0 {
+		return fmt.Errorf("swap policy %s requires kubelet %s or later, installed version is %s", policy, minSwapKubeletVersion, kubeletVersion)
+	}
+	if !cgroupV2 {
+		return fmt.Errorf("swap policy %s requires cgroup v2, the host uses cgroup v1", policy)
+	}
+	return nil
+}
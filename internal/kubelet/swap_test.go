@@ -0,0 +1,88 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	k8skubelet "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/aws/eks-hybrid/internal/api"
+)
+
+func TestSwap(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           api.SwapPolicy
+		kubeletVersion   string
+		expectFailSwapOn *bool
+		expectMemorySwap *k8skubelet.MemorySwapConfiguration
+		expectNodeSwap   bool
+	}{
+		{name: "default", kubeletVersion: "v1.31.0"},
+		{name: "disable", policy: api.SwapPolicyDisable, kubeletVersion: "v1.31.0"},
+		{
+			name:             "limited with feature gate",
+			policy:           api.SwapPolicyLimited,
+			kubeletVersion:   "v1.29.4",
+			expectFailSwapOn: ptr.Bool(false),
+			expectMemorySwap: &k8skubelet.MemorySwapConfiguration{SwapBehavior: "LimitedSwap"},
+			expectNodeSwap:   true,
+		},
+		{
+			name:             "limited",
+			policy:           api.SwapPolicyLimited,
+			kubeletVersion:   "v1.30.0",
+			expectFailSwapOn: ptr.Bool(false),
+			expectMemorySwap: &k8skubelet.MemorySwapConfiguration{SwapBehavior: "LimitedSwap"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeletConfig := defaultKubeletSubConfig()
+			kubeletConfig.withSwap(&api.NodeConfig{Spec: api.NodeConfigSpec{Instance: api.InstanceOptions{Swap: test.policy}}}, test.kubeletVersion)
+			assert.Equal(t, test.expectFailSwapOn, kubeletConfig.FailSwapOn)
+			assert.Equal(t, test.expectMemorySwap, kubeletConfig.MemorySwap)
+			_, nodeSwap := kubeletConfig.FeatureGates["NodeSwap"]
+			assert.Equal(t, test.expectNodeSwap, nodeSwap)
+		})
+	}
+}
+
+func TestValidateSwap(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         api.SwapPolicy
+		kubeletVersion string
+		cgroupV2       bool
+		expectedErr    string
+	}{
+		{name: "disable", policy: api.SwapPolicyDisable, kubeletVersion: "v1.27.0"},
+		{name: "limited", policy: api.SwapPolicyLimited, kubeletVersion: "v1.28.0", cgroupV2: true},
+		{
+			name:           "limited on old kubelet",
+			policy:         api.SwapPolicyLimited,
+			kubeletVersion: "v1.27.9",
+			cgroupV2:       true,
+			expectedErr:    "swap policy limited requires kubelet v1.28.0 or later, installed version is v1.27.9",
+		},
+		{
+			name:           "limited on cgroup v1",
+			policy:         api.SwapPolicyLimited,
+			kubeletVersion: "v1.31.0",
+			expectedErr:    "swap policy limited requires cgroup v2, the host uses cgroup v1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSwap(test.policy, test.kubeletVersion, test.cgroupV2)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
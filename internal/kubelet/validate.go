@@ -0,0 +1,128 @@
+package kubelet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	k8skubelet "k8s.io/kubelet/config/v1beta1"
+	sigsjson "sigs.k8s.io/json"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+// managedFlags are the kubelet flags nodeadm might set, depending on the kubelet
+// version and the node configuration. The user-provided flags are passed after them,
+// so a user-provided flag with the same name overrides nodeadm's value.
+var managedFlags = []string{
+	"bootstrap-kubeconfig",
+	"cloud-provider",
+	"config",
+	"config-dir",
+	"container-runtime",
+	"container-runtime-endpoint",
+	"hostname-override",
+	"image-credential-provider-bin-dir",
+	"image-credential-provider-config",
+	"kubeconfig",
+	"pod-infra-container-image",
+}
+
+// ValidateConfig decodes the user-provided kubelet config strictly into a
+// KubeletConfiguration, reporting unknown fields and values of the wrong type.
+func ValidateConfig(ctx context.Context, informer validation.Informer, node *api.NodeConfig) error {
+	var err error
+	informer.Starting(ctx, "kubelet-config", "Validating kubelet configuration")
+	defer func() {
+		informer.Done(ctx, "kubelet-config", err)
+	}()
+	err = validateConfig(node.Spec.Kubelet.Config)
+	return err
+}
+
+func validateConfig(config api.InlineDocument) error {
+	if len(config) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	remediation := "Check the field names and types in spec.kubelet.config against the KubeletConfiguration reference at https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/"
+	var kubeletConfig k8skubelet.KubeletConfiguration
+	strictErrs, err := sigsjson.UnmarshalStrict(data, &kubeletConfig, sigsjson.DisallowUnknownFields)
+	if err != nil {
+		return validation.WithRemediation(fmt.Errorf("invalid kubelet config: %w", err), remediation)
+	}
+	var errs []error
+	for _, strictErr := range strictErrs {
+		errs = append(errs, validation.WithRemediation(fmt.Errorf("invalid kubelet config: %w", strictErr), remediation))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateFlags warns about the user-provided kubelet flags that override a flag
+// managed by nodeadm.
+func ValidateFlags(ctx context.Context, informer validation.Informer, node *api.NodeConfig) error {
+	var err error
+	informer.Starting(ctx, "kubelet-flags", "Validating kubelet flags")
+	defer func() {
+		informer.Done(ctx, "kubelet-flags", err)
+	}()
+
+	conflicts := conflictingFlags(node)
+	if len(conflicts) > 0 {
+		err = validation.NewWarning(
+			fmt.Errorf("kubelet flags override the value set by nodeadm: %s", strings.Join(conflicts, ", ")),
+			"Remove these flags from spec.kubelet.flags unless you intend to replace nodeadm's value",
+		)
+	}
+	return err
+}
+
+// conflictingFlags returns the user-provided flags that nodeadm also sets for node.
+// The --node-labels flag accumulates, so it only conflicts on hybrid nodes when it
+// sets one of the labels nodeadm sets.
+func conflictingFlags(node *api.NodeConfig) []string {
+	managed := slices.Clone(managedFlags)
+	if !node.IsHybridNode() {
+		managed = append(managed, "node-ip")
+	}
+
+	var conflicts []string
+	for _, flag := range node.Spec.Kubelet.Flags {
+		name, value := splitFlag(flag)
+		var conflict string
+		switch {
+		case slices.Contains(managed, name):
+			conflict = "--" + name
+		case name == "node-labels" && node.IsHybridNode():
+			for _, label := range strings.Split(value, ",") {
+				key, _, _ := strings.Cut(strings.TrimSpace(label), "=")
+				if key == hybridNodeLabelKey || key == credentialProviderLabelKey {
+					conflict = "--node-labels " + key
+					break
+				}
+			}
+		}
+		if conflict != "" && !slices.Contains(conflicts, conflict) {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+// splitFlag returns the name and the value of a flag in the form --name=value or
+// --name value.
+func splitFlag(flag string) (string, string) {
+	name := strings.TrimLeft(strings.TrimSpace(flag), "-")
+	if i := strings.IndexAny(name, "= "); i >= 0 {
+		return name[:i], strings.TrimSpace(name[i+1:])
+	}
+	return name, ""
+}
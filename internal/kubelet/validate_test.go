@@ -0,0 +1,111 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func TestValidateKubeletConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   api.InlineDocument
+		wantErrs []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			config: api.InlineDocument{
+				"maxPods":             runtime.RawExtension{Raw: []byte(`110`)},
+				"evictionHard":        runtime.RawExtension{Raw: []byte(`{"memory.available": "100Mi"}`)},
+				"shutdownGracePeriod": runtime.RawExtension{Raw: []byte(`"30s"`)},
+			},
+		},
+		{
+			name: "unknown fields",
+			config: api.InlineDocument{
+				"maxPod":       runtime.RawExtension{Raw: []byte(`110`)},
+				"featureGates": runtime.RawExtension{Raw: []byte(`{"Foo": true}`)},
+				"logging":      runtime.RawExtension{Raw: []byte(`{"formt": "json"}`)},
+			},
+			wantErrs: []string{
+				`invalid kubelet config: unknown field "logging.formt"`,
+				`invalid kubelet config: unknown field "maxPod"`,
+			},
+		},
+		{
+			name: "wrong type",
+			config: api.InlineDocument{
+				"maxPods": runtime.RawExtension{Raw: []byte(`"110"`)},
+			},
+			wantErrs: []string{
+				"invalid kubelet config: json: cannot unmarshal string into Go struct field KubeletConfiguration.maxPods of type int32",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateConfig(test.config)
+			if len(test.wantErrs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var messages []string
+			for _, e := range validation.Unwrap(err) {
+				messages = append(messages, e.Error())
+				assert.True(t, validation.IsRemediable(e))
+			}
+			assert.Equal(t, test.wantErrs, messages)
+		})
+	}
+}
+
+func TestConflictingFlags(t *testing.T) {
+	hybrid := &api.HybridOptions{SSM: &api.SSM{ActivationCode: "code", ActivationID: "id"}}
+	tests := []struct {
+		name   string
+		hybrid *api.HybridOptions
+		flags  []string
+		want   []string
+	}{
+		{
+			name:   "no conflicts",
+			hybrid: hybrid,
+			flags:  []string{"--node-ip=10.0.0.1", "--max-pods=110"},
+		},
+		{
+			name:   "hybrid conflicts",
+			hybrid: hybrid,
+			flags:  []string{"--node-labels=rack=rack1", "--hostname-override=my-node", "-cloud-provider", "--node-labels=gpu=true"},
+			want:   []string{"--hostname-override", "--cloud-provider"},
+		},
+		{
+			name:   "hybrid node labels",
+			hybrid: hybrid,
+			flags:  []string{"--node-labels=rack=rack1,eks.amazonaws.com/compute-type=ec2", "--node-labels eks.amazonaws.com/hybrid-credential-provider=iam-ra"},
+			want:   []string{"--node-labels eks.amazonaws.com/compute-type", "--node-labels eks.amazonaws.com/hybrid-credential-provider"},
+		},
+		{
+			name:  "ec2 conflicts",
+			flags: []string{"--node-labels=rack=rack1", "--node-ip 10.0.0.1", "--kubeconfig=/root/kubeconfig"},
+			want:  []string{"--node-ip", "--kubeconfig"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := &api.NodeConfig{Spec: api.NodeConfigSpec{
+				Hybrid:  test.hybrid,
+				Kubelet: api.KubeletOptions{Flags: test.flags},
+			}}
+			assert.Equal(t, test.want, conflictingFlags(node))
+		})
+	}
+}
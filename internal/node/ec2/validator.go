@@ -7,26 +7,29 @@ import (
 )
 
 func (enp *ec2NodeProvider) withEc2NodeValidators() {
-	enp.validator = func(cfg *api.NodeConfig) error {
-		if cfg.Spec.Cluster.Name == "" {
-			return fmt.Errorf("Name is missing in cluster configuration")
-		}
-		if cfg.Spec.Cluster.APIServerEndpoint == "" {
-			return fmt.Errorf("Apiserver endpoint is missing in cluster configuration")
-		}
-		if cfg.Spec.Cluster.CertificateAuthority == nil {
-			return fmt.Errorf("Certificate authority is missing in cluster configuration")
-		}
-		if cfg.Spec.Cluster.CIDR == "" {
+	enp.validator = ValidateNodeConfig
+}
+
+// ValidateNodeConfig performs the static validation of an EC2 node config.
+func ValidateNodeConfig(cfg *api.NodeConfig) error {
+	if cfg.Spec.Cluster.Name == "" {
+		return fmt.Errorf("Name is missing in cluster configuration")
+	}
+	if cfg.Spec.Cluster.APIServerEndpoint == "" {
+		return fmt.Errorf("Apiserver endpoint is missing in cluster configuration")
+	}
+	if cfg.Spec.Cluster.CertificateAuthority == nil {
+		return fmt.Errorf("Certificate authority is missing in cluster configuration")
+	}
+	if cfg.Spec.Cluster.CIDR == "" {
+		return fmt.Errorf("CIDR is missing in cluster configuration")
+	}
+	if cfg.IsOutpostNode() {
+		if cfg.Spec.Cluster.ID == "" {
 			return fmt.Errorf("CIDR is missing in cluster configuration")
 		}
-		if cfg.IsOutpostNode() {
-			if cfg.Spec.Cluster.ID == "" {
-				return fmt.Errorf("CIDR is missing in cluster configuration")
-			}
-		}
-		return nil
 	}
+	return nil
 }
 
 func (enp *ec2NodeProvider) ValidateConfig() error {
@@ -1,11 +1,26 @@
 package hybrid
 
-import "github.com/aws/eks-hybrid/internal/system"
+import (
+	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/system"
+)
 
 func (hnp *HybridNodeProvider) GetAspects() []system.SystemAspect {
+	return aspects(hnp.nodeConfig, hnp.logger)
+}
+
+// TeardownAspects returns the aspects set up on hybrid nodes, to revert their changes
+// when there is no node config, like on uninstall.
+func TeardownAspects(logger *zap.Logger) []system.SystemAspect {
+	return aspects(&api.NodeConfig{}, logger)
+}
+
+func aspects(nodeConfig *api.NodeConfig, logger *zap.Logger) []system.SystemAspect {
 	return []system.SystemAspect{
-		system.NewSysctlAspect(hnp.nodeConfig),
-		system.NewSwapAspect(hnp.nodeConfig, hnp.logger),
-		system.NewPortsAspect(hnp.nodeConfig, hnp.logger),
+		system.NewSysctlAspect(nodeConfig),
+		system.NewSwapAspect(nodeConfig, logger),
+		system.NewPortsAspect(nodeConfig, logger),
 	}
 }
@@ -4,10 +4,11 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/creds"
 	"github.com/aws/eks-hybrid/internal/daemon"
-	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
 	"github.com/aws/eks-hybrid/internal/kubelet"
 )
 
@@ -31,16 +32,17 @@ func (hnp *HybridNodeProvider) GetDaemons() ([]daemon.Daemon, error) {
 }
 
 func (hnp *HybridNodeProvider) PreProcessDaemon(ctx context.Context) error {
-	if hnp.nodeConfig.IsIAMRolesAnywhere() {
-		if hnp.nodeConfig.Spec.Hybrid.EnableCredentialsFile {
-			hnp.logger.Info("Configuring aws_signing_helper_update daemon")
-			signingHelper := iamrolesanywhere.NewSigningHelperDaemon(hnp.daemonManager, hnp.nodeConfig)
-			if err := signingHelper.Configure(); err != nil {
-				return err
-			}
-			if err := signingHelper.EnsureRunning(ctx); err != nil {
-				return err
-			}
+	provider, err := creds.ForNodeConfig(hnp.nodeConfig)
+	if err != nil {
+		return err
+	}
+	for _, daemon := range provider.Daemons(hnp.daemonManager, hnp.nodeConfig) {
+		hnp.logger.Info("Configuring daemon", zap.String("name", daemon.Name()))
+		if err := daemon.Configure(ctx); err != nil {
+			return err
+		}
+		if err := daemon.EnsureRunning(ctx); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -2,12 +2,7 @@ package hybrid
 
 import (
 	"github.com/aws/eks-hybrid/internal/api"
-	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
-)
-
-const (
-	defaultCertificatePath = "/etc/iam/pki/server.pem"
-	defaultKeyPath         = "/etc/iam/pki/server.key"
+	"github.com/aws/eks-hybrid/internal/creds"
 )
 
 func (hnp *HybridNodeProvider) PopulateNodeConfigDefaults() {
@@ -15,17 +10,7 @@ func (hnp *HybridNodeProvider) PopulateNodeConfigDefaults() {
 }
 
 func PopulateNodeConfigDefaults(nodeConfig *api.NodeConfig) {
-	if nodeConfig.IsIAMRolesAnywhere() {
-		nodeConfig.Status.Hybrid.NodeName = nodeConfig.Spec.Hybrid.IAMRolesAnywhere.NodeName
-		if nodeConfig.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath == "" {
-			nodeConfig.Spec.Hybrid.IAMRolesAnywhere.AwsConfigPath = iamrolesanywhere.DefaultAWSConfigPath
-		}
-
-		if nodeConfig.Spec.Hybrid.IAMRolesAnywhere.CertificatePath == "" {
-			nodeConfig.Spec.Hybrid.IAMRolesAnywhere.CertificatePath = defaultCertificatePath
-		}
-		if nodeConfig.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath == "" {
-			nodeConfig.Spec.Hybrid.IAMRolesAnywhere.PrivateKeyPath = defaultKeyPath
-		}
+	if provider, err := creds.ForNodeConfig(nodeConfig); err == nil {
+		provider.PopulateDefaults(nodeConfig)
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/kubelet"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
 )
 
@@ -42,14 +43,17 @@ func NewHybridNodeProvider(nodeConfig *api.NodeConfig, skipPhases []string, logg
 		network:    &defaultKubeletNetwork{},
 	}
 	np.withHybridValidators()
-	if err := np.withDaemonManager(); err != nil {
-		return nil, err
-	}
 
 	for _, opt := range opts {
 		opt(np)
 	}
 
+	if np.daemonManager == nil {
+		if err := np.withDaemonManager(); err != nil {
+			return nil, err
+		}
+	}
+
 	return np, nil
 }
 
@@ -59,6 +63,13 @@ func WithAWSConfig(config *aws.Config) NodeProviderOpt {
 	}
 }
 
+// WithDaemonManager sets the daemon manager used to configure and run the daemons.
+func WithDaemonManager(manager daemon.DaemonManager) NodeProviderOpt {
+	return func(hnp *HybridNodeProvider) {
+		hnp.daemonManager = manager
+	}
+}
+
 // WithCluster adds an EKS cluster to the HybridNodeProvider for testing purposes.
 func WithCluster(cluster *types.Cluster) NodeProviderOpt {
 	return func(hnp *HybridNodeProvider) {
@@ -95,6 +106,10 @@ func (hnp *HybridNodeProvider) Validate() error {
 		}
 	}
 
+	if err := kubelet.ValidateSwap(hnp.nodeConfig); err != nil {
+		return err
+	}
+
 	if !slices.Contains(hnp.skipPhases, kubeletCertValidation) {
 		if err := ValidateKubeletCert(hnp.logger, hnp.installRoot, hnp.nodeConfig.Spec.Cluster.CertificateAuthority); err != nil {
 			return err
@@ -216,7 +216,7 @@ func (hnp *HybridNodeProvider) ValidateNodeIP() error {
 		// - Hybrid nodes sets --hostname-override to either the IAM-RA Node name or the SSM instance ID, which is checked separately for DNS
 		kubeletArgs := hnp.nodeConfig.Spec.Kubelet.Flags
 		var iamNodeName string
-		if hnp.nodeConfig.IsIAMRolesAnywhere() {
+		if !hnp.nodeConfig.IsSSM() {
 			iamNodeName = hnp.nodeConfig.Status.Hybrid.NodeName
 		}
 		nodeIp, err := getNodeIP(kubeletArgs, iamNodeName, hnp.network)
@@ -0,0 +1,52 @@
+package hybrid
+
+import (
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/drift"
+	"github.com/aws/eks-hybrid/internal/util"
+)
+
+// RenderConfig returns every file init writes for the node configuration without
+// writing any of them. The config needs to be enriched first.
+func (hnp *HybridNodeProvider) RenderConfig() ([]util.RenderedFile, error) {
+	var renderers []drift.Renderer
+	for _, aspect := range hnp.GetAspects() {
+		if renderer, ok := aspect.(drift.Renderer); ok {
+			renderers = append(renderers, renderer)
+		}
+	}
+
+	provider, err := creds.ForNodeConfig(hnp.nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+	for _, daemon := range provider.Daemons(hnp.daemonManager, hnp.nodeConfig) {
+		if renderer, ok := daemon.(drift.Renderer); ok {
+			renderers = append(renderers, renderer)
+		}
+	}
+
+	daemons, err := hnp.GetDaemons()
+	if err != nil {
+		return nil, err
+	}
+	for _, daemon := range daemons {
+		if renderer, ok := daemon.(drift.Renderer); ok {
+			renderers = append(renderers, renderer)
+		}
+	}
+
+	files, err := provider.RenderConfig(hnp.nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, renderer := range renderers {
+		rendered, err := renderer.RenderConfig()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rendered...)
+	}
+	return files, nil
+}
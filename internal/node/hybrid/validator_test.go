@@ -211,3 +211,28 @@ func Test_HybridNodeProviderValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNodeConfigSwap(t *testing.T) {
+	g := NewWithT(t)
+	node := &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				Region: "us-west-2",
+				Name:   "my-cluster",
+			},
+			Hybrid: &api.HybridOptions{
+				SSM: &api.SSM{
+					ActivationCode: "activation-code",
+					ActivationID:   "activation-id",
+				},
+			},
+		},
+	}
+	for _, policy := range []api.SwapPolicy{"", api.SwapPolicyDisable, api.SwapPolicyLimited} {
+		node.Spec.Instance.Swap = policy
+		g.Expect(hybrid.ValidateNodeConfig(node)).To(Succeed())
+	}
+
+	node.Spec.Instance.Swap = "unlimited"
+	g.Expect(hybrid.ValidateNodeConfig(node)).To(MatchError(`invalid swap policy "unlimited", supported policies: [disable, limited]`))
+}
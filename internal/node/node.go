@@ -3,13 +3,16 @@ package node
 import (
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/configprovider"
 	"github.com/aws/eks-hybrid/internal/node/ec2"
 	"github.com/aws/eks-hybrid/internal/node/hybrid"
 	"github.com/aws/eks-hybrid/internal/nodeprovider"
 )
 
-func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logger) (nodeprovider.NodeProvider, error) {
+// NewNodeProvider builds the provider for the node config read from configSource.
+// opts are only applied to hybrid nodes.
+func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logger, opts ...hybrid.NodeProviderOpt) (nodeprovider.NodeProvider, error) {
 	logger.Info("Loading configuration..", zap.String("configSource", configSource))
 	provider, err := configprovider.BuildConfigProvider(configSource)
 	if err != nil {
@@ -19,9 +22,16 @@ func NewNodeProvider(configSource string, skipPhases []string, logger *zap.Logge
 	if err != nil {
 		return nil, err
 	}
+	return NewNodeProviderFromConfig(nodeConfig, skipPhases, logger, opts...)
+}
+
+// NewNodeProviderFromConfig builds the provider for a node config that was already read,
+// so sources that can only be read once, like stdin, are not read again.
+// opts are only applied to hybrid nodes.
+func NewNodeProviderFromConfig(nodeConfig *api.NodeConfig, skipPhases []string, logger *zap.Logger, opts ...hybrid.NodeProviderOpt) (nodeprovider.NodeProvider, error) {
 	if nodeConfig.IsHybridNode() {
 		logger.Info("Setting up hybrid node provider...")
-		return hybrid.NewHybridNodeProvider(nodeConfig, skipPhases, logger)
+		return hybrid.NewHybridNodeProvider(nodeConfig, skipPhases, logger, opts...)
 	}
 	logger.Info("Setting up EC2 node provider...")
 	return ec2.NewEc2NodeProvider(nodeConfig, logger)
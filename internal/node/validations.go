@@ -109,3 +109,74 @@ func getNode(ctx context.Context, nodeName string, clientset kubernetes.Interfac
 
 	return node, err
 }
+
+// WaitForReady polls the API server until the current node reports the Ready condition
+// or ctx is done.
+func WaitForReady(ctx context.Context, interval time.Duration) error {
+	nodeName, err := kubelet.GetNodeName()
+	if err != nil {
+		return errors.Wrap(err, "getting node name from kubelet")
+	}
+
+	clientset, err := kubelet.GetKubeClientFromKubeConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to create kubernetes client")
+	}
+
+	return waitForReady(ctx, nodeName, clientset, interval)
+}
+
+func waitForReady(ctx context.Context, nodeName string, clientset kubernetes.Interface, interval time.Duration) error {
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			lastErr = err
+			return false, nil // continue polling
+		}
+		if isReady(node) {
+			return true, nil
+		}
+		lastErr = fmt.Errorf("node %s is not Ready", nodeName)
+		return false, nil
+	})
+	if err != nil && lastErr != nil {
+		return errors.Wrap(lastErr, "waiting for node to be Ready")
+	}
+	return err
+}
+
+func isReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// GetReadyCondition returns the name of the current node and its Ready condition.
+// Unlike IsInitialized, it doesn't retry, so it returns quickly if the API server is not reachable.
+func GetReadyCondition(ctx context.Context) (string, *v1.NodeCondition, error) {
+	nodeName, err := kubelet.GetNodeName()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "getting node name from kubelet")
+	}
+
+	clientset, err := kubelet.GetKubeClientFromKubeConfig()
+	if err != nil {
+		return nodeName, nil, errors.Wrap(err, "failed to create kubernetes client")
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nodeName, nil, errors.Wrapf(err, "getting node %s", nodeName)
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return nodeName, &condition, nil
+		}
+	}
+	return nodeName, nil, nil
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	. "github.com/onsi/gomega"
@@ -108,3 +109,58 @@ func Test_getNode(t *testing.T) {
 		})
 	}
 }
+
+func Test_waitForReady(t *testing.T) {
+	nodeName := "test"
+	testCases := []struct {
+		name       string
+		condition  corev1.ConditionStatus
+		createNode bool
+		wantErr    string
+	}{
+		{
+			name:       "ready",
+			condition:  corev1.ConditionTrue,
+			createNode: true,
+		},
+		{
+			name:       "not ready",
+			condition:  corev1.ConditionFalse,
+			createNode: true,
+			wantErr:    "node test is not Ready",
+		},
+		{
+			name:    "node not found",
+			wantErr: "not found",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			client := fake.NewSimpleClientset()
+			if tc.createNode {
+				node := &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName,
+					},
+					Status: corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{
+							{Type: corev1.NodeReady, Status: tc.condition},
+						},
+					},
+				}
+				_, _ = client.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			err := waitForReady(ctx, nodeName, client, time.Millisecond)
+
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
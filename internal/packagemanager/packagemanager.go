@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,15 +16,17 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/artifact"
 	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/system"
-	"github.com/aws/eks-hybrid/internal/util"
 	"github.com/aws/eks-hybrid/internal/util/cmd"
 )
 
 const (
-	aptPackageManager  = "apt"
-	snapPackageManager = "snap"
-	yumPackageManager  = "yum"
+	aptPackageManager    = "apt"
+	dnfPackageManager    = "dnf"
+	snapPackageManager   = "snap"
+	yumPackageManager    = "yum"
+	zypperPackageManager = "zypper"
 
 	snapInstallVerb = "install"
 	snapUpdateVerb  = "refresh"
@@ -32,12 +36,17 @@ const (
 	yumUtilsManagerPkg          = "yum-utils"
 	centOsDockerRepo            = "https://download.docker.com/linux/centos/docker-ce.repo"
 	ubuntuDockerRepo            = "https://download.docker.com/linux/ubuntu"
-	ubuntuDockerGpgKey          = "https://download.docker.com/linux/ubuntu/gpg"
+	debianDockerRepo            = "https://download.docker.com/linux/debian"
 	ubuntuDockerGpgKeyPath      = "/etc/apt/keyrings/docker.asc"
 	ubuntuDockerGpgKeyFilePerms = 0o755
 	aptDockerRepoSourceFilePath = "/etc/apt/sources.list.d/docker.list"
 	yumDockerRepoSourceFilePath = "/etc/yum.repos.d/docker-ce.repo"
 
+	aptMark                 = "apt-mark"
+	versionlockVerb         = "versionlock"
+	dnfVersionlockPluginPkg = "python3-dnf-plugin-versionlock"
+	yumVersionlockPluginPkg = "yum-plugin-versionlock"
+
 	containerdDistroPkgName = "containerd"
 	containerdDockerPkgName = "containerd.io"
 	runcPkgName             = "runc"
@@ -47,10 +56,7 @@ const (
 	ssmPkgName      = "amazon-ssm-agent"
 )
 
-var aptDockerRepoConfig = fmt.Sprintf("deb [arch=%s signed-by=%s] %s %s stable\n", runtime.GOARCH, ubuntuDockerGpgKeyPath,
-	ubuntuDockerRepo, system.GetVersionCodeName())
-
-// DistroPackageManager defines a new package manager using apt or yum
+// DistroPackageManager defines a new package manager using apt, dnf, yum or zypper
 type DistroPackageManager struct {
 	manager             string
 	installVerb         string
@@ -76,16 +82,41 @@ func New(containerdSource containerd.SourceName, logger *zap.Logger) (*DistroPac
 		refreshMetadataVerb: packageManagerMetadataRefreshCmd[manager],
 	}
 	if containerdSource == containerd.ContainerdSourceDocker {
-		pm.dockerRepo = managerToDockerRepoMap[manager]
+		pm.dockerRepo = dockerRepo(manager, system.GetOsRelease())
+		if pm.dockerRepo == "" {
+			return nil, fmt.Errorf("docker repos are not available for package manager %s", manager)
+		}
 	}
 	return pm, nil
 }
 
+// dockerRepo returns the docker-ce repository for the package manager and operating system.
+// It returns empty if docker doesn't publish one.
+func dockerRepo(manager string, osRelease system.OsRelease) string {
+	switch manager {
+	case yumPackageManager, dnfPackageManager:
+		return centOsDockerRepo
+	case aptPackageManager:
+		if osRelease.Family() == system.DebianOsName {
+			return debianDockerRepo
+		}
+		return ubuntuDockerRepo
+	default:
+		return ""
+	}
+}
+
+// usesYum returns true if the package manager reads yum repos and rpm versions,
+// which dnf shares with yum.
+func (pm *DistroPackageManager) usesYum() bool {
+	return pm.manager == yumPackageManager || pm.manager == dnfPackageManager
+}
+
 // Configure configures the package manager.
 func (pm *DistroPackageManager) Configure(ctx context.Context) error {
 	// Add docker repos to the package manager
 	if pm.dockerRepo != "" {
-		if pm.manager == yumPackageManager {
+		if pm.usesYum() {
 			return pm.configureYumPackageManagerWithDockerRepo(ctx)
 		}
 		if pm.manager == aptPackageManager {
@@ -118,8 +149,8 @@ func (pm *DistroPackageManager) configureYumPackageManagerWithDockerRepo(ctx con
 		return errors.Wrapf(err, "failed to locate yum utils manager in $PATH")
 	}
 	pm.logger.Info("Adding docker repo to package manager...")
-	configureCmd := exec.Command(yumUtilsManagerPath, "--add-repo", centOsDockerRepo)
-	out, err := configureCmd.CombinedOutput()
+	configureCmd := exec.Command(yumUtilsManagerPath, "--add-repo", pm.dockerRepo)
+	out, err := host.FromContext(ctx).Run(configureCmd)
 	if err != nil {
 		return errors.Wrapf(err, "failed adding docker repo to package manager: %s", out)
 	}
@@ -137,18 +168,19 @@ func (pm *DistroPackageManager) configureAptPackageManagerWithDockerRepo(ctx con
 	}
 
 	// Download docker gpg key and write it to file
-	resp, err := http.Get(ubuntuDockerGpgKey)
+	resp, err := http.Get(pm.dockerRepo + "/gpg")
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if err := util.WriteFileWithDirFromReader(ubuntuDockerGpgKeyPath, resp.Body, ubuntuDockerGpgKeyFilePerms); err != nil {
+	h := host.FromContext(ctx)
+	if err := h.WriteFile(ubuntuDockerGpgKeyPath, resp.Body, ubuntuDockerGpgKeyFilePerms); err != nil {
 		return err
 	}
 
 	// Add docker repo config for ubuntu-apt to apt sources
-	if err := util.WriteFileWithDir(aptDockerRepoSourceFilePath, []byte(aptDockerRepoConfig), ubuntuDockerGpgKeyFilePerms); err != nil {
+	if err := h.WriteFile(aptDockerRepoSourceFilePath, strings.NewReader(pm.aptDockerRepoConfig()), ubuntuDockerGpgKeyFilePerms); err != nil {
 		return err
 	}
 
@@ -161,8 +193,14 @@ func (pm *DistroPackageManager) configureAptPackageManagerWithDockerRepo(ctx con
 	return nil
 }
 
+func (pm *DistroPackageManager) aptDockerRepoConfig() string {
+	return fmt.Sprintf("deb [arch=%s signed-by=%s] %s %s stable\n", runtime.GOARCH, ubuntuDockerGpgKeyPath,
+		pm.dockerRepo, system.GetVersionCodeName())
+}
+
 // uninstallDockerRepo uninstalls docker repos installed by package managers when containerd source is docker
-func (pm *DistroPackageManager) uninstallDockerRepo() error {
+func (pm *DistroPackageManager) uninstallDockerRepo(ctx context.Context) error {
+	h := host.FromContext(ctx)
 	removeRepoFile := func(path, pkgType string) error {
 		_, err := os.Stat(path)
 
@@ -174,7 +212,7 @@ func (pm *DistroPackageManager) uninstallDockerRepo() error {
 				pkgType, path)
 		}
 
-		if err := os.Remove(path); err != nil {
+		if err := h.RemoveAll(path); err != nil {
 			return errors.Wrapf(err, "failed to remove %s docker repo from %s",
 				pkgType, path)
 		}
@@ -183,13 +221,11 @@ func (pm *DistroPackageManager) uninstallDockerRepo() error {
 	}
 
 	switch pm.manager {
-	case yumPackageManager:
-		return removeRepoFile(yumDockerRepoSourceFilePath, yumPackageManager)
+	case yumPackageManager, dnfPackageManager:
+		return removeRepoFile(yumDockerRepoSourceFilePath, pm.manager)
 	case aptPackageManager:
-		if err := os.Remove(ubuntuDockerGpgKeyPath); err != nil {
-			if !os.IsNotExist(err) {
-				return err
-			}
+		if err := h.RemoveAll(ubuntuDockerGpgKeyPath); err != nil {
+			return err
 		}
 
 		return removeRepoFile(aptDockerRepoSourceFilePath, aptPackageManager)
@@ -203,15 +239,37 @@ func (pm *DistroPackageManager) appendPackageVersion(packageName, version string
 		return packageName
 	}
 	switch pm.manager {
-	case yumPackageManager:
+	case yumPackageManager, dnfPackageManager:
 		return fmt.Sprintf("%s-%s", packageName, version)
 	case aptPackageManager:
 		return fmt.Sprintf("%s=%s", packageName, version)
+	case zypperPackageManager:
+		return packageName + zypperVersionCapability(version)
 	default:
 		return packageName
 	}
 }
 
+// zypperVersionCapability converts a version pattern into a zypper capability, since zypper
+// doesn't accept wildcards in versions. A version ending in .* selects the latest version
+// before the next one, so 1.* becomes <2.
+func zypperVersionCapability(version string) string {
+	if prefix, ok := strings.CutSuffix(version, "-*"); ok {
+		return "=" + prefix
+	}
+	prefix, ok := strings.CutSuffix(version, ".*")
+	if !ok {
+		return "=" + version
+	}
+	parts := strings.Split(prefix, ".")
+	last, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "=" + prefix
+	}
+	parts[len(parts)-1] = strconv.Itoa(last + 1)
+	return "<" + strings.Join(parts, ".")
+}
+
 func (pm *DistroPackageManager) getContainerdPackageNameWithVersion(version string) string {
 	containerdPkgName := containerdDistroPkgName
 	if pm.dockerRepo != "" {
@@ -251,9 +309,9 @@ func (pm *DistroPackageManager) GetIptables() artifact.Package {
 
 // GetSSMPackage satisfies the getssmpackage source interface
 func (pm *DistroPackageManager) GetSSMPackage() artifact.Package {
-	// SSM is installed using snap package manager. If apt package manager
-	// is detected, use snap to install/uninstall SSM.
-	if pm.manager == aptPackageManager {
+	// SSM is installed using snap package manager on Ubuntu. Other distros,
+	// including Debian, install it with their own package manager.
+	if pm.manager == aptPackageManager && system.GetOsName() == system.UbuntuOsName {
 		return artifact.NewPackageSource(
 			artifact.NewCmd(snapPackageManager, snapInstallVerb, ssmPkgName),
 			artifact.NewCmd(snapPackageManager, snapRemoveVerb, ssmPkgName),
@@ -291,11 +349,110 @@ func (pm *DistroPackageManager) runcPackage() artifact.Package {
 	)
 }
 
+// ContainerdPackages returns the names of the packages that provide containerd and its runtime.
+func (pm *DistroPackageManager) ContainerdPackages() []string {
+	if pm.dockerRepo != "" {
+		// containerd.io bundles runc
+		return []string{containerdDockerPkgName}
+	}
+	return []string{containerdDistroPkgName, runcPkgName}
+}
+
+// IptablesPackages returns the names of the packages that provide iptables.
+func (pm *DistroPackageManager) IptablesPackages() []string {
+	return []string{iptablesPkgName}
+}
+
+// Hold prevents the package manager, including unattended upgrades, from upgrading
+// or removing packages until they are released. It returns the versionlock plugin
+// package it installed to hold them, empty if it wasn't needed or was already installed.
+func (pm *DistroPackageManager) Hold(ctx context.Context, packages ...string) (string, error) {
+	if len(packages) == 0 {
+		return "", nil
+	}
+	var plugin string
+	if pm.usesYum() && !isRpmInstalled(pm.versionlockPluginName()) {
+		if err := cmd.Retry(ctx, pm.versionlockPluginPackage().InstallCmd, 5*time.Second); err != nil {
+			return "", errors.Wrap(err, "failed to install versionlock plugin using package manager")
+		}
+		plugin = pm.versionlockPluginName()
+	}
+	if err := cmd.Retry(ctx, pm.holdCommand(true, packages), 5*time.Second); err != nil {
+		return plugin, errors.Wrapf(err, "failed to hold packages %v", packages)
+	}
+	return plugin, nil
+}
+
+// RemoveHoldPlugin uninstalls the versionlock plugin installed by Hold.
+func (pm *DistroPackageManager) RemoveHoldPlugin(ctx context.Context, plugin string) error {
+	uninstallCmd := artifact.NewCmd(pm.manager, pm.deleteVerb, plugin, "-y")
+	if err := cmd.Retry(ctx, uninstallCmd.Command, 5*time.Second); err != nil {
+		return errors.Wrap(err, "failed to uninstall versionlock plugin using package manager")
+	}
+	return nil
+}
+
+// Release removes the holds set with Hold.
+func (pm *DistroPackageManager) Release(ctx context.Context, packages ...string) error {
+	if len(packages) == 0 {
+		return nil
+	}
+	if err := cmd.Retry(ctx, pm.holdCommand(false, packages), 5*time.Second); err != nil {
+		return errors.Wrapf(err, "failed to release packages %v", packages)
+	}
+	return nil
+}
+
+func (pm *DistroPackageManager) holdCommand(hold bool, packages []string) cmd.Builder {
+	var name string
+	var args []string
+	switch pm.manager {
+	case aptPackageManager:
+		name = aptMark
+		args = []string{"unhold"}
+		if hold {
+			args = []string{"hold"}
+		}
+	case zypperPackageManager:
+		name = pm.manager
+		args = []string{"removelock"}
+		if hold {
+			args = []string{"addlock"}
+		}
+	default:
+		name = pm.manager
+		args = []string{versionlockVerb, "delete"}
+		if hold {
+			args = []string{versionlockVerb, "add"}
+		}
+	}
+	args = append(args, packages...)
+	return func(ctx context.Context) *exec.Cmd {
+		return exec.CommandContext(ctx, name, args...)
+	}
+}
+
+func (pm *DistroPackageManager) versionlockPluginName() string {
+	if pm.manager == dnfPackageManager {
+		return dnfVersionlockPluginPkg
+	}
+	return yumVersionlockPluginPkg
+}
+
+func (pm *DistroPackageManager) versionlockPluginPackage() artifact.Package {
+	pluginPkgName := pm.versionlockPluginName()
+	return artifact.NewPackageSource(
+		artifact.NewCmd(pm.manager, pm.installVerb, pluginPkgName, "-y"),
+		artifact.NewCmd(pm.manager, pm.deleteVerb, pluginPkgName, "-y"),
+		artifact.NewCmd(pm.manager, pm.updateVerb, pluginPkgName, "-y"),
+	)
+}
+
 // Cleanup cleans up any artifacts used by package manager during nodeadm install process
-func (pm *DistroPackageManager) Cleanup() error {
+func (pm *DistroPackageManager) Cleanup(ctx context.Context) error {
 	// Removes docker repos if installed by nodeadm ("Containerd: docker" was set in tracker file)
 	if pm.dockerRepo != "" {
-		if err := pm.uninstallDockerRepo(); err != nil {
+		if err := pm.uninstallDockerRepo(ctx); err != nil {
 			return err
 		}
 	}
@@ -303,8 +460,14 @@ func (pm *DistroPackageManager) Cleanup() error {
 	return nil
 }
 
+// isRpmInstalled returns true if the rpm package is installed.
+func isRpmInstalled(name string) bool {
+	return exec.Command("rpm", "-q", name).Run() == nil
+}
+
 func getOsPackageManager() (string, error) {
-	supportedManagers := []string{yumPackageManager, aptPackageManager}
+	// dnf is checked before yum because distros that ship dnf keep yum as an alias to it.
+	supportedManagers := []string{dnfPackageManager, yumPackageManager, aptPackageManager, zypperPackageManager}
 	for _, manager := range supportedManagers {
 		if _, err := exec.LookPath(manager); err == nil {
 			return manager, nil
@@ -314,26 +477,29 @@ func getOsPackageManager() (string, error) {
 }
 
 var packageManagerInstallCmd = map[string]string{
-	aptPackageManager: "install",
-	yumPackageManager: "install",
+	aptPackageManager:    "install",
+	dnfPackageManager:    "install",
+	yumPackageManager:    "install",
+	zypperPackageManager: "install",
 }
 
 var packageManagerUpdateCmd = map[string]string{
-	aptPackageManager: "upgrade",
-	yumPackageManager: "update",
+	aptPackageManager:    "upgrade",
+	dnfPackageManager:    "upgrade",
+	yumPackageManager:    "update",
+	zypperPackageManager: "update",
 }
 
 var packageManagerDeleteCmd = map[string]string{
-	aptPackageManager: "autoremove",
-	yumPackageManager: "remove",
+	aptPackageManager:    "autoremove",
+	dnfPackageManager:    "remove",
+	yumPackageManager:    "remove",
+	zypperPackageManager: "remove",
 }
 
 var packageManagerMetadataRefreshCmd = map[string]string{
-	aptPackageManager: "update",
-	yumPackageManager: "makecache",
-}
-
-var managerToDockerRepoMap = map[string]string{
-	yumPackageManager: "https://download.docker.com/linux/centos/docker-ce.repo",
-	aptPackageManager: "https://download.docker.com/linux/ubuntu",
+	aptPackageManager:    "update",
+	dnfPackageManager:    "makecache",
+	yumPackageManager:    "makecache",
+	zypperPackageManager: "refresh",
 }
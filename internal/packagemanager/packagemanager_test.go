@@ -0,0 +1,92 @@
+package packagemanager
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/system"
+)
+
+func TestGetContainerdVersion(t *testing.T) {
+	tests := []struct {
+		manager string
+		version string
+		want    string
+	}{
+		{manager: aptPackageManager, version: "1.*", want: "containerd=1.*"},
+		{manager: dnfPackageManager, version: "1.7.24-*", want: "containerd-1.7.24-*"},
+		{manager: yumPackageManager, version: "1.7.*", want: "containerd-1.7.*"},
+		{manager: zypperPackageManager, version: "1.*", want: "containerd<2"},
+		{manager: zypperPackageManager, version: "1.7.*", want: "containerd<1.8"},
+		{manager: zypperPackageManager, version: "1.7.24-*", want: "containerd=1.7.24"},
+		{manager: zypperPackageManager, version: "1.7.24-150000.117.1", want: "containerd=1.7.24-150000.117.1"},
+		{manager: zypperPackageManager, version: "", want: "containerd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.manager+" "+tt.version, func(t *testing.T) {
+			g := NewWithT(t)
+			pm := &DistroPackageManager{manager: tt.manager}
+			g.Expect(pm.getContainerdPackageNameWithVersion(tt.version)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestHoldCommand(t *testing.T) {
+	tests := []struct {
+		manager     string
+		wantHold    []string
+		wantRelease []string
+	}{
+		{
+			manager:     aptPackageManager,
+			wantHold:    []string{"apt-mark", "hold", "containerd", "runc"},
+			wantRelease: []string{"apt-mark", "unhold", "containerd", "runc"},
+		},
+		{
+			manager:     dnfPackageManager,
+			wantHold:    []string{"dnf", "versionlock", "add", "containerd", "runc"},
+			wantRelease: []string{"dnf", "versionlock", "delete", "containerd", "runc"},
+		},
+		{
+			manager:     zypperPackageManager,
+			wantHold:    []string{"zypper", "addlock", "containerd", "runc"},
+			wantRelease: []string{"zypper", "removelock", "containerd", "runc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.manager, func(t *testing.T) {
+			g := NewWithT(t)
+			pm := &DistroPackageManager{manager: tt.manager}
+			packages := pm.ContainerdPackages()
+			g.Expect(pm.holdCommand(true, packages)(context.Background()).Args).To(Equal(tt.wantHold))
+			g.Expect(pm.holdCommand(false, packages)(context.Background()).Args).To(Equal(tt.wantRelease))
+		})
+	}
+}
+
+func TestRemoveHoldPlugin(t *testing.T) {
+	g := NewWithT(t)
+	dryRun := host.NewDryRun()
+	pm := &DistroPackageManager{manager: dnfPackageManager, deleteVerb: packageManagerDeleteCmd[dnfPackageManager]}
+
+	g.Expect(pm.RemoveHoldPlugin(host.NewContext(context.Background(), dryRun), pm.versionlockPluginName())).To(Succeed())
+	g.Expect(dryRun.Actions()).To(Equal([]host.Action{
+		{Kind: host.ActionRun, Target: "dnf remove python3-dnf-plugin-versionlock -y"},
+	}))
+}
+
+func TestDockerRepo(t *testing.T) {
+	g := NewWithT(t)
+	rocky := system.OsRelease{ID: system.RockyOsName, IDLike: []string{"rhel", "centos", "fedora"}}
+	debian := system.OsRelease{ID: system.DebianOsName}
+	ubuntu := system.OsRelease{ID: system.UbuntuOsName, IDLike: []string{"debian"}}
+	sles := system.OsRelease{ID: system.SlesOsName, IDLike: []string{"suse"}}
+
+	g.Expect(dockerRepo(dnfPackageManager, rocky)).To(Equal(centOsDockerRepo))
+	g.Expect(dockerRepo(aptPackageManager, debian)).To(Equal(debianDockerRepo))
+	g.Expect(dockerRepo(aptPackageManager, ubuntu)).To(Equal(ubuntuDockerRepo))
+	g.Expect(dockerRepo(zypperPackageManager, sles)).To(BeEmpty())
+}
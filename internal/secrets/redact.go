@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"strings"
+	"sync"
+)
+
+// Redacted replaces secret values in redacted text.
+const Redacted = "<redacted>"
+
+var (
+	mu       sync.RWMutex
+	resolved = map[string]struct{}{}
+)
+
+func register(value string) {
+	mu.Lock()
+	defer mu.Unlock()
+	resolved[value] = struct{}{}
+}
+
+// Redact replaces every secret resolved by this process that appears in s.
+func Redact(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for value := range resolved {
+		s = strings.ReplaceAll(s, value, Redacted)
+	}
+	return s
+}
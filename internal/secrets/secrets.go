@@ -0,0 +1,163 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/pkg/errors"
+)
+
+// Reference prefixes supported for secret values.
+const (
+	EnvPrefix            = "env:"
+	FilePrefix           = "file:"
+	SSMParameterPrefix   = "ssm-parameter:"
+	SecretsManagerPrefix = "secretsmanager:"
+)
+
+// secretsManagerParameterPrefix is the SSM parameter path that references Secrets Manager secrets.
+// https://docs.aws.amazon.com/systems-manager/latest/userguide/integration-ps-secretsmanager.html
+const secretsManagerParameterPrefix = "/aws/reference/secretsmanager/"
+
+var prefixes = []string{EnvPrefix, FilePrefix, SSMParameterPrefix, SecretsManagerPrefix}
+
+// IsReference returns true if value references a secret instead of holding it.
+func IsReference(value string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SSMClient is the subset of the SSM API used to read parameters and Secrets Manager secrets.
+type SSMClient interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// Resolver reads the value of secret references.
+type Resolver struct {
+	// Region is used for SSM parameters and secrets referenced by name instead of ARN.
+	Region    string
+	newClient func(ctx context.Context, region string) (SSMClient, error)
+}
+
+// ResolverOpt allows to configure the Resolver.
+type ResolverOpt func(*Resolver)
+
+// WithSSMClient configures the client used to read SSM parameters and Secrets Manager secrets,
+// instead of one built with the host's AWS credentials.
+func WithSSMClient(client SSMClient) ResolverOpt {
+	return func(r *Resolver) {
+		r.newClient = func(context.Context, string) (SSMClient, error) {
+			return client, nil
+		}
+	}
+}
+
+// NewResolver returns a Resolver that reads AWS secrets with the host's AWS credentials.
+func NewResolver(region string, opts ...ResolverOpt) *Resolver {
+	r := &Resolver{
+		Region:    region,
+		newClient: newSSMClient,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the value referenced by ref. Values that are not references are
+// returned as is. Resolved values are registered to be redacted.
+// Errors never include the resolved value.
+func (r *Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	var value string
+	var err error
+	switch {
+	case strings.HasPrefix(ref, EnvPrefix):
+		value, err = resolveEnv(strings.TrimPrefix(ref, EnvPrefix))
+	case strings.HasPrefix(ref, FilePrefix):
+		value, err = resolveFile(strings.TrimPrefix(ref, FilePrefix))
+	case strings.HasPrefix(ref, SSMParameterPrefix):
+		value, err = r.resolveParameter(ctx, strings.TrimPrefix(ref, SSMParameterPrefix))
+	case strings.HasPrefix(ref, SecretsManagerPrefix):
+		value, err = r.resolveSecret(ctx, strings.TrimPrefix(ref, SecretsManagerPrefix))
+	default:
+		return ref, nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving secret %s", ref)
+	}
+	if value == "" {
+		return "", fmt.Errorf("resolving secret %s: value is empty", ref)
+	}
+	register(value)
+	return value, nil
+}
+
+func resolveEnv(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("environment variable name is empty")
+	}
+	return os.Getenv(name), nil
+}
+
+func resolveFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// Files usually end with a new line that is not part of the secret.
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func (r *Resolver) resolveParameter(ctx context.Context, name string) (string, error) {
+	return r.getParameter(ctx, name, regionFromARN(name, r.Region))
+}
+
+func (r *Resolver) resolveSecret(ctx context.Context, id string) (string, error) {
+	return r.getParameter(ctx, secretsManagerParameterPrefix+id, regionFromARN(id, r.Region))
+}
+
+func (r *Resolver) getParameter(ctx context.Context, name, region string) (string, error) {
+	if region == "" {
+		return "", fmt.Errorf("no region configured to read from AWS, set spec.cluster.region or use an ARN")
+	}
+	client, err := r.newClient(ctx, region)
+	if err != nil {
+		return "", err
+	}
+	out, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	if out.Parameter == nil {
+		return "", nil
+	}
+	return aws.ToString(out.Parameter.Value), nil
+}
+
+func regionFromARN(value, defaultRegion string) string {
+	if parsed, err := arn.Parse(value); err == nil && parsed.Region != "" {
+		return parsed.Region
+	}
+	return defaultRegion
+}
+
+func newSSMClient(ctx context.Context, region string) (SSMClient, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return ssm.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,135 @@
+package secrets_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/secrets"
+)
+
+type fakeSSMClient struct {
+	parameters map[string]string
+	requested  []string
+}
+
+func (f *fakeSSMClient) GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	name := aws.ToString(params.Name)
+	f.requested = append(f.requested, name)
+	value, ok := f.parameters[name]
+	if !ok {
+		return nil, &types.ParameterNotFound{Message: aws.String("parameter not found")}
+	}
+	if !aws.ToBool(params.WithDecryption) {
+		return nil, errors.New("secure parameters must be decrypted")
+	}
+	return &ssm.GetParameterOutput{Parameter: &types.Parameter{Value: aws.String(value)}}, nil
+}
+
+func TestResolverResolve(t *testing.T) {
+	t.Setenv("ACTIVATION_CODE", "code-from-env")
+	keyFile := filepath.Join(t.TempDir(), "code")
+	if err := os.WriteFile(keyFile, []byte("code-from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeSSMClient{parameters: map[string]string{
+		"/nodes/activation-code": "code-from-ssm",
+		"/aws/reference/secretsmanager/arn:aws:secretsmanager:us-west-2:123456789012:secret:code": "code-from-secrets-manager",
+	}}
+
+	testCases := []struct {
+		name    string
+		region  string
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "plain value",
+			ref:  "my-activation-code",
+			want: "my-activation-code",
+		},
+		{
+			name: "env",
+			ref:  "env:ACTIVATION_CODE",
+			want: "code-from-env",
+		},
+		{
+			name:    "env not set",
+			ref:     "env:NOT_SET_FOR_TESTS",
+			wantErr: "resolving secret env:NOT_SET_FOR_TESTS: value is empty",
+		},
+		{
+			name: "file",
+			ref:  "file:" + keyFile,
+			want: "code-from-file",
+		},
+		{
+			name:    "file missing",
+			ref:     "file:/does/not/exist",
+			wantErr: "resolving secret file:/does/not/exist: open /does/not/exist: no such file or directory",
+		},
+		{
+			name:   "ssm parameter",
+			region: "us-west-2",
+			ref:    "ssm-parameter:/nodes/activation-code",
+			want:   "code-from-ssm",
+		},
+		{
+			name:    "ssm parameter without region",
+			ref:     "ssm-parameter:/nodes/activation-code",
+			wantErr: "no region configured to read from AWS",
+		},
+		{
+			name:    "ssm parameter not found",
+			region:  "us-west-2",
+			ref:     "ssm-parameter:/nodes/missing",
+			wantErr: "resolving secret ssm-parameter:/nodes/missing: ParameterNotFound",
+		},
+		{
+			name: "secrets manager uses the region in the arn",
+			ref:  "secretsmanager:arn:aws:secretsmanager:us-west-2:123456789012:secret:code",
+			want: "code-from-secrets-manager",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resolver := secrets.NewResolver(tc.region, secrets.WithSSMClient(client))
+			got, err := resolver.Resolve(context.Background(), tc.ref)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("REDACT_TEST_SECRET", "s3cr3t-activation-code")
+
+	g.Expect(secrets.Redact("-code s3cr3t-activation-code")).To(Equal("-code s3cr3t-activation-code"))
+
+	_, err := secrets.NewResolver("").Resolve(context.Background(), "env:REDACT_TEST_SECRET")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secrets.Redact("-code s3cr3t-activation-code -id 1234")).To(Equal("-code <redacted> -id 1234"))
+}
+
+func TestIsReference(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(secrets.IsReference("env:CODE")).To(BeTrue())
+	g.Expect(secrets.IsReference("secretsmanager:my-secret")).To(BeTrue())
+	g.Expect(secrets.IsReference("/etc/iam/pki/server.key")).To(BeFalse())
+	g.Expect(secrets.IsReference("aBcD1234")).To(BeFalse())
+}
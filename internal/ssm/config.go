@@ -1,6 +1,7 @@
 package ssm
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -8,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 type HybridInstanceRegistration struct {
@@ -15,7 +17,7 @@ type HybridInstanceRegistration struct {
 	Region            string `json:"Region"`
 }
 
-func (s *ssm) registerMachine(cfg *api.NodeConfig) error {
+func (s *ssm) registerMachine(ctx context.Context, cfg *api.NodeConfig) error {
 	registration := NewSSMRegistration()
 	registered, err := registration.isRegistered()
 	if err != nil {
@@ -38,10 +40,15 @@ func (s *ssm) registerMachine(cfg *api.NodeConfig) error {
 			"-id", cfg.Spec.Hybrid.SSM.ActivationID,
 		)
 
-		out, err := registerCmd.CombinedOutput()
+		out, err := host.FromContext(ctx).Run(registerCmd)
 		if err != nil {
 			return fmt.Errorf("running register machine command: %s, error: %v", out, err)
 		}
+
+		if host.IsDryRun(ctx) {
+			s.logger.Info("Node name will be the instance ID assigned by the SSM registration")
+			return nil
+		}
 	}
 
 	// Set the nodename on nodeconfig post registration
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/system"
 )
 
@@ -44,8 +45,8 @@ func NewSsmDaemon(daemonManager daemon.DaemonManager, cfg *api.NodeConfig, logge
 	}
 }
 
-func (s *ssm) Configure() error {
-	if err := s.registerMachine(s.nodeConfig); err != nil {
+func (s *ssm) Configure(ctx context.Context) error {
+	if err := s.registerMachine(ctx, s.nodeConfig); err != nil {
 		if match := activationExpiredRegex.MatchString(err.Error()); match {
 			return fmt.Errorf("SSM activation expired. Please use a valid activation")
 		} else if match := invalidActivationRegex.MatchString(err.Error()); match {
@@ -84,20 +85,21 @@ func (s *ssm) EnsureRunning(ctx context.Context) error {
 	return nil
 }
 
-func (s *ssm) PostLaunch() error {
+func (s *ssm) PostLaunch(ctx context.Context) error {
 	if s.nodeConfig.Spec.Hybrid.EnableCredentialsFile {
+		h := host.FromContext(ctx)
 		s.logger.Info("Creating symlink for AWS credentials", zap.String("Symbolic link path", symlinkedAWSConfigPath))
-		err := os.MkdirAll(eksHybridPath, 0o755)
+		err := h.MkdirAll(eksHybridPath, 0o755)
 		if err != nil {
 			return fmt.Errorf("creating path: %v", err)
 		}
 
-		err = os.RemoveAll(symlinkedAWSConfigPath)
+		err = h.RemoveAll(symlinkedAWSConfigPath)
 		if err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("removing directory %s: %v", symlinkedAWSConfigPath, err)
 		}
 
-		err = os.Symlink(defaultAWSConfigPath, symlinkedAWSConfigPath)
+		err = h.Symlink(defaultAWSConfigPath, symlinkedAWSConfigPath)
 		if err != nil {
 			return fmt.Errorf("creating symlink: %v", err)
 		}
@@ -126,3 +128,9 @@ func setDaemonName() {
 		SsmDaemonName = daemonName
 	}
 }
+
+// DaemonName returns the name of the SSM agent daemon for the current OS.
+func DaemonName() string {
+	setDaemonName()
+	return SsmDaemonName
+}
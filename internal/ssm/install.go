@@ -16,6 +16,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/tracker"
 	"github.com/aws/eks-hybrid/internal/util"
 	"github.com/aws/eks-hybrid/internal/util/cmd"
@@ -63,7 +64,7 @@ func Install(ctx context.Context, opts InstallOptions) error {
 func installFromSource(ctx context.Context, opts InstallOptions) error {
 	installerPath := filepath.Join(opts.InstallRoot, defaultInstallerPath)
 
-	if err := writeGpgConfig(); err != nil {
+	if err := writeGpgConfig(ctx); err != nil {
 		return errors.Wrapf(err, "writing gpg config file")
 	}
 
@@ -120,7 +121,7 @@ func downloadFileTo(ctx context.Context, source Source, installerPath string) er
 		return fmt.Errorf("validating ssm-setup-cli signature: %w", err)
 	}
 
-	if err := artifact.InstallFile(installerPath, bytes.NewReader(installerBuffer.Bytes()), 0o755); err != nil {
+	if err := host.FromContext(ctx).WriteFile(installerPath, bytes.NewReader(installerBuffer.Bytes()), 0o755); err != nil {
 		return fmt.Errorf("installing ssm-setup-cli: %w", err)
 	}
 
@@ -172,19 +173,19 @@ func Uninstall(ctx context.Context, opts UninstallOptions) error {
 			return Deregister(ctx, opts.SSMRegistration, opts.SSMClient, opts.Logger)
 		},
 		func() error {
-			return removeFileOrDir(opts.SSMRegistration.RegistrationFilePath(), "uninstalling ssm registration file")
+			return removeFileOrDir(ctx, opts.SSMRegistration.RegistrationFilePath(), "uninstalling ssm registration file")
 		},
 		func() error {
 			return uninstallPreRegisterComponents(ctx, opts.PkgSource)
 		},
 		func() error {
-			return removeFileOrDir(filepath.Join(opts.InstallRoot, configRoot), "uninstalling ssm config files")
+			return removeFileOrDir(ctx, filepath.Join(opts.InstallRoot, configRoot), "uninstalling ssm config files")
 		},
 		func() error {
-			return removeFileOrDir(filepath.Join(opts.InstallRoot, symlinkedAWSConfigPath), "uninstalling ssm aws config symlink")
+			return removeFileOrDir(ctx, filepath.Join(opts.InstallRoot, symlinkedAWSConfigPath), "uninstalling ssm aws config symlink")
 		},
 		func() error {
-			return removeFileOrDir(filepath.Join(opts.InstallRoot, defaultAWSConfigPath), "uninstalling ssm aws config")
+			return removeFileOrDir(ctx, filepath.Join(opts.InstallRoot, defaultAWSConfigPath), "uninstalling ssm aws config")
 		},
 	}
 
@@ -202,21 +203,21 @@ func Uninstall(ctx context.Context, opts UninstallOptions) error {
 	return nil
 }
 
-func removeFileOrDir(path, errorMessage string) error {
-	if err := os.RemoveAll(path); err != nil {
+func removeFileOrDir(ctx context.Context, path, errorMessage string) error {
+	if err := host.FromContext(ctx).RemoveAll(path); err != nil {
 		return errors.Wrap(err, errorMessage)
 	}
 	return nil
 }
 
-func writeGpgConfig() error {
+func writeGpgConfig(ctx context.Context) error {
 	// In some environments, HOME will not be defined like while running cloud-init
 	homeDir, set := os.LookupEnv("HOME")
 	if !set {
 		homeDir = rootDir
 	}
 	gpgConfigFile := filepath.Join(homeDir, gpgConfigDirName, gpgConfigFileName)
-	return util.WriteFileUniqueLine(gpgConfigFile, []byte("no-tty"), gpgConfigFilePerms)
+	return util.WriteFileUniqueLine(ctx, gpgConfigFile, []byte("no-tty"), gpgConfigFilePerms)
 }
 
 func uninstallPreRegisterComponents(ctx context.Context, pkgSource PkgSource) error {
@@ -224,7 +225,7 @@ func uninstallPreRegisterComponents(ctx context.Context, pkgSource PkgSource) er
 	if err := cmd.Retry(ctx, ssmPkg.UninstallCmd, 5*time.Second); err != nil {
 		return errors.Wrapf(err, "uninstalling ssm")
 	}
-	return os.RemoveAll(defaultInstallerPath)
+	return host.FromContext(ctx).RemoveAll(defaultInstallerPath)
 }
 
 func runInstallWithRetries(ctx context.Context, installerPath, region string) error {
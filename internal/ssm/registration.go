@@ -10,6 +10,8 @@ import (
 	awsSsm "github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 const registrationFilePath = "/var/lib/amazon/ssm/registration"
@@ -61,7 +63,10 @@ func Deregister(ctx context.Context, registration *SSMRegistration, ssmClient SS
 	// Only deregister the instance if init/ssm init was run and
 	// if instances is actively listed as managed
 	if managed {
-		if err := deregister(ssmClient, instanceId); err != nil {
+		err := host.FromContext(ctx).CallAPI("ssm:DeregisterManagedInstance", func() error {
+			return deregister(ssmClient, instanceId)
+		})
+		if err != nil {
 			return errors.Wrapf(err, "deregistering ssm managed instance")
 		}
 	}
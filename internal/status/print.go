@@ -0,0 +1,102 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// Print writes status to w in the given output format.
+func Print(w io.Writer, status Status, output string) error {
+	switch output {
+	case OutputJSON:
+		data, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case OutputYAML:
+		data, err := yaml.Marshal(status)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case OutputTable, "":
+		return printTable(w, status)
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats: [%s, %s, %s]", output, OutputTable, OutputJSON, OutputYAML)
+	}
+}
+
+func printTable(w io.Writer, status Status) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NODE\tREADY\tREASON\tMESSAGE")
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", valueOrNone(status.Node.Name), status.Node.Ready, status.Node.Reason, withError(status.Node.Message, status.Node.Error))
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "KUBELET VERSION\tCREDENTIAL PROVIDER\tDETAILS")
+	fmt.Fprintf(tw, "%s\t%s\t%s\n", valueOrNone(status.KubeletVersion), valueOrNone(status.CredentialProvider.Name), credentialDetails(status.CredentialProvider))
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "DAEMON\tSTATUS\tERROR")
+	for _, d := range status.Daemons {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Name, d.Status, d.Error)
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "COMPONENT\tVERSION\tINSTALLED AT\tPATH")
+	for _, c := range status.Components {
+		installedAt := ""
+		if c.InstalledAt != nil {
+			installedAt = c.InstalledAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Name, valueOrNone(c.Version), installedAt, c.InstallPath)
+	}
+
+	for _, err := range status.Errors {
+		fmt.Fprintf(tw, "\nERROR: %s\n", err)
+	}
+
+	return tw.Flush()
+}
+
+func credentialDetails(c CredentialProviderStatus) string {
+	var details string
+	switch {
+	case c.ManagedInstanceID != "":
+		details = "managed instance " + c.ManagedInstanceID
+	case c.CertificateExpiry != nil:
+		details = fmt.Sprintf("certificate %s expires %s", c.CertificatePath, c.CertificateExpiry.Format(time.RFC3339))
+	}
+	return withError(details, c.Error)
+}
+
+func withError(value, err string) string {
+	if err == "" {
+		return value
+	}
+	if value == "" {
+		return "error: " + err
+	}
+	return value + " (error: " + err + ")"
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,259 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/containerd"
+	"github.com/aws/eks-hybrid/internal/creds"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/iamrolesanywhere"
+	"github.com/aws/eks-hybrid/internal/kubelet"
+	"github.com/aws/eks-hybrid/internal/node"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+// Status summarizes the state of a node and the components installed by nodeadm.
+type Status struct {
+	KubeletVersion     string                   `json:"kubeletVersion,omitempty"`
+	CredentialProvider CredentialProviderStatus `json:"credentialProvider"`
+	Components         []ComponentStatus        `json:"components"`
+	Daemons            []DaemonStatus           `json:"daemons"`
+	Node               NodeStatus               `json:"node"`
+	// Errors holds the problems found while collecting the status that are not
+	// specific to any of the other sections.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ComponentStatus describes a component installed by nodeadm.
+type ComponentStatus struct {
+	Name        string     `json:"name"`
+	Version     string     `json:"version,omitempty"`
+	Source      string     `json:"source,omitempty"`
+	Sha256      string     `json:"sha256,omitempty"`
+	InstallPath string     `json:"installPath,omitempty"`
+	InstalledAt *time.Time `json:"installedAt,omitempty"`
+}
+
+// DaemonStatus is the systemd status of a daemon.
+type DaemonStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CredentialProviderStatus describes how the node gets its AWS credentials.
+type CredentialProviderStatus struct {
+	Name string `json:"name,omitempty"`
+	// ManagedInstanceID is the SSM hybrid instance id, only set for SSM.
+	ManagedInstanceID string `json:"managedInstanceId,omitempty"`
+	// CertificatePath and CertificateExpiry are only set for IAM Roles Anywhere.
+	CertificatePath   string     `json:"certificatePath,omitempty"`
+	CertificateExpiry *time.Time `json:"certificateExpiry,omitempty"`
+	Error             string     `json:"error,omitempty"`
+}
+
+// NodeStatus is the Ready condition of the node as reported by the API server.
+type NodeStatus struct {
+	Name    string `json:"name,omitempty"`
+	Ready   string `json:"ready"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Collector gathers the Status of the node. Collecting never fails, errors are reported
+// in the section of the Status they affect so the rest of the information is still available.
+type Collector struct {
+	daemonManager     daemon.DaemonManager
+	ssmRegistration   *ssm.SSMRegistration
+	awsConfigPath     string
+	getKubeletVersion func() (string, error)
+	getReadyCondition func(context.Context) (string, *v1.NodeCondition, error)
+}
+
+type CollectorOption func(*Collector)
+
+// WithKubeletVersion overrides how the kubelet version is retrieved.
+func WithKubeletVersion(getKubeletVersion func() (string, error)) CollectorOption {
+	return func(c *Collector) {
+		c.getKubeletVersion = getKubeletVersion
+	}
+}
+
+// WithReadyCondition overrides how the node Ready condition is retrieved.
+func WithReadyCondition(getReadyCondition func(context.Context) (string, *v1.NodeCondition, error)) CollectorOption {
+	return func(c *Collector) {
+		c.getReadyCondition = getReadyCondition
+	}
+}
+
+// WithSSMRegistration overrides the SSM registration used to read the managed instance id.
+func WithSSMRegistration(registration *ssm.SSMRegistration) CollectorOption {
+	return func(c *Collector) {
+		c.ssmRegistration = registration
+	}
+}
+
+// WithAWSConfigPath overrides the AWS config file used to find the IAM Roles Anywhere certificate.
+func WithAWSConfigPath(path string) CollectorOption {
+	return func(c *Collector) {
+		c.awsConfigPath = path
+	}
+}
+
+func NewCollector(daemonManager daemon.DaemonManager, opts ...CollectorOption) *Collector {
+	c := &Collector{
+		daemonManager:     daemonManager,
+		ssmRegistration:   ssm.NewSSMRegistration(),
+		awsConfigPath:     iamrolesanywhere.DefaultAWSConfigPath,
+		getKubeletVersion: kubelet.GetKubeletVersion,
+		getReadyCondition: node.GetReadyCondition,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Collect returns the Status of the node with the components installed according to tr.
+func (c *Collector) Collect(ctx context.Context, tr *tracker.Tracker) Status {
+	var status Status
+
+	status.Components = components(tr)
+
+	if tr.Artifacts.Kubelet {
+		version, err := c.getKubeletVersion()
+		if err != nil {
+			status.Errors = append(status.Errors, "getting kubelet version: "+err.Error())
+		}
+		status.KubeletVersion = version
+	}
+
+	provider, err := creds.GetCredentialProviderFromInstalledArtifacts(tr.Artifacts)
+	if err != nil {
+		status.CredentialProvider.Error = err.Error()
+	} else {
+		status.CredentialProvider = c.credentialProvider(provider)
+	}
+
+	status.Daemons = c.daemons(tr, status.CredentialProvider.Name)
+	status.Node = c.node(ctx)
+
+	return status
+}
+
+func (c *Collector) credentialProvider(provider creds.CredentialProvider) CredentialProviderStatus {
+	status := CredentialProviderStatus{Name: string(provider)}
+	switch provider {
+	case creds.SsmCredentialProvider:
+		id, err := c.ssmRegistration.GetManagedHybridInstanceId()
+		if err != nil {
+			status.Error = "reading SSM registration: " + err.Error()
+			return status
+		}
+		status.ManagedInstanceID = id
+	case creds.IamRolesAnywhereCredentialProvider:
+		certPath, err := iamrolesanywhere.CertificatePathFromConfig(c.awsConfigPath)
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		status.CertificatePath = certPath
+		cert, err := iamrolesanywhere.ReadCertificate(certPath)
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		expiry := cert.NotAfter.UTC()
+		status.CertificateExpiry = &expiry
+	}
+	return status
+}
+
+func (c *Collector) daemons(tr *tracker.Tracker, provider string) []DaemonStatus {
+	var names []string
+	if tr.Artifacts.Containerd != "" && tr.Artifacts.Containerd != string(containerd.ContainerdSourceNone) {
+		names = append(names, containerd.ContainerdDaemonName)
+	}
+	if tr.Artifacts.Kubelet {
+		names = append(names, kubelet.KubeletDaemonName)
+	}
+	switch creds.CredentialProvider(provider) {
+	case creds.SsmCredentialProvider:
+		names = append(names, ssm.DaemonName())
+	case creds.IamRolesAnywhereCredentialProvider:
+		names = append(names, iamrolesanywhere.DaemonName)
+	}
+
+	daemons := make([]DaemonStatus, 0, len(names))
+	for _, name := range names {
+		d := DaemonStatus{Name: name}
+		status, err := c.daemonManager.GetDaemonStatus(name)
+		if err != nil {
+			d.Status = string(daemon.DaemonStatusUnknown)
+			d.Error = err.Error()
+		} else {
+			d.Status = string(status)
+		}
+		daemons = append(daemons, d)
+	}
+	return daemons
+}
+
+func (c *Collector) node(ctx context.Context) NodeStatus {
+	name, condition, err := c.getReadyCondition(ctx)
+	status := NodeStatus{Name: name, Ready: string(v1.ConditionUnknown)}
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if condition != nil {
+		status.Ready = string(condition.Status)
+		status.Reason = condition.Reason
+		status.Message = condition.Message
+	}
+	return status
+}
+
+func components(tr *tracker.Tracker) []ComponentStatus {
+	installed := []struct {
+		name      string
+		installed bool
+	}{
+		{artifact.Containerd, tr.Artifacts.Containerd != "" && tr.Artifacts.Containerd != string(containerd.ContainerdSourceNone)},
+		{artifact.Iptables, tr.Artifacts.Iptables},
+		{artifact.Kubelet, tr.Artifacts.Kubelet},
+		{artifact.Kubectl, tr.Artifacts.Kubectl},
+		{artifact.CniPlugins, tr.Artifacts.CniPlugins},
+		{artifact.ImageCredentialProvider, tr.Artifacts.ImageCredentialProvider},
+		{artifact.IamAuthenticator, tr.Artifacts.IamAuthenticator},
+		{artifact.IamRolesAnywhere, tr.Artifacts.IamRolesAnywhere},
+		{artifact.Ssm, tr.Artifacts.Ssm},
+	}
+
+	var components []ComponentStatus
+	for _, c := range installed {
+		if !c.installed {
+			continue
+		}
+		component := ComponentStatus{Name: c.name}
+		if c.name == artifact.Containerd {
+			component.Source = tr.Artifacts.Containerd
+		}
+		if record, ok := tr.Records[c.name]; ok {
+			installedAt := record.InstalledAt
+			component.Version = record.Version
+			component.Source = record.SourceURI
+			component.Sha256 = record.Sha256
+			component.InstallPath = record.InstallPath
+			component.InstalledAt = &installedAt
+		}
+		components = append(components, component)
+	}
+	return components
+}
@@ -0,0 +1,111 @@
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/daemon"
+	"github.com/aws/eks-hybrid/internal/ssm"
+	"github.com/aws/eks-hybrid/internal/status"
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
+type fakeDaemonManager struct {
+	daemon.DaemonManager
+	statuses map[string]daemon.DaemonStatus
+}
+
+func (f fakeDaemonManager) GetDaemonStatus(name string) (daemon.DaemonStatus, error) {
+	s, ok := f.statuses[name]
+	if !ok {
+		return "", errors.New("unit not found")
+	}
+	return s, nil
+}
+
+func TestCollectSSM(t *testing.T) {
+	g := NewWithT(t)
+	installRoot := t.TempDir()
+	registration := ssm.NewSSMRegistration(ssm.WithInstallRoot(installRoot))
+	g.Expect(os.MkdirAll(filepath.Dir(registration.RegistrationFilePath()), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(registration.RegistrationFilePath(), []byte(`{"ManagedInstanceID":"mi-1234","Region":"us-west-2"}`), 0o644)).To(Succeed())
+
+	installedAt := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	tr := &tracker.Tracker{
+		Artifacts: &tracker.InstalledArtifacts{
+			Containerd: "distro",
+			Kubelet:    true,
+			Ssm:        true,
+		},
+		Records: map[string]*tracker.ArtifactRecord{
+			artifact.Kubelet: {Version: "1.31.2", InstallPath: "/usr/bin/kubelet", InstalledAt: installedAt},
+		},
+	}
+
+	collector := status.NewCollector(
+		fakeDaemonManager{statuses: map[string]daemon.DaemonStatus{
+			"containerd": daemon.DaemonStatusRunning,
+			"kubelet":    daemon.DaemonStatusRunning,
+		}},
+		status.WithSSMRegistration(registration),
+		status.WithKubeletVersion(func() (string, error) { return "v1.31.2", nil }),
+		status.WithReadyCondition(func(context.Context) (string, *v1.NodeCondition, error) {
+			return "mi-1234", &v1.NodeCondition{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady"}, nil
+		}),
+	)
+
+	s := collector.Collect(context.Background(), tr)
+	g.Expect(s.KubeletVersion).To(Equal("v1.31.2"))
+	g.Expect(s.CredentialProvider.Name).To(Equal("ssm"))
+	g.Expect(s.CredentialProvider.ManagedInstanceID).To(Equal("mi-1234"))
+	g.Expect(s.Node).To(Equal(status.NodeStatus{Name: "mi-1234", Ready: "True", Reason: "KubeletReady"}))
+	g.Expect(s.Components).To(HaveLen(3))
+	g.Expect(s.Components[0]).To(Equal(status.ComponentStatus{Name: artifact.Containerd, Source: "distro"}))
+	g.Expect(s.Components[1].Version).To(Equal("1.31.2"))
+	g.Expect(s.Daemons).To(HaveLen(3))
+	g.Expect(s.Daemons[0]).To(Equal(status.DaemonStatus{Name: "containerd", Status: "running"}))
+	g.Expect(s.Daemons[2].Status).To(Equal("unknown"))
+	g.Expect(s.Daemons[2].Error).To(ContainSubstring("unit not found"))
+
+	var buf bytes.Buffer
+	g.Expect(status.Print(&buf, s, status.OutputJSON)).To(Succeed())
+	var decoded status.Status
+	g.Expect(json.Unmarshal(buf.Bytes(), &decoded)).To(Succeed())
+	g.Expect(decoded.CredentialProvider.ManagedInstanceID).To(Equal("mi-1234"))
+
+	buf.Reset()
+	g.Expect(status.Print(&buf, s, status.OutputTable)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring("managed instance mi-1234"))
+
+	g.Expect(status.Print(&buf, s, "xml")).To(MatchError(ContainSubstring("invalid output format xml")))
+}
+
+func TestCollectNodeNotReachable(t *testing.T) {
+	g := NewWithT(t)
+	tr := &tracker.Tracker{Artifacts: &tracker.InstalledArtifacts{IamRolesAnywhere: true}}
+
+	collector := status.NewCollector(
+		fakeDaemonManager{},
+		status.WithAWSConfigPath(filepath.Join(t.TempDir(), "missing")),
+		status.WithReadyCondition(func(context.Context) (string, *v1.NodeCondition, error) {
+			return "my-node", nil, errors.New("connection refused")
+		}),
+	)
+
+	s := collector.Collect(context.Background(), tr)
+	g.Expect(s.KubeletVersion).To(BeEmpty())
+	g.Expect(s.CredentialProvider.Name).To(Equal("iam-ra"))
+	g.Expect(s.CredentialProvider.Error).To(ContainSubstring("reading AWS config file"))
+	g.Expect(s.Node.Ready).To(Equal("Unknown"))
+	g.Expect(s.Node.Error).To(Equal("connection refused"))
+}
@@ -1,6 +1,33 @@
 package system
 
+import (
+	"context"
+
+	"github.com/aws/eks-hybrid/internal/tracker"
+)
+
 type SystemAspect interface {
 	Name() string
-	Setup() error
+	Setup(ctx context.Context) error
+	// Teardown reverts the changes Setup recorded in the tracker.
+	Teardown(ctx context.Context) error
+}
+
+// recordChanges adds the changes made by the aspect to the tracker, so Teardown can revert them.
+func recordChanges(ctx context.Context, aspect string, changes tracker.AspectChanges) error {
+	installed, err := tracker.GetCurrentState()
+	if err != nil {
+		return err
+	}
+	installed.RecordAspectChanges(aspect, changes)
+	return installed.Save(ctx)
+}
+
+// recordedChanges returns the changes recorded in the tracker for the aspect.
+func recordedChanges(aspect string) (tracker.AspectChanges, error) {
+	installed, err := tracker.GetCurrentState()
+	if err != nil {
+		return tracker.AspectChanges{}, err
+	}
+	return installed.AspectChanges(aspect), nil
 }
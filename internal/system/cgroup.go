@@ -0,0 +1,21 @@
+package system
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// cgroupV2ControllersPath only exists when the unified cgroup v2 hierarchy is mounted.
+const cgroupV2ControllersPath = "/sys/fs/cgroup/cgroup.controllers"
+
+// IsCgroupV2 returns true if the host uses the unified cgroup v2 hierarchy.
+func IsCgroupV2() (bool, error) {
+	if _, err := os.Stat(cgroupV2ControllersPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
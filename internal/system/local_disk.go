@@ -1,13 +1,15 @@
 package system
 
 import (
-	"os"
+	"context"
+	"fmt"
 	"os/exec"
 	"strings"
 
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 const localDiskAspectName = "local-disk"
@@ -24,7 +26,7 @@ func (a *localDiskAspect) Name() string {
 	return localDiskAspectName
 }
 
-func (a *localDiskAspect) Setup() error {
+func (a *localDiskAspect) Setup(ctx context.Context) error {
 	if a.nodeConfig.Spec.Instance.LocalStorage.Strategy == "" {
 		zap.L().Info("Not configuring local disks!")
 		return nil
@@ -32,7 +34,14 @@ func (a *localDiskAspect) Setup() error {
 	strategy := strings.ToLower(string(a.nodeConfig.Spec.Instance.LocalStorage.Strategy))
 	// #nosec G204 Subprocess launched with variable
 	cmd := exec.Command("setup-local-disks", strategy)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	out, err := host.FromContext(ctx).Run(cmd)
+	if err != nil {
+		return fmt.Errorf("setting up local disks: %s: %w", out, err)
+	}
+	return nil
+}
+
+// Teardown is a no-op, EC2 instances are replaced instead of uninstalled.
+func (a *localDiskAspect) Teardown(ctx context.Context) error {
+	return nil
 }
@@ -2,15 +2,16 @@ package system
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"fmt"
-	"os"
 	"os/exec"
 	"text/template"
 
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -50,13 +51,18 @@ func (a *networkingAspect) Name() string {
 }
 
 // Setup executes the logic of this aspect.
-func (a *networkingAspect) Setup() error {
-	if err := a.ensureEKSNetworkConfiguration(); err != nil {
+func (a *networkingAspect) Setup(ctx context.Context) error {
+	if err := a.ensureEKSNetworkConfiguration(ctx); err != nil {
 		return fmt.Errorf("failed to ensure eks network configuration: %w", err)
 	}
 	return nil
 }
 
+// Teardown is a no-op, EC2 instances are replaced instead of uninstalled.
+func (a *networkingAspect) Teardown(ctx context.Context) error {
+	return nil
+}
+
 // ensureEKSNetworkConfiguration will install eks specific network configuration into system.
 // NOTE: this is a temporary fix for AL2023, where the `80-ec2.network` setup by amazon-ec2-net-utils will cause systemd.network
 // to manage all ENIs on host, and that can potentially result in multiple issues including:
@@ -66,7 +72,7 @@ func (a *networkingAspect) Setup() error {
 // To address this issue temporarily, we use drop-ins to alter configuration of `80-ec2.network` after boot to make it match against primary ENI only.
 // TODO: there are limitations on current solutions as well, and we should figure long term solution for this:
 //  1. the altNames for ENIs(a new feature in AL2023) were setup by amazon-ec2-net-utils via udev rules, but it's disabled by eks.
-func (a *networkingAspect) ensureEKSNetworkConfiguration() error {
+func (a *networkingAspect) ensureEKSNetworkConfiguration(ctx context.Context) error {
 	networkCfgDropInDir := fmt.Sprintf("%s/%s.d", administrationNetworkDir, ec2NetworkConfigurationName)
 	eksPrimaryENIOnlyConfPathName := fmt.Sprintf("%s/%s", networkCfgDropInDir, eksPrimaryENIOnlyConfName)
 	if exists, err := util.IsFilePathExists(eksPrimaryENIOnlyConfPathName); err != nil {
@@ -81,13 +87,14 @@ func (a *networkingAspect) ensureEKSNetworkConfiguration() error {
 		return fmt.Errorf("failed to generate eks_primary_eni_only network configuration: %w", err)
 	}
 	zap.L().Info("writing eks_primary_eni_only network configuration")
-	if err := os.MkdirAll(networkCfgDropInDir, networkConfDropInDirPerms); err != nil {
+	h := host.FromContext(ctx)
+	if err := h.MkdirAll(networkCfgDropInDir, networkConfDropInDirPerms); err != nil {
 		return fmt.Errorf("failed to create network configuration drop-in directory %s: %w", networkCfgDropInDir, err)
 	}
-	if err := os.WriteFile(eksPrimaryENIOnlyConfPathName, eksPrimaryENIOnlyConfContent, networkConfFilePerms); err != nil {
+	if err := h.WriteFile(eksPrimaryENIOnlyConfPathName, bytes.NewReader(eksPrimaryENIOnlyConfContent), networkConfFilePerms); err != nil {
 		return fmt.Errorf("failed to write eks_primary_eni_only network configuration: %w", err)
 	}
-	if err := a.reloadNetworkConfigurations(); err != nil {
+	if err := a.reloadNetworkConfigurations(h); err != nil {
 		return fmt.Errorf("failed to reload network configurations: %w", err)
 	}
 	return nil
@@ -112,9 +119,10 @@ func (a *networkingAspect) generateEKSPrimaryENIOnlyConfiguration() ([]byte, err
 	return buf.Bytes(), nil
 }
 
-func (a *networkingAspect) reloadNetworkConfigurations() error {
-	cmd := exec.Command("networkctl", "reload")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+func (a *networkingAspect) reloadNetworkConfigurations(h host.Host) error {
+	out, err := h.Run(exec.Command("networkctl", "reload"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", out, err)
+	}
+	return nil
 }
@@ -1,28 +1,92 @@
 package system
 
-import "github.com/go-ini/ini"
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-ini/ini"
+)
 
 const (
 	UbuntuOsName = "ubuntu"
 	RhelOsName   = "rhel"
 	AmazonOsName = "amzn"
+	RockyOsName  = "rocky"
+	AlmaOsName   = "almalinux"
+	DebianOsName = "debian"
+	SlesOsName   = "sles"
 
 	UbuntuResolvConfPath = "/run/systemd/resolve/resolv.conf"
+
+	osReleasePath = "/etc/os-release"
 )
 
+// osFamilies are the operating systems other distributions can derive from, in the
+// order they are matched against ID_LIKE.
+var osFamilies = []string{UbuntuOsName, DebianOsName, RhelOsName, AmazonOsName, SlesOsName}
+
+// OsRelease holds the fields of /etc/os-release that identify the operating system.
+type OsRelease struct {
+	ID              string
+	IDLike          []string
+	VersionID       string
+	VersionCodeName string
+}
+
+// GetOsRelease reads the /etc/os-release file. Missing fields are left empty.
+func GetOsRelease() OsRelease {
+	return readOsRelease(osReleasePath)
+}
+
+func readOsRelease(path string) OsRelease {
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return OsRelease{}
+	}
+	section := cfg.Section("")
+	return OsRelease{
+		ID:              section.Key("ID").String(),
+		IDLike:          strings.Fields(section.Key("ID_LIKE").String()),
+		VersionID:       section.Key("VERSION_ID").String(),
+		VersionCodeName: section.Key("VERSION_CODENAME").String(),
+	}
+}
+
+// IsLike returns true if the os is name or declares it in ID_LIKE.
+func (r OsRelease) IsLike(name string) bool {
+	return r.ID == name || slices.Contains(r.IDLike, name)
+}
+
+// Family returns the operating system this one derives from, so Rocky Linux and
+// AlmaLinux are handled as rhel. If none is known, it returns the os ID.
+func (r OsRelease) Family() string {
+	if slices.Contains(osFamilies, r.ID) {
+		return r.ID
+	}
+	for _, family := range osFamilies {
+		if slices.Contains(r.IDLike, family) {
+			return family
+		}
+	}
+	return r.ID
+}
+
 // GetOsName reads the /etc/os-release file and returns the os name
 func GetOsName() string {
-	cfg, _ := ini.Load("/etc/os-release")
-	return cfg.Section("").Key("ID").String()
+	return GetOsRelease().ID
+}
+
+// GetOsFamily returns the operating system the os in /etc/os-release derives from.
+func GetOsFamily() string {
+	return GetOsRelease().Family()
 }
 
 // GetOsNameWithVersion returns the os name and version on /etc/os-release file
 func GetOsNameWithVersion() (string, string) {
-	cfg, _ := ini.Load("/etc/os-release")
-	return cfg.Section("").Key("ID").String(), cfg.Section("").Key("VERSION_ID").String()
+	r := GetOsRelease()
+	return r.ID, r.VersionID
 }
 
 func GetVersionCodeName() string {
-	cfg, _ := ini.Load("/etc/os-release")
-	return cfg.Section("").Key("VERSION_CODENAME").String()
+	return GetOsRelease().VersionCodeName
 }
@@ -0,0 +1,107 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestReadOsRelease(t *testing.T) {
+	tests := []struct {
+		name      string
+		osRelease string
+		want      OsRelease
+		family    string
+		wantErr   string
+	}{
+		{
+			name: "rocky",
+			osRelease: `NAME="Rocky Linux"
+VERSION="9.4 (Blue Onyx)"
+ID="rocky"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="9.4"
+`,
+			want:   OsRelease{ID: RockyOsName, IDLike: []string{"rhel", "centos", "fedora"}, VersionID: "9.4"},
+			family: RhelOsName,
+		},
+		{
+			name: "almalinux",
+			osRelease: `NAME="AlmaLinux"
+ID="almalinux"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="8.10"
+`,
+			want:   OsRelease{ID: AlmaOsName, IDLike: []string{"rhel", "centos", "fedora"}, VersionID: "8.10"},
+			family: RhelOsName,
+		},
+		{
+			name: "debian",
+			osRelease: `PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+VERSION_ID="12"
+VERSION_CODENAME=bookworm
+ID=debian
+`,
+			want:   OsRelease{ID: DebianOsName, IDLike: []string{}, VersionID: "12", VersionCodeName: "bookworm"},
+			family: DebianOsName,
+		},
+		{
+			name: "ubuntu",
+			osRelease: `ID=ubuntu
+ID_LIKE=debian
+VERSION_ID="24.04"
+VERSION_CODENAME=noble
+`,
+			want:   OsRelease{ID: UbuntuOsName, IDLike: []string{"debian"}, VersionID: "24.04", VersionCodeName: "noble"},
+			family: UbuntuOsName,
+		},
+		{
+			name: "sles",
+			osRelease: `NAME="SLES"
+VERSION_ID="15.6"
+ID="sles"
+ID_LIKE="suse"
+`,
+			want:   OsRelease{ID: SlesOsName, IDLike: []string{"suse"}, VersionID: "15.6"},
+			family: SlesOsName,
+		},
+		{
+			name: "unsupported version",
+			osRelease: `ID="rhel"
+ID_LIKE="fedora"
+VERSION_ID="7.9"
+`,
+			want:    OsRelease{ID: RhelOsName, IDLike: []string{"fedora"}, VersionID: "7.9"},
+			family:  RhelOsName,
+			wantErr: "rhel 7.9 is not supported, supported versions are 8, 9",
+		},
+		{
+			name: "unknown os",
+			osRelease: `ID="opensuse-leap"
+ID_LIKE="suse opensuse"
+VERSION_ID="15.6"
+`,
+			want:    OsRelease{ID: "opensuse-leap", IDLike: []string{"suse", "opensuse"}, VersionID: "15.6"},
+			family:  "opensuse-leap",
+			wantErr: `operating system "opensuse-leap" is not supported`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			path := filepath.Join(t.TempDir(), "os-release")
+			g.Expect(os.WriteFile(path, []byte(tt.osRelease), 0o644)).To(Succeed())
+
+			r := readOsRelease(path)
+			g.Expect(r).To(Equal(tt.want))
+			g.Expect(r.Family()).To(Equal(tt.family))
+			if tt.wantErr == "" {
+				g.Expect(ValidateSupportedOs(r)).To(Succeed())
+			} else {
+				g.Expect(ValidateSupportedOs(r)).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
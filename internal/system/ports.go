@@ -1,12 +1,16 @@
 package system
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"maps"
+	"slices"
 
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
 	"github.com/aws/eks-hybrid/internal/firewall"
+	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
 const (
@@ -33,45 +37,109 @@ func NewPortsAspect(cfg *api.NodeConfig, logger *zap.Logger) SystemAspect {
 	}
 }
 
+// NewFirewallManager returns the manager of the first firewall enabled on the host,
+// checking the frontends before the backends they configure. If none is enabled, it
+// returns the default firewall of the OS.
 func NewFirewallManager() firewall.Manager {
-	osName := GetOsName()
-	if osName == UbuntuOsName {
-		return firewall.NewUncomplicatedFirewall()
+	return newFirewallManager(GetOsRelease())
+}
+
+func newFirewallManager(osRelease OsRelease) firewall.Manager {
+	candidates := []firewall.Manager{
+		firewall.NewFirewalld(),
+		firewall.NewIptables(),
+		firewall.NewNftables(),
+	}
+	if osRelease.IsLike(DebianOsName) {
+		candidates = append([]firewall.Manager{firewall.NewUncomplicatedFirewall()}, candidates...)
 	}
-	return firewall.NewFirewalld()
+	for _, manager := range candidates {
+		if enabled, err := manager.IsEnabled(); err == nil && enabled {
+			return manager
+		}
+	}
+	return candidates[0]
 }
 
 func (s *portsAspect) Name() string {
 	return portsAspectName
 }
 
-func (s *portsAspect) Setup() error {
+func (s *portsAspect) Setup(ctx context.Context) error {
 	firewallEnabled, err := s.firewallManager.IsEnabled()
 	if err != nil {
 		s.logger.Warn("Failed to get firewall status", zap.Error(err))
 		s.logger.Info("Skip setting firewall rules")
 		return nil
 	}
-	if firewallEnabled {
-		s.logger.Info("Allowing port on firewall", zap.Reflect("kubelet-server-port", kubeletServePort))
-		if err = s.firewallManager.AllowTcpPort(kubeletServePort); err != nil {
+	if !firewallEnabled {
+		s.logger.Info("No firewall enabled on the host. Skipping setting firewall rules...")
+		return nil
+	}
+
+	rules := []firewall.Rule{
+		firewall.TCPPort(kubeletServePort),
+		firewall.TCPPort(kubeProxyHealthzPort),
+		firewall.TCPPortRange(nodePortStartRangePort, nodePortEndRangePort),
+	}
+	var added []firewall.Rule
+	for _, rule := range rules {
+		allowed, err := s.firewallManager.IsAllowed(rule)
+		if err != nil {
 			return err
 		}
-		s.logger.Info("Allowing port on firewall", zap.Reflect("kube-proxy-port", kubeProxyHealthzPort))
-		if err = s.firewallManager.AllowTcpPort(kubeProxyHealthzPort); err != nil {
+		if allowed {
+			s.logger.Info("Port already allowed on firewall", zap.Stringer("rule", rule))
+			continue
+		}
+		s.logger.Info("Allowing port on firewall", zap.Stringer("rule", rule), zap.String("firewall", s.firewallManager.Name()))
+		if err := s.firewallManager.AllowRule(ctx, rule); err != nil {
 			return err
 		}
-		s.logger.Info("Allowing port on firewall", zap.Reflect("node-port-services",
-			fmt.Sprintf("%s-%s", nodePortStartRangePort, nodePortEndRangePort)))
-		if err = s.firewallManager.AllowTcpPortRange(nodePortStartRangePort, nodePortEndRangePort); err != nil {
+		added = append(added, rule)
+	}
+	s.logger.Info("Flushing firewall rules")
+	if err := s.firewallManager.FlushRules(ctx); err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	// Only the rules added here are recorded, uninstall leaves the ones that already
+	// allowed the traffic.
+	return recordChanges(ctx, portsAspectName, tracker.AspectChanges{
+		FirewallRules: map[string][]firewall.Rule{s.firewallManager.Name(): added},
+	})
+}
+
+// Teardown removes the firewall rules added by Setup. Rules that allowed the same
+// traffic before the node was initialized are not recorded and stay in place. Rules
+// of a firewall that was removed or stopped since are gone already, so they are
+// skipped with a warning.
+func (s *portsAspect) Teardown(ctx context.Context) error {
+	changes, err := recordedChanges(portsAspectName)
+	if err != nil {
+		return err
+	}
+	for _, backend := range slices.Sorted(maps.Keys(changes.FirewallRules)) {
+		manager, err := firewall.New(backend)
+		if err != nil {
 			return err
 		}
-		s.logger.Info("Flushing firewall rules")
-		if err = s.firewallManager.FlushRules(); err != nil {
+		for _, rule := range changes.FirewallRules[backend] {
+			s.logger.Info("Removing firewall rule", zap.Stringer("rule", rule), zap.String("firewall", backend))
+			if err := manager.RemoveRule(ctx, rule); errors.Is(err, firewall.ErrNotFound) {
+				s.logger.Warn("Firewall rule already gone", zap.Stringer("rule", rule), zap.String("firewall", backend), zap.Error(err))
+			} else if err != nil {
+				return err
+			}
+		}
+		if err := manager.FlushRules(ctx); errors.Is(err, firewall.ErrNotFound) {
+			s.logger.Warn("Skipping flushing firewall rules", zap.String("firewall", backend), zap.Error(err))
+		} else if err != nil {
 			return err
 		}
-	} else {
-		s.logger.Info("No firewall enabled on the host. Skipping setting firewall rules...")
 	}
 	return nil
 }
@@ -0,0 +1,94 @@
+package system
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/firewall"
+)
+
+// fakeFirewallBinaries puts in PATH, and only them, scripts named after the firewall
+// binaries that print the given output.
+func fakeFirewallBinaries(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for binary, output := range outputs {
+		// Only shell builtins, PATH has nothing else.
+		script := "#!/bin/sh\nwhile IFS= read -r line; do echo \"$line\"; done <<'EOF'\n" + output + "EOF\n"
+		if err := os.WriteFile(filepath.Join(dir, binary), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestNewFirewallManagerAcceptPolicy(t *testing.T) {
+	g := NewWithT(t)
+	fakeFirewallBinaries(t, map[string]string{
+		"iptables":  "-P INPUT ACCEPT\n",
+		"ip6tables": "-P INPUT ACCEPT\n",
+		"nft": `table inet filter {
+	chain input {
+		type filter hook input priority filter; policy accept;
+	}
+}
+`,
+	})
+
+	manager := newFirewallManager(OsRelease{ID: RhelOsName})
+	g.Expect(manager.Name()).To(Equal(firewall.FirewalldBackend))
+	g.Expect(manager.IsEnabled()).To(BeFalse())
+}
+
+func TestNewFirewallManagerIptablesNft(t *testing.T) {
+	g := NewWithT(t)
+	fakeFirewallBinaries(t, map[string]string{
+		"iptables": "-P INPUT DROP\n-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT\n",
+		"nft": `# Warning: table ip filter is managed by iptables-nft, do not touch!
+table ip filter {
+	chain INPUT {
+		type filter hook input priority filter; policy drop;
+		meta l4proto tcp tcp dport 22 counter packets 0 bytes 0 accept
+	}
+}
+`,
+	})
+
+	manager := newFirewallManager(OsRelease{ID: RhelOsName})
+	g.Expect(manager.Name()).To(Equal(firewall.IptablesBackend))
+	g.Expect(manager.IsEnabled()).To(BeTrue())
+	g.Expect(firewall.NewNftables().IsEnabled()).To(BeFalse())
+}
+
+func TestNewFirewallManagerIp6tables(t *testing.T) {
+	g := NewWithT(t)
+	fakeFirewallBinaries(t, map[string]string{
+		"iptables":  "-P INPUT ACCEPT\n",
+		"ip6tables": "-P INPUT DROP\n",
+	})
+
+	manager := newFirewallManager(OsRelease{ID: RhelOsName})
+	g.Expect(manager.Name()).To(Equal(firewall.IptablesBackend))
+	g.Expect(manager.IsAllowed(firewall.TCPPort("10250"))).To(BeFalse())
+}
+
+func TestRemoveRuleFirewallNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fakeFirewallBinaries(t, map[string]string{
+		"nft": `table inet filter {
+	chain input {
+		type filter hook input priority filter; policy accept;
+	}
+}
+`,
+	})
+
+	g.Expect(firewall.NewNftables().RemoveRule(ctx, firewall.TCPPort("10250"))).To(MatchError(firewall.ErrNotFound))
+	g.Expect(firewall.NewIptables().RemoveRule(ctx, firewall.TCPPort("10250"))).To(MatchError(firewall.ErrNotFound))
+	g.Expect(firewall.NewFirewalld().RemoveRule(ctx, firewall.TCPPort("10250"))).To(MatchError(firewall.ErrNotFound))
+}
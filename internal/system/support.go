@@ -0,0 +1,33 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+)
+
+// supportedOsVersions are the operating system versions nodeadm is validated on.
+// A version matches the VERSION_ID in /etc/os-release or its major version.
+var supportedOsVersions = map[string][]string{
+	AmazonOsName: {"2023"},
+	UbuntuOsName: {"20.04", "22.04", "24.04"},
+	RhelOsName:   {"8", "9"},
+	RockyOsName:  {"8", "9"},
+	AlmaOsName:   {"8", "9"},
+	DebianOsName: {"12"},
+	SlesOsName:   {"15"},
+}
+
+// ValidateSupportedOs returns an error if the operating system version is not in the
+// support matrix.
+func ValidateSupportedOs(r OsRelease) error {
+	versions, ok := supportedOsVersions[r.ID]
+	if !ok {
+		return fmt.Errorf("operating system %q is not supported", r.ID)
+	}
+	for _, version := range versions {
+		if r.VersionID == version || strings.HasPrefix(r.VersionID, version+".") {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %s is not supported, supported versions are %s", r.ID, r.VersionID, strings.Join(versions, ", "))
+}
@@ -3,22 +3,27 @@ package system
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"go.uber.org/zap"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/tracker"
 )
 
 const (
 	swapAspectName    = "swap"
 	swapTypePartition = "partition"
 	swapTypeFile      = "file"
+	fstabPath         = "/etc/fstab"
 )
 
 type swapAspect struct {
@@ -36,7 +41,11 @@ func (s *swapAspect) Name() string {
 	return swapAspectName
 }
 
-func (s *swapAspect) Setup() error {
+func (s *swapAspect) Setup(ctx context.Context) error {
+	if s.nodeConfig.Spec.Instance.Swap == api.SwapPolicyLimited {
+		s.logger.Info("Leaving swap enabled for kubelet to use with LimitedSwap")
+		return nil
+	}
 	hasSwapPartition, err := partitionSwapExists()
 	if err != nil {
 		return err
@@ -44,10 +53,49 @@ func (s *swapAspect) Setup() error {
 	if hasSwapPartition {
 		return fmt.Errorf("failed to disable swap: partition type swap found on the host")
 	}
-	if err = s.swapOff(); err != nil {
+	swapFiles, err := s.swapOff(ctx)
+	if err != nil {
+		return err
+	}
+	fstabEntries, err := disableSwapOnFstab(ctx)
+	if err != nil {
+		return err
+	}
+	return recordChanges(ctx, s.Name(), tracker.AspectChanges{FstabEntries: fstabEntries, SwapFiles: swapFiles})
+}
+
+// Teardown adds the swap entries removed by Setup back to /etc/fstab and turns the
+// swap files back on.
+func (s *swapAspect) Teardown(ctx context.Context) error {
+	changes, err := recordedChanges(s.Name())
+	if err != nil {
+		return err
+	}
+	if len(changes.FstabEntries) > 0 {
+		s.logger.Info("Restoring swap entries in /etc/fstab", zap.Strings("entries", changes.FstabEntries))
+		if err := restoreSwapOnFstab(ctx, changes.FstabEntries); err != nil {
+			return err
+		}
+	}
+	active, err := getSwapfilePaths()
+	if err != nil {
 		return err
 	}
-	return disableSwapOnFstab()
+	for _, path := range changes.SwapFiles {
+		if slices.ContainsFunc(active, func(swap *swap) bool { return swap.filePath == path }) {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			s.logger.Warn("Not enabling swap, swapfile is not accessible", zap.String("swapfile path", path), zap.Error(err))
+			continue
+		}
+		s.logger.Info("Enabling swap...", zap.String("swapfile path", path))
+		out, err := host.FromContext(ctx).Run(exec.Command("swapon", path))
+		if err != nil {
+			return fmt.Errorf("failed to turn on swap on %s, command output: %s, %v", path, out, err)
+		}
+	}
+	return nil
 }
 
 // Check if there are swaps of type partition exist on host because currently
@@ -68,32 +116,35 @@ func partitionSwapExists() (bool, error) {
 	return false, nil
 }
 
-func (s *swapAspect) swapOff() error {
+// swapOff turns off the file type swaps and returns their paths.
+func (s *swapAspect) swapOff(ctx context.Context) ([]string, error) {
 	swapfiles, err := getSwapfilePaths()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var disabled []string
 	for _, swap := range swapfiles {
 		path := swap.filePath
 		if swap.swapType == swapTypePartition {
-			return fmt.Errorf("partition type swapfile %s found in /proc/swaps, please remove the swapfile", swap.filePath)
+			return nil, fmt.Errorf("partition type swapfile %s found in /proc/swaps, please remove the swapfile", swap.filePath)
 		}
 		if _, err := os.Stat(path); err == nil {
 			s.logger.Info("Disabling swap...", zap.Reflect("swapfile path", path))
 			offCmd := exec.Command("swapoff", path)
-			out, err := offCmd.CombinedOutput()
+			out, err := host.FromContext(ctx).Run(offCmd)
 			if err != nil {
-				return fmt.Errorf("failed to turn of swap on %s, command output: %s, %v", path, out, err)
+				return nil, fmt.Errorf("failed to turn of swap on %s, command output: %s, %v", path, out, err)
 			}
+			disabled = append(disabled, path)
 		} else if errors.Is(err, fs.ErrNotExist) {
 			// path to swapfile does not exist
 			s.logger.Warn("swapfile path does not exists", zap.Reflect("swapfile path", path))
 		} else {
 			// file may or may not exist. See err for details.
-			return fmt.Errorf("unexpced error while trying to open /proc/swaps file: %v", err)
+			return nil, fmt.Errorf("unexpced error while trying to open /proc/swaps file: %v", err)
 		}
 	}
-	return nil
+	return disabled, nil
 }
 
 // Read swapfile paths from /proc/fstab file and return them as a list of string
@@ -146,36 +197,82 @@ type mount struct {
 	vfsType string
 }
 
-func disableSwapOnFstab() error {
-	file, err := os.OpenFile("/etc/fstab", os.O_RDWR, 0o644)
+// disableSwapOnFstab removes the swap entries from /etc/fstab and returns them.
+func disableSwapOnFstab(ctx context.Context) ([]string, error) {
+	content, perms, err := readFstab()
+	if err != nil {
+		return nil, err
+	}
+	kept, removed, err := removeSwapEntries(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := host.FromContext(ctx).WriteFile(fstabPath, bytes.NewReader(kept), perms); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// restoreSwapOnFstab adds entries back to /etc/fstab.
+func restoreSwapOnFstab(ctx context.Context, entries []string) error {
+	content, perms, err := readFstab()
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	return host.FromContext(ctx).WriteFile(fstabPath, bytes.NewReader(appendFstabEntries(content, entries)), perms)
+}
 
-	var bs []byte
-	buf := bytes.NewBuffer(bs)
-	scanner := bufio.NewScanner(file)
+func readFstab() ([]byte, fs.FileMode, error) {
+	info, err := os.Stat(fstabPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	content, err := os.ReadFile(fstabPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, info.Mode().Perm(), nil
+}
+
+// removeSwapEntries returns fstab without its swap entries, and the entries removed.
+func removeSwapEntries(fstab []byte) ([]byte, []string, error) {
+	var buf bytes.Buffer
+	var removed []string
+	scanner := bufio.NewScanner(bytes.NewReader(fstab))
 	lineNo := 0
 
 	for scanner.Scan() {
 		lineNo++
 		fstabMount, err := parseFstabLine(scanner.Text())
 		if err != nil {
-			return fmt.Errorf("/etc/fstab syntax error at line %d: %s", lineNo, err)
+			return nil, nil, fmt.Errorf("/etc/fstab syntax error at line %d: %s", lineNo, err)
 		}
-		if fstabMount == nil || fstabMount != nil && fstabMount.vfsType != "swap" {
-			buf.WriteString(scanner.Text() + "\n")
+		if fstabMount != nil && fstabMount.vfsType == "swap" {
+			removed = append(removed, scanner.Text())
+			continue
 		}
+		buf.WriteString(scanner.Text() + "\n")
 	}
-	if err := file.Truncate(0); err != nil {
-		return err
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
 	}
-	if _, err := file.Seek(0, 0); err != nil {
-		return err
+	return buf.Bytes(), removed, nil
+}
+
+// appendFstabEntries returns fstab with the entries that are not already in it appended.
+func appendFstabEntries(fstab []byte, entries []string) []byte {
+	lines := strings.Split(string(fstab), "\n")
+	var buf bytes.Buffer
+	buf.Write(fstab)
+	if len(fstab) > 0 && !bytes.HasSuffix(fstab, []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	for _, entry := range entries {
+		if !slices.Contains(lines, entry) {
+			buf.WriteString(entry + "\n")
+		}
 	}
-	_, err = buf.WriteTo(file)
-	return err
+	return buf.Bytes()
 }
 
 func parseFstabLine(line string) (*mount, error) {
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const fstab = `# /etc/fstab
+UUID=0a3407de-014b-458b-b5c1-848e92a327a3 /     ext4 defaults 0 1
+/swapfile                                 none  swap sw       0 0
+/dev/sdb1                                 /data xfs  defaults 0 2
+`
+
+func TestRemoveSwapEntries(t *testing.T) {
+	g := NewWithT(t)
+	kept, removed, err := removeSwapEntries([]byte(fstab))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(kept)).To(Equal(`# /etc/fstab
+UUID=0a3407de-014b-458b-b5c1-848e92a327a3 /     ext4 defaults 0 1
+/dev/sdb1                                 /data xfs  defaults 0 2
+`))
+	g.Expect(removed).To(Equal([]string{"/swapfile                                 none  swap sw       0 0"}))
+
+	_, _, err = removeSwapEntries([]byte("/swapfile none\n"))
+	g.Expect(err).To(MatchError(ContainSubstring("/etc/fstab syntax error at line 1")))
+}
+
+func TestAppendFstabEntries(t *testing.T) {
+	g := NewWithT(t)
+	kept, removed, err := removeSwapEntries([]byte(fstab))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	restored := appendFstabEntries(kept, removed)
+	g.Expect(string(restored)).To(Equal(`# /etc/fstab
+UUID=0a3407de-014b-458b-b5c1-848e92a327a3 /     ext4 defaults 0 1
+/dev/sdb1                                 /data xfs  defaults 0 2
+/swapfile                                 none  swap sw       0 0
+`))
+	// Entries already in the file are not duplicated.
+	g.Expect(appendFstabEntries(restored, removed)).To(Equal(restored))
+	g.Expect(string(appendFstabEntries([]byte("/dev/sdb1 /data xfs defaults 0 2"), []string{"/swapfile none swap sw 0 0"}))).
+		To(Equal("/dev/sdb1 /data xfs defaults 0 2\n/swapfile none swap sw 0 0\n"))
+}
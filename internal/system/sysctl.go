@@ -1,12 +1,15 @@
 package system
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"os/exec"
 	"path"
 
 	"github.com/aws/eks-hybrid/internal/api"
+	"github.com/aws/eks-hybrid/internal/host"
+	"github.com/aws/eks-hybrid/internal/tracker"
 	"github.com/aws/eks-hybrid/internal/util"
 )
 
@@ -37,20 +40,46 @@ func (s *sysctlAspect) Name() string {
 	return sysctlAspectName
 }
 
-func (s *sysctlAspect) Setup() error {
-	if err := writeSysctlConfig(); err != nil {
+func (s *sysctlAspect) Setup(ctx context.Context) error {
+	files, err := s.RenderConfig()
+	if err != nil {
+		return err
+	}
+	if err := util.WriteRenderedFiles(ctx, files); err != nil {
+		return err
+	}
+	if err := reloadSysctl(ctx); err != nil {
+		return err
+	}
+	return recordChanges(ctx, s.Name(), tracker.AspectChanges{Files: []string{nodeadmSysctlConfPath}})
+}
+
+// Teardown removes the sysctl drop-in and reloads the settings. Values that are not
+// set by any other file keep the value nodeadm set until the host reboots.
+func (s *sysctlAspect) Teardown(ctx context.Context) error {
+	changes, err := recordedChanges(s.Name())
+	if err != nil {
 		return err
 	}
-	return reloadSysctl()
+	if len(changes.Files) == 0 {
+		return nil
+	}
+	for _, file := range changes.Files {
+		if err := host.FromContext(ctx).RemoveAll(file); err != nil {
+			return err
+		}
+	}
+	return reloadSysctl(ctx)
 }
 
-func writeSysctlConfig() error {
-	return util.WriteFileWithDir(nodeadmSysctlConfPath, []byte(sysctlConfFileData), nodeadmSysctlFilePerm)
+// RenderConfig returns the sysctl drop-in written by Setup without writing it.
+func (s *sysctlAspect) RenderConfig() ([]util.RenderedFile, error) {
+	return []util.RenderedFile{{Path: nodeadmSysctlConfPath, Content: []byte(sysctlConfFileData), Perms: nodeadmSysctlFilePerm}}, nil
 }
 
-func reloadSysctl() error {
+func reloadSysctl(ctx context.Context) error {
 	reloadCmd := exec.Command(sysctlAspectName, "--system")
-	out, err := reloadCmd.CombinedOutput()
+	out, err := host.FromContext(ctx).Run(reloadCmd)
 	if err != nil {
 		return fmt.Errorf("running sysctl reload command: %s, error: %v", out, err)
 	}
@@ -1,6 +1,8 @@
 package tracker
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -10,18 +12,42 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-hybrid/internal/artifact"
-	"github.com/aws/eks-hybrid/internal/util"
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
-const trackerFile = "/opt/nodeadm/tracker"
+const (
+	trackerFile = "/opt/nodeadm/tracker"
+
+	// BackupDir is where the files replaced by an upgrade are copied to, so they can be rolled back.
+	BackupDir = "/opt/nodeadm/backup"
+)
 
 type Tracker struct {
+	// Version is the schema version of the tracker file.
+	Version   int
 	Artifacts *InstalledArtifacts
+	// Records holds the details of each installed artifact, keyed by component name.
+	Records map[string]*ArtifactRecord `json:",omitempty"`
+	// Backup holds the files as they were before the last upgrade.
+	Backup *artifact.Backup `json:",omitempty"`
+	// BackupRecords holds the artifact records as they were when Backup was taken.
+	BackupRecords map[string]*ArtifactRecord `json:",omitempty"`
+	// BackupHeldPackages holds the held packages as they were when Backup was taken.
+	BackupHeldPackages []string `json:",omitempty"`
+	// Aspects holds the changes made to the host by each system aspect.
+	Aspects map[string]*AspectChanges `json:",omitempty"`
+	// HeldPackages holds the distro packages nodeadm pinned to their installed version.
+	HeldPackages []string `json:",omitempty"`
+	// HoldPlugins holds the plugin packages nodeadm installed to hold packages.
+	HoldPlugins []string `json:",omitempty"`
+	// AWSConfigPaths holds the AWS config files written by init for the node credentials.
+	AWSConfigPaths []string `json:",omitempty"`
 }
 
 type InstalledArtifacts struct {
 	Containerd              string
 	CniPlugins              bool
+	CredentialProcess       bool
 	IamAuthenticator        bool
 	IamRolesAnywhere        bool
 	ImageCredentialProvider bool
@@ -36,6 +62,8 @@ func (tracker *Tracker) Add(componentName string) error {
 	switch componentName {
 	case artifact.CniPlugins:
 		tracker.Artifacts.CniPlugins = true
+	case artifact.CredentialProcess:
+		tracker.Artifacts.CredentialProcess = true
 	case artifact.IamAuthenticator:
 		tracker.Artifacts.IamAuthenticator = true
 	case artifact.IamRolesAnywhere:
@@ -60,24 +88,30 @@ func (tracker *Tracker) MarkContainerd(source string) {
 	tracker.Artifacts.Containerd = source
 }
 
-// Save() saves the tracker to file
-func (tracker *Tracker) Save() error {
+// Save() saves the tracker to file with the Host in the context.
+func (tracker *Tracker) Save(ctx context.Context) error {
+	tracker.Version = currentVersion
 	data, err := yaml.Marshal(tracker)
 	if err != nil {
 		return err
 	}
 
-	return util.WriteFileWithDir(trackerFile, data, 0o644)
+	return host.FromContext(ctx).WriteFile(trackerFile, bytes.NewReader(data), 0o644)
 }
 
-func Clear() error {
-	return os.RemoveAll(path.Dir(trackerFile))
+// Clear removes the tracker file with the Host in the context.
+func Clear(ctx context.Context) error {
+	return host.FromContext(ctx).RemoveAll(path.Dir(trackerFile))
 }
 
 // GetInstalledArtifacts reads the tracker file and returns the current
 // installed artifacts
 func GetInstalledArtifacts() (*Tracker, error) {
-	yamlFileData, err := os.ReadFile(trackerFile)
+	return load(trackerFile)
+}
+
+func load(path string) (*Tracker, error) {
+	yamlFileData, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +120,9 @@ func GetInstalledArtifacts() (*Tracker, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid yaml data in tracker")
 	}
+	if err := artifacts.migrate(); err != nil {
+		return nil, err
+	}
 	return &artifacts, nil
 }
 
@@ -96,7 +133,9 @@ func GetCurrentState() (*Tracker, error) {
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return &Tracker{
+				Version:   currentVersion,
 				Artifacts: &InstalledArtifacts{},
+				Records:   map[string]*ArtifactRecord{},
 			}, nil
 		}
 		return nil, err
@@ -0,0 +1,207 @@
+package tracker
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+	"github.com/aws/eks-hybrid/internal/firewall"
+)
+
+func TestLoadMigratesLegacyTracker(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tracker")
+	legacy := `Artifacts:
+  Containerd: distro
+  CniPlugins: true
+  Kubelet: true
+  Ssm: true
+`
+	g.Expect(os.WriteFile(path, []byte(legacy), 0o644)).To(Succeed())
+
+	tracker, err := load(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tracker.Version).To(Equal(currentVersion))
+	g.Expect(tracker.Artifacts.Containerd).To(Equal("distro"))
+	g.Expect(tracker.Artifacts.Kubelet).To(BeTrue())
+	g.Expect(tracker.Artifacts.Kubectl).To(BeFalse())
+	g.Expect(tracker.Records).To(BeEmpty())
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "tracker")
+	g.Expect(os.WriteFile(path, []byte("Version: 99\nArtifacts: {}\n"), 0o644)).To(Succeed())
+
+	_, err := load(path)
+	g.Expect(err).To(MatchError(ContainSubstring("tracker schema version 99 is not supported")))
+}
+
+func TestRecord(t *testing.T) {
+	g := NewWithT(t)
+	data := "kubelet binary"
+	checksum := []byte(sha256Hex(data) + "  kubelet")
+	src, err := artifact.WithChecksum(io.NopCloser(strings.NewReader(data)), sha256.New(), checksum)
+	g.Expect(err).NotTo(HaveOccurred())
+	src = artifact.WithMetadata(src, artifact.Metadata{Version: "1.31.2", URI: "https://example.com/kubelet"})
+
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.IsCurrent(artifact.Kubelet, src)).To(BeFalse())
+
+	tracker.Record(artifact.Kubelet, "/usr/bin/kubelet", src)
+	record := tracker.Records[artifact.Kubelet]
+	g.Expect(record.Version).To(Equal("1.31.2"))
+	g.Expect(record.SourceURI).To(Equal("https://example.com/kubelet"))
+	g.Expect(record.Sha256).To(Equal(sha256Hex(data)))
+	g.Expect(record.InstallPath).To(Equal("/usr/bin/kubelet"))
+	g.Expect(record.InstalledAt).NotTo(BeZero())
+	g.Expect(tracker.IsCurrent(artifact.Kubelet, src)).To(BeTrue())
+
+	other, err := artifact.WithChecksum(io.NopCloser(strings.NewReader("new")), sha256.New(), []byte(sha256Hex("new")+"  kubelet"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tracker.IsCurrent(artifact.Kubelet, other)).To(BeFalse())
+}
+
+func TestRecordKeepsInstalledAtWhenSkipped(t *testing.T) {
+	g := NewWithT(t)
+	newSource := func(data, version string) artifact.Source {
+		src, err := artifact.WithChecksum(io.NopCloser(strings.NewReader(data)), sha256.New(), []byte(sha256Hex(data)+"  kubelet"))
+		g.Expect(err).NotTo(HaveOccurred())
+		return artifact.WithMetadata(src, artifact.Metadata{Version: version})
+	}
+	installedAt := time.Date(2024, 11, 5, 10, 0, 0, 0, time.UTC)
+	tracker := &Tracker{
+		Artifacts: &InstalledArtifacts{},
+		Records: map[string]*ArtifactRecord{
+			artifact.Kubelet: {Version: "1.31.1", Sha256: sha256Hex("kubelet binary"), InstallPath: "/usr/bin/kubelet", InstalledAt: installedAt},
+		},
+	}
+
+	// The upgrade skipped the binary because it didn't change.
+	tracker.Record(artifact.Kubelet, "/usr/bin/kubelet", newSource("kubelet binary", "1.31.2"))
+	g.Expect(tracker.Records[artifact.Kubelet].InstalledAt).To(Equal(installedAt))
+	g.Expect(tracker.Records[artifact.Kubelet].Version).To(Equal("1.31.2"))
+
+	tracker.Record(artifact.Kubelet, "/usr/bin/kubelet", newSource("new kubelet binary", "1.31.3"))
+	g.Expect(tracker.Records[artifact.Kubelet].InstalledAt).To(BeTemporally(">", installedAt))
+}
+
+func sha256Hex(data string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(data)))
+}
+
+func TestRecordAspectChangesFirewallRules(t *testing.T) {
+	g := NewWithT(t)
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+
+	tracker.RecordAspectChanges("ports", AspectChanges{FirewallRules: map[string][]firewall.Rule{firewall.UfwBackend: nil}})
+	g.Expect(tracker.AspectChanges("ports").FirewallRules).To(BeNil())
+
+	tracker.RecordAspectChanges("ports", AspectChanges{FirewallRules: map[string][]firewall.Rule{
+		firewall.UfwBackend: {firewall.TCPPort("10250")},
+	}})
+	tracker.RecordAspectChanges("ports", AspectChanges{FirewallRules: map[string][]firewall.Rule{
+		firewall.UfwBackend: {firewall.TCPPort("10250"), firewall.TCPPortRange("30000", "32767")},
+	}})
+	g.Expect(tracker.AspectChanges("ports").FirewallRules).To(Equal(map[string][]firewall.Rule{
+		firewall.UfwBackend: {firewall.TCPPort("10250"), firewall.TCPPortRange("30000", "32767")},
+	}))
+
+	path := filepath.Join(t.TempDir(), "tracker")
+	data, err := yaml.Marshal(tracker)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.WriteFile(path, data, 0o644)).To(Succeed())
+	loaded, err := load(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(loaded.AspectChanges("ports")).To(Equal(tracker.AspectChanges("ports")))
+}
+
+func TestRecordAspectChanges(t *testing.T) {
+	g := NewWithT(t)
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+	g.Expect(tracker.AspectChanges("swap")).To(BeZero())
+
+	tracker.RecordAspectChanges("swap", AspectChanges{
+		FstabEntries: []string{"/swapfile none swap sw 0 0"},
+		SwapFiles:    []string{"/swapfile"},
+	})
+	// A later init finds nothing to change, the recorded changes are kept.
+	tracker.RecordAspectChanges("swap", AspectChanges{})
+	tracker.RecordAspectChanges("sysctl", AspectChanges{Files: []string{"/etc/sysctl.d/99-nodeadm.conf"}})
+	tracker.RecordAspectChanges("sysctl", AspectChanges{Files: []string{"/etc/sysctl.d/99-nodeadm.conf"}})
+
+	g.Expect(tracker.AspectChanges("swap")).To(Equal(AspectChanges{
+		FstabEntries: []string{"/swapfile none swap sw 0 0"},
+		SwapFiles:    []string{"/swapfile"},
+	}))
+	g.Expect(tracker.AspectChanges("sysctl")).To(Equal(AspectChanges{Files: []string{"/etc/sysctl.d/99-nodeadm.conf"}}))
+}
+
+func TestRecordHeldPackages(t *testing.T) {
+	g := NewWithT(t)
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+
+	tracker.RecordHeldPackages(nil)
+	g.Expect(tracker.HeldPackages).To(BeEmpty())
+
+	tracker.RecordHeldPackages([]string{"containerd", "runc"})
+	tracker.RecordHeldPackages([]string{"containerd", "runc", "iptables"})
+	g.Expect(tracker.HeldPackages).To(Equal([]string{"containerd", "runc", "iptables"}))
+
+	tracker.RecordHoldPlugin("")
+	g.Expect(tracker.HoldPlugins).To(BeEmpty())
+	tracker.RecordHoldPlugin("python3-dnf-plugin-versionlock")
+	tracker.RecordHoldPlugin("python3-dnf-plugin-versionlock")
+	g.Expect(tracker.HoldPlugins).To(Equal([]string{"python3-dnf-plugin-versionlock"}))
+}
+
+func TestRecordAWSConfigPath(t *testing.T) {
+	g := NewWithT(t)
+	tracker := &Tracker{Artifacts: &InstalledArtifacts{}}
+
+	tracker.RecordAWSConfigPath("")
+	g.Expect(tracker.AWSConfigPaths).To(BeEmpty())
+	g.Expect(tracker.CurrentAWSConfigPath()).To(BeEmpty())
+
+	tracker.RecordAWSConfigPath("/etc/custom/aws/config")
+	tracker.RecordAWSConfigPath("/etc/custom/aws/config")
+	g.Expect(tracker.AWSConfigPaths).To(Equal([]string{"/etc/custom/aws/config"}))
+
+	tracker.RecordAWSConfigPath("/etc/other/aws/config")
+	tracker.RecordAWSConfigPath("/etc/custom/aws/config")
+	g.Expect(tracker.AWSConfigPaths).To(Equal([]string{"/etc/other/aws/config", "/etc/custom/aws/config"}))
+	g.Expect(tracker.CurrentAWSConfigPath()).To(Equal("/etc/custom/aws/config"))
+}
+
+func TestBackupRestoresRecords(t *testing.T) {
+	g := NewWithT(t)
+	previous := &ArtifactRecord{Version: "v1.30.0", Sha256: "old", InstallPath: "/usr/bin/kubelet"}
+	tracker := &Tracker{
+		Artifacts:    &InstalledArtifacts{},
+		Records:      map[string]*ArtifactRecord{artifact.Kubelet: previous},
+		HeldPackages: []string{"containerd"},
+	}
+
+	tracker.SetBackup(&artifact.Backup{Dir: "/opt/nodeadm/backup"})
+	tracker.Records[artifact.Kubelet] = &ArtifactRecord{Version: "v1.31.0", Sha256: "new", InstallPath: "/usr/bin/kubelet"}
+	tracker.HeldPackages = []string{"containerd", "runc"}
+	g.Expect(tracker.BackupRecords[artifact.Kubelet]).To(Equal(previous))
+	g.Expect(tracker.BackupHeldPackages).To(Equal([]string{"containerd"}))
+
+	tracker.RestoreBackupRecords()
+	g.Expect(tracker.Records[artifact.Kubelet]).To(Equal(previous))
+
+	tracker.ClearBackup()
+	g.Expect(tracker.Backup).To(BeNil())
+	g.Expect(tracker.BackupRecords).To(BeNil())
+	g.Expect(tracker.BackupHeldPackages).To(BeNil())
+}
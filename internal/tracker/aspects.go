@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"slices"
+
+	"github.com/aws/eks-hybrid/internal/firewall"
+)
+
+// AspectChanges are the changes a system aspect made to the host, so uninstall can revert them.
+type AspectChanges struct {
+	// Files are the files written by the aspect.
+	Files []string `json:",omitempty"`
+	// FstabEntries are the lines removed from /etc/fstab, as they were.
+	FstabEntries []string `json:",omitempty"`
+	// SwapFiles are the swap files turned off.
+	SwapFiles []string `json:",omitempty"`
+	// FirewallRules are the rules added to the firewall, keyed by firewall backend.
+	// Rules that already allowed the traffic are not recorded.
+	FirewallRules map[string][]firewall.Rule `json:",omitempty"`
+}
+
+// RecordAspectChanges adds changes to the ones recorded for the aspect. Changes already
+// recorded by a previous init are kept, so they are reverted even if the host no longer
+// needs to be changed.
+func (tracker *Tracker) RecordAspectChanges(aspect string, changes AspectChanges) {
+	if tracker.Aspects == nil {
+		tracker.Aspects = map[string]*AspectChanges{}
+	}
+	recorded, ok := tracker.Aspects[aspect]
+	if !ok {
+		recorded = &AspectChanges{}
+		tracker.Aspects[aspect] = recorded
+	}
+	recorded.Files = appendMissing(recorded.Files, changes.Files)
+	recorded.FstabEntries = appendMissing(recorded.FstabEntries, changes.FstabEntries)
+	recorded.SwapFiles = appendMissing(recorded.SwapFiles, changes.SwapFiles)
+	for backend, rules := range changes.FirewallRules {
+		if len(rules) == 0 {
+			continue
+		}
+		if recorded.FirewallRules == nil {
+			recorded.FirewallRules = map[string][]firewall.Rule{}
+		}
+		recorded.FirewallRules[backend] = appendMissing(recorded.FirewallRules[backend], rules)
+	}
+}
+
+// AspectChanges returns the changes recorded for the aspect, empty if there are none.
+func (tracker *Tracker) AspectChanges(aspect string) AspectChanges {
+	if recorded, ok := tracker.Aspects[aspect]; ok {
+		return *recorded
+	}
+	return AspectChanges{}
+}
+
+func appendMissing[T comparable](values, added []T) []T {
+	for _, value := range added {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
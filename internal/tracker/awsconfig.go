@@ -0,0 +1,24 @@
+package tracker
+
+import "slices"
+
+// RecordAWSConfigPath stores the AWS config file init wrote for the node
+// credentials, so uninstall removes it even when it's not in the default path.
+// The path is moved last, as it's the one of the current node config.
+func (tracker *Tracker) RecordAWSConfigPath(path string) {
+	if path == "" {
+		return
+	}
+	tracker.AWSConfigPaths = append(slices.DeleteFunc(tracker.AWSConfigPaths, func(recorded string) bool {
+		return recorded == path
+	}), path)
+}
+
+// CurrentAWSConfigPath returns the AWS config file written by the last init, or an
+// empty string if none was recorded.
+func (tracker *Tracker) CurrentAWSConfigPath() string {
+	if len(tracker.AWSConfigPaths) == 0 {
+		return ""
+	}
+	return tracker.AWSConfigPaths[len(tracker.AWSConfigPaths)-1]
+}
@@ -0,0 +1,32 @@
+package tracker
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+// SetBackup stores the backup taken before an upgrade, together with the current
+// records and held packages, so rolling back the backup restores them too.
+func (tracker *Tracker) SetBackup(backup *artifact.Backup) {
+	tracker.Backup = backup
+	tracker.BackupRecords = maps.Clone(tracker.Records)
+	tracker.BackupHeldPackages = slices.Clone(tracker.HeldPackages)
+}
+
+// RestoreBackupRecords puts back the records saved with the backup. The held
+// packages are restored by the caller once they are held again.
+func (tracker *Tracker) RestoreBackupRecords() {
+	tracker.Records = maps.Clone(tracker.BackupRecords)
+	if tracker.Records == nil {
+		tracker.Records = map[string]*ArtifactRecord{}
+	}
+}
+
+// ClearBackup forgets the backup and the state saved with it.
+func (tracker *Tracker) ClearBackup() {
+	tracker.Backup = nil
+	tracker.BackupRecords = nil
+	tracker.BackupHeldPackages = nil
+}
@@ -0,0 +1,33 @@
+package tracker
+
+import "fmt"
+
+const (
+	// legacyVersion is the schema of tracker files written before the tracker was versioned,
+	// which only record whether each artifact is installed.
+	legacyVersion = 0
+	// currentVersion adds per artifact records.
+	currentVersion = 1
+)
+
+// migrate upgrades a tracker read from disk to the current schema. The result is
+// persisted the next time the tracker is saved.
+func (tracker *Tracker) migrate() error {
+	switch tracker.Version {
+	case currentVersion:
+	case legacyVersion:
+		// Legacy trackers don't know which version of each artifact is installed,
+		// records get populated the next time the artifacts are installed or upgraded.
+		tracker.Version = currentVersion
+	default:
+		return fmt.Errorf("tracker schema version %d is not supported by this version of nodeadm, latest supported is %d", tracker.Version, currentVersion)
+	}
+
+	if tracker.Artifacts == nil {
+		tracker.Artifacts = &InstalledArtifacts{}
+	}
+	if tracker.Records == nil {
+		tracker.Records = map[string]*ArtifactRecord{}
+	}
+	return nil
+}
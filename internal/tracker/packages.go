@@ -0,0 +1,16 @@
+package tracker
+
+// RecordHeldPackages stores the packages nodeadm put on hold, so uninstall
+// releases them before removing the packages.
+func (tracker *Tracker) RecordHeldPackages(packages []string) {
+	tracker.HeldPackages = appendMissing(tracker.HeldPackages, packages)
+}
+
+// RecordHoldPlugin stores the plugin package nodeadm installed to hold packages, so
+// uninstall removes it. Plugins that were already installed are not recorded.
+func (tracker *Tracker) RecordHoldPlugin(plugin string) {
+	if plugin == "" {
+		return
+	}
+	tracker.HoldPlugins = appendMissing(tracker.HoldPlugins, []string{plugin})
+}
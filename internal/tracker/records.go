@@ -0,0 +1,50 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/aws/eks-hybrid/internal/artifact"
+)
+
+// ArtifactRecord describes an artifact installed by nodeadm.
+type ArtifactRecord struct {
+	Version   string `json:",omitempty"`
+	SourceURI string `json:",omitempty"`
+	// Sha256 is the hex encoded checksum of the artifact as it was downloaded.
+	Sha256      string `json:",omitempty"`
+	InstallPath string
+	InstalledAt time.Time
+}
+
+// Record stores the details of the artifact installed at installPath from src.
+// src must have been completely read. If the artifact at installPath already has
+// the checksum of src, it was not reinstalled and InstalledAt is kept.
+func (tracker *Tracker) Record(componentName, installPath string, src artifact.Source) {
+	if tracker.Records == nil {
+		tracker.Records = map[string]*ArtifactRecord{}
+	}
+	metadata := artifact.GetMetadata(src)
+	record := &ArtifactRecord{
+		Version:     metadata.Version,
+		SourceURI:   metadata.URI,
+		Sha256:      hex.EncodeToString(src.ExpectedChecksum()),
+		InstallPath: installPath,
+		InstalledAt: time.Now().UTC(),
+	}
+	if previous, ok := tracker.Records[componentName]; ok && previous.Sha256 != "" &&
+		previous.Sha256 == record.Sha256 && previous.InstallPath == installPath {
+		record.InstalledAt = previous.InstalledAt
+	}
+	tracker.Records[componentName] = record
+}
+
+// IsCurrent returns true if the artifact recorded for componentName has the
+// same checksum src is expected to have.
+func (tracker *Tracker) IsCurrent(componentName string, src artifact.Source) bool {
+	record, ok := tracker.Records[componentName]
+	if !ok || record.Sha256 == "" {
+		return false
+	}
+	return record.Sha256 == hex.EncodeToString(src.ExpectedChecksum())
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/aws/eks-hybrid/internal/host"
 	"github.com/aws/eks-hybrid/internal/logger"
 )
 
@@ -15,15 +16,16 @@ import (
 // so they can be retried independently.
 type Builder func(context.Context) *exec.Cmd
 
-// Retry runs the command until it succeeds or the context is cancelled.
-// The backoff duration is the time to wait between retries.
+// Retry runs the command with the Host in the context until it succeeds or the
+// context is cancelled. The backoff duration is the time to wait between retries.
 func Retry(ctx context.Context, newCmd Builder, backoff time.Duration) error {
 	log := logger.FromContext(ctx)
+	h := host.FromContext(ctx)
 	var err error
 	for {
 		var out []byte
 		cmd := newCmd(ctx)
-		out, err = cmd.CombinedOutput()
+		out, err = h.Run(cmd)
 		if err == nil {
 			return nil
 		}
@@ -2,6 +2,8 @@ package util
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"io"
 	"io/fs"
@@ -9,6 +11,8 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+
+	"github.com/aws/eks-hybrid/internal/host"
 )
 
 // Wraps os.WriteFile to automatically create parent directories such that the
@@ -49,23 +53,45 @@ func WriteFileWithDirFromReader(path string, reader io.Reader, perm fs.FileMode)
 }
 
 // WriteFileUniqueLine creates the dir and file if it doesn't exist and writes the input data to the file
-// If the file already exist, the input data will only be appended if it doesn't exist in the file
-func WriteFileUniqueLine(filepath string, data []byte, perm fs.FileMode) error {
-	if err := os.MkdirAll(path.Dir(filepath), perm); err != nil {
-		return err
-	}
-	file, err := os.OpenFile(filepath, os.O_APPEND|os.O_CREATE|os.O_RDWR, perm)
-	if err != nil {
+// with the Host in the context. If the file already exist, the input data will only be appended if it
+// doesn't exist in the file
+func WriteFileUniqueLine(ctx context.Context, filepath string, data []byte, perm fs.FileMode) error {
+	existing, err := os.ReadFile(filepath)
+	if err != nil && !os.IsNotExist(err) {
 		return err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(existing))
 	for scanner.Scan() {
 		if strings.Contains(scanner.Text(), string(data)) {
 			return nil
 		}
 	}
-	_, err = file.WriteString(string(data) + "\n")
-	return err
+
+	content := bytes.NewBuffer(existing)
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		content.WriteString("\n")
+	}
+	content.Write(data)
+	content.WriteString("\n")
+	return host.FromContext(ctx).WriteFile(filepath, content, perm)
+}
+
+// RenderedFile is the content nodeadm writes to a path on disk.
+type RenderedFile struct {
+	Path    string
+	Content []byte
+	Perms   fs.FileMode
+}
+
+// WriteRenderedFiles writes every file with the Host in the context, creating the parent
+// directories as needed.
+func WriteRenderedFiles(ctx context.Context, files []RenderedFile) error {
+	h := host.FromContext(ctx)
+	for _, file := range files {
+		if err := h.WriteFile(file.Path, bytes.NewReader(file.Content), file.Perms); err != nil {
+			return err
+		}
+	}
+	return nil
 }
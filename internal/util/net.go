@@ -17,8 +17,31 @@ const userAgentHeader = "User-Agent"
 
 var userAgent = fmt.Sprintf("nodeadm/%s (%s/%s)", version.GitVersion, runtime.GOOS, runtime.GOARCH)
 
-func GetHttpFile(ctx context.Context, uri string) ([]byte, error) {
-	reader, err := GetHttpFileReader(ctx, uri)
+// HttpOption customizes the requests made by GetHttpFile and GetHttpFileReader.
+type HttpOption func(*httpOptions)
+
+type httpOptions struct {
+	client  *http.Client
+	headers http.Header
+}
+
+// WithHttpClient configures the http client used to perform the request.
+// This allows, for example, to trust a custom CA bundle.
+func WithHttpClient(client *http.Client) HttpOption {
+	return func(o *httpOptions) {
+		o.client = client
+	}
+}
+
+// WithHeader adds a header to the request.
+func WithHeader(key, value string) HttpOption {
+	return func(o *httpOptions) {
+		o.headers.Add(key, value)
+	}
+}
+
+func GetHttpFile(ctx context.Context, uri string, opts ...HttpOption) ([]byte, error) {
+	reader, err := GetHttpFileReader(ctx, uri, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -32,14 +55,27 @@ func GetHttpFile(ctx context.Context, uri string) ([]byte, error) {
 	return data, nil
 }
 
-func GetHttpFileReader(ctx context.Context, uri string) (io.ReadCloser, error) {
+func GetHttpFileReader(ctx context.Context, uri string, opts ...HttpOption) (io.ReadCloser, error) {
+	o := httpOptions{
+		client:  http.DefaultClient,
+		headers: http.Header{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed creating request from url: %s", uri)
 	}
 	request.Header.Add(userAgentHeader, userAgent)
+	for key, values := range o.headers {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
 
-	httpRetryClient := newRetryableHttpClient(2*time.Second, 3)
+	httpRetryClient := newRetryableHttpClient(o.client, 2*time.Second, 3)
 	resp, err := httpRetryClient.Do(request)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed reading file from url: %s", uri)
@@ -48,12 +84,14 @@ func GetHttpFileReader(ctx context.Context, uri string) (io.ReadCloser, error) {
 }
 
 type retryHttpClient struct {
+	client     *http.Client
 	backoff    time.Duration
 	maxRetries int
 }
 
-func newRetryableHttpClient(backoff time.Duration, maxRetries int) *retryHttpClient {
+func newRetryableHttpClient(client *http.Client, backoff time.Duration, maxRetries int) *retryHttpClient {
 	return &retryHttpClient{
+		client:     client,
 		backoff:    backoff,
 		maxRetries: maxRetries,
 	}
@@ -64,7 +102,7 @@ func (hc *retryHttpClient) Do(req *http.Request) (*http.Response, error) {
 	var err error
 
 	for range hc.maxRetries {
-		resp, err = http.DefaultClient.Do(req)
+		resp, err = hc.client.Do(req)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,246 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Concurrently runs all validations in parallel, waiting for their dependencies to
+// succeed before starting them, and aggregates the errors if present. Warnings are not
+// returned. A validation is skipped if one of its dependencies fails, and reported to
+// the Informer with an error for which IsSkipped is true. Dependencies that are not
+// registered, because they were skipped, are considered successful.
+//
+// Each validation runs with its own timeout, or the runner's one if not set. What
+// validations report to the Informer is buffered and replayed in registration order,
+// so the output is the same as running them Sequentially.
+//
+// obj must not be modified. If it is, this indicates a programming error and the method will panic.
+func (r *Runner[O]) Concurrently(ctx context.Context, obj O) error {
+	if err := r.checkDependencies(); err != nil {
+		return err
+	}
+
+	copyObj := obj.DeepCopy()
+	runs := make([]*run, len(r.validations))
+	byName := make(map[string]*run, len(r.validations))
+	for i, v := range r.validations {
+		runs[i] = &run{done: make(chan struct{}), informer: &bufferedInformer{}}
+		byName[v.Name] = runs[i]
+	}
+
+	var wg sync.WaitGroup
+	for i, v := range r.validations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			current := runs[i]
+			defer close(current.done)
+
+			for _, dep := range v.DependsOn {
+				depRun, ok := byName[dep]
+				if !ok {
+					continue
+				}
+				<-depRun.done
+				if depRun.failed() {
+					current.skipped = true
+					current.informer.Starting(ctx, v.Name, "Skipping validation "+v.Name)
+					current.informer.Done(ctx, v.Name, newSkipped(dep))
+					return
+				}
+			}
+
+			current.err = r.runWithTimeout(ctx, v, current.informer, copyObj)
+		}()
+	}
+
+	var errs []error
+	for _, current := range runs {
+		<-current.done
+		current.informer.replay(r.informer)
+		if current.failed() {
+			errs = append(errs, Unwrap(current.err)...)
+		}
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(obj, copyObj) {
+		panic("validations must not modify the object under validation")
+	}
+
+	return errors.Join(errs...)
+}
+
+// runWithTimeout runs v and returns a timeout error if it doesn't finish in time, even
+// if the validation doesn't honor the context cancellation.
+func (r *Runner[O]) runWithTimeout(ctx context.Context, v Validation[O], informer *bufferedInformer, obj O) error {
+	timeout := v.Timeout
+	if timeout == 0 {
+		timeout = r.config.validationTimeout
+	}
+	if timeout == 0 {
+		return v.Validate(ctx, informer, obj)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- v.Validate(ctx, informer, obj)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		err := WithRemediation(
+			fmt.Errorf("validation %s timed out after %s", v.Name, timeout),
+			"Check the network connectivity of the node, the validation might be waiting on an unreachable endpoint.",
+		)
+		informer.close(ctx, err)
+		return err
+	}
+}
+
+// checkDependencies returns an error if the dependencies between validations have a cycle.
+func (r *Runner[O]) checkDependencies() error {
+	deps := make(map[string][]string, len(r.validations))
+	for _, v := range r.validations {
+		deps[v.Name] = v.DependsOn
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(deps))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("validations have a circular dependency: %v", append(path, name))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, v := range r.validations {
+		if err := visit(v.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type run struct {
+	done     chan struct{}
+	informer *bufferedInformer
+	err      error
+	skipped  bool
+}
+
+func (r *run) failed() bool {
+	return r.skipped || (r.err != nil && !IsWarning(r.err))
+}
+
+type informerEvent struct {
+	ctx     context.Context
+	name    string
+	message string
+	err     error
+	done    bool
+}
+
+// bufferedInformer keeps the events of a validation so they can be replayed once it finishes.
+type bufferedInformer struct {
+	mu      sync.Mutex
+	events  []informerEvent
+	started map[string]int
+	closed  bool
+}
+
+var _ Informer = &bufferedInformer{}
+
+func (b *bufferedInformer) Starting(ctx context.Context, name, message string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	if b.started == nil {
+		b.started = map[string]int{}
+	}
+	b.started[name]++
+	b.events = append(b.events, informerEvent{ctx: withEventTime(ctx, time.Now()), name: name, message: message})
+}
+
+func (b *bufferedInformer) Done(ctx context.Context, name string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.started[name]--
+	b.events = append(b.events, informerEvent{ctx: withEventTime(ctx, time.Now()), name: name, err: err, done: true})
+}
+
+// close stops recording events and finishes the validations that started but are not done with err.
+func (b *bufferedInformer) close(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for _, e := range b.events {
+		if e.done || b.started[e.name] <= 0 {
+			continue
+		}
+		b.started[e.name]--
+		b.events = append(b.events, informerEvent{ctx: withEventTime(ctx, time.Now()), name: e.name, err: err, done: true})
+	}
+}
+
+func (b *bufferedInformer) replay(informer Informer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, e := range b.events {
+		if e.done {
+			informer.Done(e.ctx, e.name, e.err)
+		} else {
+			informer.Starting(e.ctx, e.name, e.message)
+		}
+	}
+	b.events = nil
+}
+
+type eventTimeKey struct{}
+
+// withEventTime returns a context that carries when an informer event happened, for
+// events delivered after the fact.
+func withEventTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, eventTimeKey{}, t)
+}
+
+// EventTime returns when the informer event with ctx happened. Events can be delivered
+// to the Informer some time after they happen, when validations run Concurrently.
+func EventTime(ctx context.Context) time.Time {
+	if t, ok := ctx.Value(eventTimeKey{}).(time.Time); ok {
+		return t
+	}
+	return time.Now()
+}
@@ -0,0 +1,218 @@
+package validation_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func reporting(name string, delay time.Duration, err error) validation.Validation[*nodeConfig] {
+	return validation.New(name, func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+		informer.Starting(ctx, name, "Validating "+name)
+		time.Sleep(delay)
+		informer.Done(ctx, name, err)
+		return err
+	})
+}
+
+// barrier returns a validation that only finishes once all the validations
+// sharing the WaitGroup have started, so it deadlocks unless they run concurrently.
+func barrier(name string, started *sync.WaitGroup, err error) validation.Validation[*nodeConfig] {
+	return validation.New(name, func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+		informer.Starting(ctx, name, "Validating "+name)
+		started.Done()
+		started.Wait()
+		informer.Done(ctx, name, err)
+		return err
+	})
+}
+
+func TestRunnerConcurrentlyOrderedOutput(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	r := validation.NewRunner[*nodeConfig](validation.NewPrinter(validation.WithNoColor(), validation.WithOutWriter(&buf)))
+
+	var started sync.WaitGroup
+	started.Add(3)
+	r.Register(
+		barrier("slow", &started, nil),
+		barrier("fast", &started, errors.New("fast failed")),
+		barrier("medium", &started, nil),
+	)
+
+	err := r.Concurrently(ctx, &nodeConfig{})
+	g.Expect(err).To(MatchError("fast failed"))
+	g.Expect(buf.String()).To(Equal(`* Validating slow [Success]
+* Validating fast [Failed]
+  └─ Error
+     └─ fast failed
+* Validating medium [Success]
+`))
+}
+
+func TestRunnerConcurrentlyDependencies(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+	r := validation.NewRunner[*nodeConfig](recorder)
+
+	var authRanAfterNetwork, identityRan atomic.Bool
+	var networkDone atomic.Bool
+	r.Register(
+		validation.New("network", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			time.Sleep(20 * time.Millisecond)
+			networkDone.Store(true)
+			return nil
+		}),
+		validation.New("auth", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			authRanAfterNetwork.Store(networkDone.Load())
+			return errors.New("unauthorized")
+		}).DependingOn("network"),
+		validation.New("identity", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			identityRan.Store(true)
+			return nil
+		}).DependingOn("auth"),
+		validation.New("uses-skipped", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			return nil
+		}).DependingOn("not-registered"),
+	)
+
+	err := r.Concurrently(ctx, &nodeConfig{})
+	g.Expect(err).To(MatchError("unauthorized"))
+	g.Expect(authRanAfterNetwork.Load()).To(BeTrue())
+	g.Expect(identityRan.Load()).To(BeFalse())
+}
+
+func TestRunnerConcurrentlyReportsSkippedDependents(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+	r := validation.NewRunner[*nodeConfig](recorder)
+	r.Register(
+		reporting("auth", 0, errors.New("unauthorized")),
+		reporting("identity", 0, nil).DependingOn("auth"),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(MatchError("unauthorized"))
+	results := recorder.Results()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[1].Name).To(Equal("identity"))
+	g.Expect(results[1].Result).To(Equal(validation.ResultSkipped))
+	g.Expect(results[1].Errors[0].Message).To(Equal("skipped: dependency auth failed"))
+
+	var buf bytes.Buffer
+	g.Expect(recorder.Write(&buf, validation.OutputJUnit)).To(Succeed())
+	g.Expect(buf.String()).To(ContainSubstring(`<skipped message="skipped: dependency auth failed"></skipped>`))
+	g.Expect(buf.String()).To(ContainSubstring(`tests="2" failures="1" skipped="1"`))
+}
+
+func TestRunnerConcurrentlyWarningDoesntBlockDependents(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{})
+
+	var ran atomic.Bool
+	r.Register(
+		validation.New("first", func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			return validation.NewWarning(errors.New("almost"), "fix it")
+		}),
+		validation.New("second", func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			ran.Store(true)
+			return nil
+		}).DependingOn("first"),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(Succeed())
+	g.Expect(ran.Load()).To(BeTrue())
+}
+
+func TestRunnerConcurrentlyTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	var buf bytes.Buffer
+	r := validation.NewRunner[*nodeConfig](
+		validation.NewPrinter(validation.WithNoColor(), validation.WithOutWriter(&buf)),
+		validation.WithValidationTimeout(time.Hour),
+	)
+
+	r.Register(
+		validation.New("hangs", func(ctx context.Context, informer validation.Informer, _ *nodeConfig) error {
+			informer.Starting(ctx, "hangs", "Validating something slow")
+			// Ignores the context on purpose.
+			time.Sleep(time.Second)
+			informer.Done(ctx, "hangs", nil)
+			return nil
+		}).WithTimeout(20*time.Millisecond),
+		reporting("quick", 0, nil),
+	)
+
+	start := time.Now()
+	err := r.Concurrently(ctx, &nodeConfig{})
+	g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	g.Expect(err).To(MatchError("validation hangs timed out after 20ms"))
+	g.Expect(buf.String()).To(Equal(`* Validating something slow [Failed]
+  └─ Error
+     └─ validation hangs timed out after 20ms
+     └─ Remediation
+        └─ Check the network connectivity of the node, the validation might be waiting on an unreachable endpoint.
+* Validating quick [Success]
+`))
+}
+
+func TestRunnerConcurrentlyCircularDependency(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{})
+
+	noop := func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error { return nil }
+	r.Register(
+		validation.New("a", noop).DependingOn("b"),
+		validation.New("b", noop).DependingOn("c"),
+		validation.New("c", noop).DependingOn("a"),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(MatchError(ContainSubstring("circular dependency")))
+}
+
+func TestRunnerConcurrentlyRecorderKeepsEventTimes(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+	r := validation.NewRunner[*nodeConfig](recorder)
+
+	r.Register(
+		reporting("slow", 50*time.Millisecond, nil),
+		reporting("fast", 0, nil),
+	)
+
+	g.Expect(r.Concurrently(ctx, &nodeConfig{})).To(Succeed())
+	results := recorder.Results()
+	g.Expect(results).To(HaveLen(2))
+	g.Expect(results[0].Name).To(Equal("slow"))
+	g.Expect(results[1].Name).To(Equal("fast"))
+	// fast ran while slow was still running, even if it was reported after.
+	g.Expect(results[1].End).To(BeTemporally("<", results[0].End))
+}
+
+func TestRunnerConcurrentlyPanicAfterModifyingObject(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{})
+	r.Register(
+		newValidation(func(ctx context.Context, _ validation.Informer, config *nodeConfig) error {
+			config.maxPods = 5
+			return nil
+		}),
+	)
+
+	g.Expect(func() { _ = r.Concurrently(ctx, &nodeConfig{}) }).To(PanicWith("validations must not modify the object under validation"))
+}
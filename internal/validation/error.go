@@ -1,6 +1,9 @@
 package validation
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Remediable is an error that provides a possible remediation.
 type Remediable interface {
@@ -18,6 +21,11 @@ func (e *remediableError) Remediation() string {
 	return e.remediation
 }
 
+// Unwrap returns the error that needs remediation.
+func (e *remediableError) Unwrap() error {
+	return e.error
+}
+
 // NewRemediableErr returns a new [Remediable] error.
 func NewRemediableErr(err, remediation string) error {
 	return &remediableError{
@@ -50,3 +58,42 @@ func Remediation(err error) string {
 
 	return fixable.Remediation()
 }
+
+// warning is a Remediable error that is reported but doesn't fail the validation.
+type warning struct {
+	remediableError
+}
+
+// NewWarning returns an error that is reported to the user with its remediation
+// but doesn't make the validation fail.
+func NewWarning(err error, remediation string) error {
+	return &warning{
+		remediableError: remediableError{
+			error:       err,
+			remediation: remediation,
+		},
+	}
+}
+
+// IsWarning checks if an error is a warning.
+func IsWarning(err error) bool {
+	_, ok := err.(*warning)
+	return ok
+}
+
+// skipped is reported for a validation that didn't run because one of its dependencies
+// failed. It doesn't make the validation fail.
+type skipped struct {
+	error
+}
+
+// newSkipped returns the error reported for a validation skipped because dependency failed.
+func newSkipped(dependency string) error {
+	return &skipped{error: fmt.Errorf("skipped: dependency %s failed", dependency)}
+}
+
+// IsSkipped checks if an error reports a skipped validation.
+func IsSkipped(err error) bool {
+	_, ok := err.(*skipped)
+	return ok
+}
@@ -67,3 +67,14 @@ func TestRemediation(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWarning(t *testing.T) {
+	g := NewWithT(t)
+	warning := validation.NewWarning(errors.New("expires soon"), "renew it")
+
+	g.Expect(validation.IsWarning(warning)).To(BeTrue())
+	g.Expect(validation.IsRemediable(warning)).To(BeTrue())
+	g.Expect(validation.Remediation(warning)).To(Equal("renew it"))
+	g.Expect(warning).To(MatchError("expires soon"))
+	g.Expect(validation.IsWarning(validation.NewRemediableErr("fails", "fix it"))).To(BeFalse())
+}
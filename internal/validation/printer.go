@@ -71,6 +71,21 @@ func (p Printer) Done(ctx context.Context, name string, err error) {
 		return
 	}
 
+	if IsSkipped(err) {
+		p.println("[%s]", p.color.Grey("Skipped"))
+		p.println("  └─ %s", err)
+		return
+	}
+
+	if IsWarning(err) {
+		p.println("[%s]", p.color.Yellow("Warning"))
+		p.println("  └─ %s", p.color.Yellow("Warning"))
+		p.println("     └─ %s", err)
+		p.println("     └─ %s", p.color.Blue("Remediation"))
+		p.println("        └─ %s", Remediation(err))
+		return
+	}
+
 	p.println("[%s]", p.color.Red("Failed"))
 
 	errs := Unwrap(err)
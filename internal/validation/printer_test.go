@@ -26,6 +26,8 @@ func TestPrinter(t *testing.T) {
 	printer.Done(ctx, "test", errors.New("error message"))
 	printer.Starting(ctx, "test", "Third validation")
 	printer.Done(ctx, "test", validation.NewRemediableErr("another error message", "you can fix this"))
+	printer.Starting(ctx, "test", "Warning validation")
+	printer.Done(ctx, "test", validation.NewWarning(errors.New("about to fail"), "fix this soon"))
 	printer.Starting(ctx, "test", "Fourth validation")
 	printer.Done(ctx, "test",
 		errors.Join(
@@ -45,6 +47,11 @@ func TestPrinter(t *testing.T) {
      └─ another error message
      └─ Remediation
         └─ you can fix this
+* Warning validation [Warning]
+  └─ Warning
+     └─ about to fail
+     └─ Remediation
+        └─ fix this soon
 * Fourth validation [Failed]
   ├─ Error
   │  └─ error message
@@ -0,0 +1,279 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	OutputText  = "text"
+	OutputJSON  = "json"
+	OutputJUnit = "junit"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultWarning = "warning"
+	ResultSkipped = "skipped"
+)
+
+// Result is the outcome of a single validation.
+type Result struct {
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Result  string    `json:"result"`
+	Errors  []Error   `json:"errors,omitempty"`
+}
+
+// Error is one of the errors a validation failed with.
+type Error struct {
+	// Message is the full error message.
+	Message string `json:"message"`
+	// Chain holds the messages of the error and each error it wraps, outermost first.
+	Chain       []string `json:"chain,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Recorder is an Informer that keeps the result of each validation so they can be
+// written in a machine-readable format once all validations are done.
+type Recorder struct {
+	mu      sync.Mutex
+	results []*Result
+}
+
+var _ Informer = &Recorder{}
+
+// NewRecorder returns a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Starting records the start of a validation.
+func (r *Recorder) Starting(ctx context.Context, name, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, &Result{
+		Name:    name,
+		Message: message,
+		Start:   EventTime(ctx),
+	})
+}
+
+// Done records the result of a validation.
+func (r *Recorder) Done(ctx context.Context, name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.running(name)
+	if result == nil {
+		result = &Result{Name: name, Start: EventTime(ctx)}
+		r.results = append(r.results, result)
+	}
+	result.End = EventTime(ctx)
+
+	switch {
+	case err == nil:
+		result.Result = ResultSuccess
+	case IsWarning(err):
+		result.Result = ResultWarning
+	case IsSkipped(err):
+		result.Result = ResultSkipped
+	default:
+		result.Result = ResultFailure
+	}
+
+	if err != nil {
+		for _, e := range Unwrap(err) {
+			result.Errors = append(result.Errors, newError(e))
+		}
+	}
+}
+
+// running returns the last validation started with name that is not done yet.
+func (r *Recorder) running(name string) *Result {
+	for i := len(r.results) - 1; i >= 0; i-- {
+		if r.results[i].Name == name && r.results[i].End.IsZero() {
+			return r.results[i]
+		}
+	}
+	return nil
+}
+
+// Results returns a copy of the recorded results in the order the validations started.
+func (r *Recorder) Results() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := make([]Result, 0, len(r.results))
+	for _, result := range r.results {
+		results = append(results, *result)
+	}
+	return results
+}
+
+// Write writes the recorded results to w in the given output format.
+func (r *Recorder) Write(w io.Writer, output string) error {
+	switch output {
+	case OutputJSON:
+		return writeJSON(w, r.Results())
+	case OutputJUnit:
+		return writeJUnit(w, r.Results())
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats: [%s, %s]", output, OutputJSON, OutputJUnit)
+	}
+}
+
+func newError(err error) Error {
+	e := Error{
+		Message:     err.Error(),
+		Remediation: Remediation(err),
+	}
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		// Some wrappers, like the ones adding a stack trace, don't change the message.
+		if len(e.Chain) > 0 && e.Chain[len(e.Chain)-1] == cause.Error() {
+			continue
+		}
+		e.Chain = append(e.Chain, cause.Error())
+	}
+	return e
+}
+
+type jsonReport struct {
+	Validations []Result `json:"validations"`
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	data, err := json.MarshalIndent(jsonReport{Validations: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, results []Result) error {
+	suite := junitTestSuite{Name: "nodeadm-debug"}
+	var start, end time.Time
+	for _, result := range results {
+		if start.IsZero() || result.Start.Before(start) {
+			start = result.Start
+		}
+		if result.End.After(end) {
+			end = result.End
+		}
+
+		testCase := junitTestCase{
+			Name:      result.Name,
+			ClassName: suite.Name,
+			Time:      seconds(result.End.Sub(result.Start)),
+		}
+		switch result.Result {
+		case ResultFailure:
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: errorMessages(result.Errors),
+				Type:    ResultFailure,
+				Body:    errorDetails(result.Errors),
+			}
+		case ResultSkipped:
+			suite.Skipped++
+			testCase.Skipped = &junitSkipped{Message: errorMessages(result.Errors)}
+		case ResultWarning:
+			testCase.SystemOut = errorDetails(result.Errors)
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = seconds(end.Sub(start))
+	if !start.IsZero() {
+		suite.Timestamp = start.UTC().Format(time.RFC3339)
+	}
+
+	report := junitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, data)
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func errorMessages(errs []Error) string {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func errorDetails(errs []Error) string {
+	var b strings.Builder
+	for _, e := range errs {
+		fmt.Fprintf(&b, "Error: %s\n", e.Message)
+		for _, cause := range e.Chain[1:] {
+			fmt.Fprintf(&b, "  Caused by: %s\n", cause)
+		}
+		if e.Remediation != "" {
+			fmt.Fprintf(&b, "Remediation: %s\n", e.Remediation)
+		}
+	}
+	return b.String()
+}
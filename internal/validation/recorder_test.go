@@ -0,0 +1,133 @@
+package validation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-hybrid/internal/validation"
+)
+
+func recordValidations(ctx context.Context, recorder *validation.Recorder) {
+	recorder.Starting(ctx, "network", "Validating network")
+	recorder.Done(ctx, "network", nil)
+	recorder.Starting(ctx, "auth", "Validating auth")
+	recorder.Done(ctx, "auth", validation.WithRemediation(
+		fmt.Errorf("authenticating: %w", errors.New("access denied")),
+		"Check your credentials",
+	))
+	recorder.Starting(ctx, "certificate", "Validating certificate")
+	recorder.Done(ctx, "certificate", validation.NewWarning(errors.New("expires in 3 days"), "Rotate it"))
+}
+
+func TestRecorderResults(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+
+	recordValidations(ctx, recorder)
+
+	results := recorder.Results()
+	g.Expect(results).To(HaveLen(3))
+	for _, result := range results {
+		g.Expect(result.Start).NotTo(BeZero())
+		g.Expect(result.End).NotTo(BeTemporally("<", result.Start))
+	}
+
+	g.Expect(results[0].Name).To(Equal("network"))
+	g.Expect(results[0].Message).To(Equal("Validating network"))
+	g.Expect(results[0].Result).To(Equal(validation.ResultSuccess))
+	g.Expect(results[0].Errors).To(BeEmpty())
+
+	g.Expect(results[1].Result).To(Equal(validation.ResultFailure))
+	g.Expect(results[1].Errors).To(ConsistOf(validation.Error{
+		Message:     "authenticating: access denied",
+		Chain:       []string{"authenticating: access denied", "access denied"},
+		Remediation: "Check your credentials",
+	}))
+
+	g.Expect(results[2].Result).To(Equal(validation.ResultWarning))
+	g.Expect(results[2].Errors[0].Remediation).To(Equal("Rotate it"))
+}
+
+func TestRecorderJoinedErrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+
+	recorder.Done(ctx, "not-started", errors.Join(errors.New("first"), errors.New("second")))
+
+	results := recorder.Results()
+	g.Expect(results).To(HaveLen(1))
+	g.Expect(results[0].Result).To(Equal(validation.ResultFailure))
+	g.Expect(results[0].Errors).To(HaveLen(2))
+	g.Expect(results[0].Errors[0].Message).To(Equal("first"))
+	g.Expect(results[0].Errors[1].Message).To(Equal("second"))
+}
+
+func TestRecorderWriteJSON(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+	recordValidations(ctx, recorder)
+
+	var buf bytes.Buffer
+	g.Expect(recorder.Write(&buf, validation.OutputJSON)).To(Succeed())
+
+	var report struct {
+		Validations []validation.Result `json:"validations"`
+	}
+	g.Expect(json.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+	g.Expect(report.Validations).To(HaveLen(3))
+	g.Expect(report.Validations[1].Name).To(Equal("auth"))
+	g.Expect(report.Validations[1].Errors[0].Remediation).To(Equal("Check your credentials"))
+}
+
+func TestRecorderWriteJUnit(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	recorder := validation.NewRecorder()
+	recordValidations(ctx, recorder)
+
+	var buf bytes.Buffer
+	g.Expect(recorder.Write(&buf, validation.OutputJUnit)).To(Succeed())
+
+	var report struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Cases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Body    string `xml:",chardata"`
+				} `xml:"failure"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	g.Expect(xml.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+	g.Expect(report.Tests).To(Equal(3))
+	g.Expect(report.Failures).To(Equal(1))
+	g.Expect(report.Suites).To(HaveLen(1))
+
+	cases := report.Suites[0].Cases
+	g.Expect(cases).To(HaveLen(3))
+	g.Expect(cases[0].Failure).To(BeNil())
+	g.Expect(cases[1].Failure).NotTo(BeNil())
+	g.Expect(cases[1].Failure.Message).To(Equal("authenticating: access denied"))
+	g.Expect(cases[1].Failure.Body).To(Equal("Error: authenticating: access denied\n  Caused by: access denied\nRemediation: Check your credentials\n"))
+	g.Expect(cases[2].Failure).To(BeNil())
+	g.Expect(cases[2].SystemOut).To(ContainSubstring("expires in 3 days"))
+}
+
+func TestRecorderWriteInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validation.NewRecorder().Write(&bytes.Buffer{}, "yaml")).To(MatchError("invalid output format yaml, supported formats: [json, junit]"))
+}
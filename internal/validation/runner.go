@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // Validatable is anything that can be validated.
@@ -16,12 +17,31 @@ type Validatable[O any] interface {
 type Validation[O Validatable[O]] struct {
 	Name     string
 	Validate Validate[O]
+	// DependsOn are the names of the validations that need to succeed before
+	// this one runs. Only used when running Concurrently.
+	DependsOn []string
+	// Timeout is the maximum time the validation can take when running Concurrently.
+	// If zero, the runner's validation timeout is used.
+	Timeout time.Duration
 }
 
 func New[O Validatable[O]](name string, validate Validate[O]) Validation[O] {
 	return Validation[O]{Name: name, Validate: validate}
 }
 
+// DependingOn returns a copy of the validation that only runs after the validations
+// with the given names succeed.
+func (v Validation[O]) DependingOn(names ...string) Validation[O] {
+	v.DependsOn = append(append([]string{}, v.DependsOn...), names...)
+	return v
+}
+
+// WithTimeout returns a copy of the validation that fails if it takes longer than timeout.
+func (v Validation[O]) WithTimeout(timeout time.Duration) Validation[O] {
+	v.Timeout = timeout
+	return v
+}
+
 // Validate is the logic for a validation of a type O.
 type Validate[O Validatable[O]] func(ctx context.Context, informer Informer, obj O) error
 
@@ -39,7 +59,8 @@ type Informer interface {
 
 // RunnerConfig holds the configuration for the Runner.
 type RunnerConfig struct {
-	skipValidations []string
+	skipValidations   []string
+	validationTimeout time.Duration
 }
 
 // RunnerOpt allows to configure the Runner.
@@ -53,6 +74,14 @@ func WithSkipValidations(namesToSkip ...string) RunnerOpt {
 	}
 }
 
+// WithValidationTimeout configures the maximum time each validation can take
+// when running Concurrently, unless the validation sets its own.
+func WithValidationTimeout(timeout time.Duration) RunnerOpt {
+	return func(c *RunnerConfig) {
+		c.validationTimeout = timeout
+	}
+}
+
 // NewRunner constructs a new Runner.
 func NewRunner[O Validatable[O]](informer Informer, opts ...RunnerOpt) *Runner[O] {
 	r := &Runner[O]{
@@ -76,7 +105,7 @@ func (r *Runner[O]) Register(validations ...Validation[O]) {
 }
 
 // Sequentially runs all validations one after the other and waits until they all finish,
-// aggregating the errors if present. obj must not be modified. If it is, this
+// aggregating the errors if present. Warnings are not returned. obj must not be modified. If it is, this
 // indicates a programming error and the method will panic.
 func (r *Runner[O]) Sequentially(ctx context.Context, obj O) error {
 	copyObj := obj.DeepCopy()
@@ -84,7 +113,7 @@ func (r *Runner[O]) Sequentially(ctx context.Context, obj O) error {
 
 	for _, validation := range r.validations {
 		err := validation.Validate(ctx, r.informer, copyObj)
-		if err != nil {
+		if err != nil && !IsWarning(err) {
 			errs = append(errs, Unwrap(err)...)
 		}
 	}
@@ -130,10 +159,11 @@ func Unwrap(err error) []error {
 }
 
 // UntilError returns a composed validate that runs all validations until one fails.
+// Warnings don't stop the remaining validations.
 func UntilError[O Validatable[O]](validates ...Validate[O]) Validate[O] {
 	return func(ctx context.Context, informer Informer, obj O) error {
 		for _, v := range validates {
-			if err := v(ctx, informer, obj); err != nil {
+			if err := v(ctx, informer, obj); err != nil && !IsWarning(err) {
 				return err
 			}
 		}
@@ -181,3 +181,28 @@ func newValidation(run func(ctx context.Context, informer validation.Informer, o
 		Validate: run,
 	}
 }
+
+func TestRunnerWarningsDontFail(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r := validation.NewRunner[*nodeConfig](validation.NoOpInformer{})
+
+	ran := false
+	r.Register(
+		newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+			return validation.NewWarning(errors.New("almost invalid"), "fix this")
+		}),
+		r.UntilError(
+			newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+				return validation.NewWarning(errors.New("almost invalid again"), "fix this too")
+			}),
+			newValidation(func(ctx context.Context, _ validation.Informer, _ *nodeConfig) error {
+				ran = true
+				return nil
+			}),
+		),
+	)
+
+	g.Expect(r.Sequentially(ctx, &nodeConfig{name: "my-node-1"})).To(Succeed())
+	g.Expect(ran).To(BeTrue())
+}